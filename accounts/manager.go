@@ -0,0 +1,293 @@
+// Package accounts manages the clients and account streams of multiple Bitvavo accounts,
+// with combined views over the balances and open orders of every account.
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+var (
+	errAccountExists = func(name string) error {
+		return fmt.Errorf("account '%s' already exists", name)
+	}
+	errAccountNotFound = func(name string) error {
+		return fmt.Errorf("account '%s' not found", name)
+	}
+)
+
+// Order is an open order of an account.
+type Order struct {
+	// The name of the account.
+	Account string
+
+	types.Order
+}
+
+type account struct {
+	credentials http.CredentialsProvider
+	client      http.HttpClientAuth
+
+	mu       sync.Mutex
+	wsclient ws.WsClient
+	handler  ws.AccountEventHandler
+}
+
+// Manager holds the credentials of multiple accounts by name. It's safe for concurrent use.
+//
+// Every account has its own http client (and rate limit) and its own websocket connection,
+// which is only opened once the account stream is requested.
+type Manager struct {
+	httpOptions []http.Option
+	wsOptions   []ws.Option
+
+	mu       sync.RWMutex
+	accounts map[string]*account
+}
+
+type Option func(*Manager)
+
+// The options of the http client of every account.
+// default: the default http client
+func WithHttpOptions(options ...http.Option) Option {
+	return func(m *Manager) {
+		m.httpOptions = options
+	}
+}
+
+// The options of the websocket client of every account.
+// default: the default websocket client
+func WithWsOptions(options ...ws.Option) Option {
+	return func(m *Manager) {
+		m.wsOptions = options
+	}
+}
+
+// New creates a manager without accounts.
+func New(options ...Option) *Manager {
+	manager := &Manager{accounts: make(map[string]*account)}
+	for _, opt := range options {
+		opt(manager)
+	}
+	return manager
+}
+
+// Add an account by name with apiKey and apiSecret.
+func (m *Manager) Add(name string, apiKey string, apiSecret string) error {
+	return m.AddWithCredentials(name, http.StaticCredentials(apiKey, apiSecret))
+}
+
+// AddWithCredentials is the same as Add, but the apiKey and apiSecret are provided by credentials.
+func (m *Manager) AddWithCredentials(name string, credentials http.CredentialsProvider) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, found := m.accounts[name]; found {
+		return errAccountExists(name)
+	}
+	m.accounts[name] = &account{
+		credentials: credentials,
+		client:      http.NewHttpClient(m.httpOptions...).ToAuthClientWithCredentials(credentials),
+	}
+	return nil
+}
+
+// Remove the account by name and close its websocket connection (if any)
+func (m *Manager) Remove(name string) error {
+	m.mu.Lock()
+	account, found := m.accounts[name]
+	delete(m.accounts, name)
+	m.mu.Unlock()
+
+	if !found {
+		return errAccountNotFound(name)
+	}
+	return account.close()
+}
+
+// Names returns the name of every account, sorted.
+func (m *Manager) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]string, 0, len(m.accounts))
+	for name := range m.accounts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Client returns the authenticated http client of the account by name.
+func (m *Manager) Client(name string) (http.HttpClientAuth, error) {
+	account, err := m.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return account.client, nil
+}
+
+// Account returns the account event handler of the account by name, which opens its websocket connection on first use.
+func (m *Manager) Account(name string) (ws.AccountEventHandler, error) {
+	account, err := m.get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	account.mu.Lock()
+	defer account.mu.Unlock()
+
+	if account.handler == nil {
+		wsclient, err := ws.NewWsClient(m.wsOptions...)
+		if err != nil {
+			return nil, err
+		}
+		account.wsclient = wsclient
+		account.handler = wsclient.AccountWithCredentials(account.credentials)
+	}
+	return account.handler, nil
+}
+
+// BalancesPerAccount returns the balances of every account by name, optionally filtered by symbol (e.g: ETH)
+func (m *Manager) BalancesPerAccount(ctx context.Context, symbol ...string) (map[string][]types.Balance, error) {
+	return collect(m, func(client http.HttpClientAuth) ([]types.Balance, error) {
+		return client.GetBalanceWithContext(ctx, symbol...)
+	})
+}
+
+// Balances returns the combined balances of every account per symbol, optionally filtered by symbol (e.g: ETH)
+func (m *Manager) Balances(ctx context.Context, symbol ...string) ([]types.Balance, error) {
+	perAccount, err := m.BalancesPerAccount(ctx, symbol...)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		balances = make([]types.Balance, 0)
+		index    = make(map[string]int)
+	)
+	for _, name := range sortedKeys(perAccount) {
+		for _, balance := range perAccount[name] {
+			i, found := index[balance.Symbol]
+			if !found {
+				index[balance.Symbol] = len(balances)
+				balances = append(balances, balance)
+				continue
+			}
+			balances[i].Available += balance.Available
+			balances[i].InOrder += balance.InOrder
+		}
+	}
+	return balances, nil
+}
+
+// OrdersOpen returns the open orders of every account, optionally filtered by market (e.g: ETH-EUR)
+func (m *Manager) OrdersOpen(ctx context.Context, market ...string) ([]Order, error) {
+	perAccount, err := collect(m, func(client http.HttpClientAuth) ([]types.Order, error) {
+		return client.GetOrdersOpenWithContext(ctx, market...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]Order, 0)
+	for _, name := range sortedKeys(perAccount) {
+		for _, order := range perAccount[name] {
+			orders = append(orders, Order{Account: name, Order: order})
+		}
+	}
+	return orders, nil
+}
+
+// Close the websocket connection of every account.
+func (m *Manager) Close() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	errs := make([]error, 0)
+	for name, account := range m.accounts {
+		if err := account.close(); err != nil {
+			errs = append(errs, fmt.Errorf("account '%s': %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Manager) get(name string) (*account, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	account, found := m.accounts[name]
+	if !found {
+		return nil, errAccountNotFound(name)
+	}
+	return account, nil
+}
+
+func (a *account) close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.wsclient == nil {
+		return nil
+	}
+	err := a.wsclient.Close()
+	a.wsclient = nil
+	a.handler = nil
+	return err
+}
+
+// collect calls fetch for every account concurrently, the error of every failed account is returned.
+func collect[T any](m *Manager, fetch func(client http.HttpClientAuth) ([]T, error)) (map[string][]T, error) {
+	m.mu.RLock()
+	clients := make(map[string]http.HttpClientAuth, len(m.accounts))
+	for name, account := range m.accounts {
+		clients[name] = account.client
+	}
+	m.mu.RUnlock()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string][]T, len(clients))
+		errs    = make([]error, 0)
+	)
+	for name, client := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, err := fetch(client)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("account '%s': %w", name, err))
+				return
+			}
+			results[name] = result
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return results, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,83 @@
+package alert
+
+import (
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+const defaultBuffSize = 50
+
+type config[T any] struct {
+	buffSize  int
+	callbacks []func(event T)
+}
+
+type Option[T any] func(*config[T])
+
+// The size of the buffer of the events channel, an event is dropped whenever the buffer is full.
+// default: 50
+func WithBuffSize[T any](buffSize int) Option[T] {
+	return func(c *config[T]) {
+		c.buffSize = buffSize
+	}
+}
+
+// Invoke callback for every event, callbacks are invoked synchronously so they should return quickly.
+func WithCallback[T any](callback func(event T)) Option[T] {
+	return func(c *config[T]) {
+		c.callbacks = append(c.callbacks, callback)
+	}
+}
+
+func newConfig[T any](options ...Option[T]) *config[T] {
+	config := &config[T]{buffSize: defaultBuffSize}
+	for _, opt := range options {
+		opt(config)
+	}
+	return config
+}
+
+// emitter sends events to a channel and invokes the callbacks.
+type emitter[T any] struct {
+	callbacks []func(event T)
+
+	mu      sync.Mutex
+	eventch chan T
+	closed  bool
+}
+
+func newEmitter[T any](config *config[T]) *emitter[T] {
+	return &emitter[T]{
+		callbacks: config.callbacks,
+		eventch:   make(chan T, config.buffSize),
+	}
+}
+
+func (e *emitter[T]) emit(event T) {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return
+	}
+	select {
+	case e.eventch <- event:
+	default:
+		log.Warn().Msg("Dropped event, the events channel is full")
+	}
+	e.mu.Unlock()
+
+	for _, callback := range e.callbacks {
+		callback(event)
+	}
+}
+
+func (e *emitter[T]) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.closed {
+		e.closed = true
+		close(e.eventch)
+	}
+}
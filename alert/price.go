@@ -0,0 +1,250 @@
+// Package alert watches the market data streams of the websocket and emits typed events whenever a rule matches
+// (e.g: a price crossing a level), on a channel and/or by invoking callbacks.
+package alert
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+type Condition string
+
+const (
+	// The price crosses above the level of the rule.
+	ConditionCrossAbove Condition = "crossAbove"
+
+	// The price crosses below the level of the rule.
+	ConditionCrossBelow Condition = "crossBelow"
+
+	// The price moves by at least the percentage of the rule within its window.
+	ConditionMove Condition = "move"
+)
+
+var errInvalidRule = func(reason string) error {
+	return fmt.Errorf("invalid rule: %s", reason)
+}
+
+type Rule struct {
+	// The market (e.g: ETH-EUR)
+	Market string
+
+	// The condition which triggers the alert.
+	Condition Condition
+
+	// The price level for ConditionCrossAbove and ConditionCrossBelow.
+	Price float64
+
+	// The move in percent for ConditionMove (e.g: 5 for a rise of 5% or -5 for a drop of 5%)
+	Percent float64
+
+	// The window in which the price has to move for ConditionMove.
+	Window time.Duration
+
+	// Remove the rule after it triggered once.
+	Once bool
+}
+
+func (r Rule) validate() error {
+	if r.Market == "" {
+		return errInvalidRule("market is required")
+	}
+	switch r.Condition {
+	case ConditionCrossAbove, ConditionCrossBelow:
+		if r.Price <= 0 {
+			return errInvalidRule("price must be greater than 0")
+		}
+	case ConditionMove:
+		if r.Percent == 0 {
+			return errInvalidRule("percent can't be 0")
+		}
+		if r.Window <= 0 {
+			return errInvalidRule("window must be greater than 0")
+		}
+	default:
+		return errInvalidRule(fmt.Sprintf("unknown condition %s", r.Condition))
+	}
+	return nil
+}
+
+type PriceAlert struct {
+	// The id of the rule as returned by Add.
+	RuleId int
+
+	// The rule which triggered.
+	Rule Rule
+
+	// The price which triggered the rule.
+	Price float64
+
+	// The price before the cross, or the price at the start of the window for ConditionMove.
+	Reference float64
+
+	// The change in percent from the reference price to the price.
+	Change float64
+
+	// The time the rule triggered.
+	Time time.Time
+}
+
+// rule is a registered rule and its state.
+type rule struct {
+	id   int
+	rule Rule
+
+	last    float64
+	samples []sample
+}
+
+type sample struct {
+	price float64
+	time  time.Time
+}
+
+// PriceMonitor checks the rules against the last price of every market. It's safe for concurrent use.
+type PriceMonitor struct {
+	emitter *emitter[PriceAlert]
+	now     func() time.Time
+
+	mu     sync.Mutex
+	nextId int
+	rules  map[int]*rule
+}
+
+// NewPriceMonitor creates a monitor without rules.
+func NewPriceMonitor(options ...Option[PriceAlert]) *PriceMonitor {
+	return &PriceMonitor{
+		emitter: newEmitter(newConfig(options...)),
+		now:     time.Now,
+		rules:   make(map[int]*rule),
+	}
+}
+
+// Add registers rule and returns its id, which can be used to remove it.
+func (m *PriceMonitor) Add(r Rule) (int, error) {
+	if err := r.validate(); err != nil {
+		return 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextId++
+	m.rules[m.nextId] = &rule{id: m.nextId, rule: r}
+
+	return m.nextId, nil
+}
+
+// Remove removes the rule with id, false if it doesn't exist.
+func (m *PriceMonitor) Remove(id int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, found := m.rules[id]
+	delete(m.rules, id)
+	return found
+}
+
+// Rules returns the registered rules by id.
+func (m *PriceMonitor) Rules() map[int]Rule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rules := make(map[int]Rule, len(m.rules))
+	for id, r := range m.rules {
+		rules[id] = r.rule
+	}
+	return rules
+}
+
+// Alerts returns the channel which receives every alert, it's closed by Close.
+func (m *PriceMonitor) Alerts() <-chan PriceAlert {
+	return m.emitter.eventch
+}
+
+// Consume checks the rules against the last price of every event of tickerchn until it's closed.
+func (m *PriceMonitor) Consume(tickerchn <-chan ws.TickerEvent) {
+	for event := range tickerchn {
+		// the last price is only sent when it has changed
+		if event.Ticker.LastPriceStr != "" {
+			m.Update(event.Market, event.Ticker.LastPrice)
+		}
+	}
+}
+
+// Update checks the rules of market against price (e.g: a price from another source than the ticker)
+func (m *PriceMonitor) Update(market string, price float64) {
+	now := m.now()
+
+	m.mu.Lock()
+	ids := make([]int, 0)
+	for id, r := range m.rules {
+		if r.rule.Market == market {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	alerts := make([]PriceAlert, 0)
+	for _, id := range ids {
+		r := m.rules[id]
+		if alert, triggered := r.check(price, now); triggered {
+			alerts = append(alerts, alert)
+			if r.rule.Once {
+				delete(m.rules, id)
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	for _, alert := range alerts {
+		m.emitter.emit(alert)
+	}
+}
+
+// Close closes the alerts channel, prices are ignored afterwards.
+func (m *PriceMonitor) Close() error {
+	m.emitter.close()
+	return nil
+}
+
+func (r *rule) check(price float64, now time.Time) (PriceAlert, bool) {
+	alert := PriceAlert{RuleId: r.id, Rule: r.rule, Price: price, Time: now}
+
+	switch r.rule.Condition {
+	case ConditionCrossAbove, ConditionCrossBelow:
+		last := r.last
+		r.last = price
+		if last == 0 {
+			return PriceAlert{}, false
+		}
+
+		crossed := r.rule.Condition == ConditionCrossAbove && last < r.rule.Price && price >= r.rule.Price ||
+			r.rule.Condition == ConditionCrossBelow && last > r.rule.Price && price <= r.rule.Price
+		if !crossed {
+			return PriceAlert{}, false
+		}
+		alert.Reference = last
+	case ConditionMove:
+		r.samples = append(r.samples, sample{price: price, time: now})
+		for len(r.samples) > 1 && now.Sub(r.samples[0].time) > r.rule.Window {
+			r.samples = r.samples[1:]
+		}
+
+		reference := r.samples[0].price
+		change := (price - reference) / reference * 100
+		if math.Signbit(change) != math.Signbit(r.rule.Percent) || math.Abs(change) < math.Abs(r.rule.Percent) {
+			return PriceAlert{}, false
+		}
+		// the window starts over, so the same move doesn't trigger again
+		r.samples = []sample{{price: price, time: now}}
+		alert.Reference = reference
+	}
+
+	alert.Change = (price - alert.Reference) / alert.Reference * 100
+	return alert, true
+}
@@ -0,0 +1,184 @@
+package alert
+
+import (
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/orderbook"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+type SpreadThreshold struct {
+	// Alert whenever the spread in percent of the mid price rises above this value (e.g: 0.5 for 0.5%)
+	Above float64
+
+	// Alert whenever the spread in percent of the mid price falls below this value after it was above Above,
+	// 0 to use Above as well. A lower value than Above prevents alerts on every tick around the threshold.
+	Below float64
+}
+
+type SpreadAlert struct {
+	// The market (e.g: ETH-EUR)
+	Market string
+
+	// The best (highest) bid.
+	Bid float64
+
+	// The best (lowest) ask.
+	Ask float64
+
+	// The spread in quote currency (ask - bid)
+	Spread float64
+
+	// The spread in percent of the mid price.
+	Percent float64
+
+	// True if the spread rose above the threshold, false if it fell below it again.
+	Wide bool
+
+	// The time the threshold was crossed.
+	Time time.Time
+}
+
+type spreadState struct {
+	threshold SpreadThreshold
+	bid       float64
+	ask       float64
+	wide      bool
+}
+
+// SpreadMonitor tracks the spread of markets and alerts whenever it crosses the threshold of a market,
+// so illiquid conditions can be detected before trading. It's safe for concurrent use.
+type SpreadMonitor struct {
+	emitter *emitter[SpreadAlert]
+	now     func() time.Time
+
+	mu     sync.Mutex
+	states map[string]*spreadState
+}
+
+// NewSpreadMonitor creates a monitor without markets.
+func NewSpreadMonitor(options ...Option[SpreadAlert]) *SpreadMonitor {
+	return &SpreadMonitor{
+		emitter: newEmitter(newConfig(options...)),
+		now:     time.Now,
+		states:  make(map[string]*spreadState),
+	}
+}
+
+// Watch starts (or updates) tracking the spread of market (e.g: ETH-EUR) with threshold.
+func (m *SpreadMonitor) Watch(market string, threshold SpreadThreshold) error {
+	if market == "" {
+		return errInvalidRule("market is required")
+	}
+	if threshold.Above <= 0 {
+		return errInvalidRule("above must be greater than 0")
+	}
+	if threshold.Below > threshold.Above {
+		return errInvalidRule("below can't be greater than above")
+	}
+	if threshold.Below == 0 {
+		threshold.Below = threshold.Above
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if state, found := m.states[market]; found {
+		state.threshold = threshold
+	} else {
+		m.states[market] = &spreadState{threshold: threshold}
+	}
+	return nil
+}
+
+// Unwatch stops tracking the spread of market, false if it wasn't tracked.
+func (m *SpreadMonitor) Unwatch(market string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, found := m.states[market]
+	delete(m.states, market)
+	return found
+}
+
+// Alerts returns the channel which receives every alert, it's closed by Close.
+func (m *SpreadMonitor) Alerts() <-chan SpreadAlert {
+	return m.emitter.eventch
+}
+
+// Consume updates the spread with the best bid and ask of every event of tickerchn until it's closed.
+func (m *SpreadMonitor) Consume(tickerchn <-chan ws.TickerEvent) {
+	for event := range tickerchn {
+		// the best bid and ask are only sent when they have changed
+		m.update(event.Market, event.Ticker.BestBid, event.Ticker.BestBidStr != "", event.Ticker.BestAsk, event.Ticker.BestAskStr != "")
+	}
+}
+
+// UpdateBook updates the spread with the best bid and ask of book, call it after every update of the book.
+func (m *SpreadMonitor) UpdateBook(book *orderbook.Book) {
+	bid, found := book.BestBid()
+	if !found {
+		return
+	}
+	ask, found := book.BestAsk()
+	if !found {
+		return
+	}
+	m.Update(book.Market(), bid.Price, ask.Price)
+}
+
+// Update updates the spread of market with the best bid and ask.
+func (m *SpreadMonitor) Update(market string, bid float64, ask float64) {
+	m.update(market, bid, true, ask, true)
+}
+
+// Close closes the alerts channel, updates are ignored afterwards.
+func (m *SpreadMonitor) Close() error {
+	m.emitter.close()
+	return nil
+}
+
+func (m *SpreadMonitor) update(market string, bid float64, hasBid bool, ask float64, hasAsk bool) {
+	m.mu.Lock()
+	state, found := m.states[market]
+	if !found {
+		m.mu.Unlock()
+		return
+	}
+	if hasBid {
+		state.bid = bid
+	}
+	if hasAsk {
+		state.ask = ask
+	}
+	if state.bid <= 0 || state.ask <= 0 {
+		m.mu.Unlock()
+		return
+	}
+
+	var (
+		spread  = state.ask - state.bid
+		percent = spread / ((state.ask + state.bid) / 2) * 100
+		wide    = state.wide
+	)
+	if !state.wide && percent > state.threshold.Above {
+		state.wide = true
+	} else if state.wide && percent < state.threshold.Below {
+		state.wide = false
+	}
+	alert := SpreadAlert{
+		Market:  market,
+		Bid:     state.bid,
+		Ask:     state.ask,
+		Spread:  spread,
+		Percent: percent,
+		Wide:    state.wide,
+		Time:    m.now(),
+	}
+	m.mu.Unlock()
+
+	if alert.Wide != wide {
+		m.emitter.emit(alert)
+	}
+}
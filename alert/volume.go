@@ -0,0 +1,171 @@
+package alert
+
+import (
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+type VolumeSpike struct {
+	// The market (e.g: ETH-EUR)
+	Market string
+
+	// The volume in base currency of the current window.
+	Volume float64
+
+	// The volume in quote currency of the current window.
+	VolumeQuote float64
+
+	// The number of trades in the current window.
+	Trades int
+
+	// The average volume in base currency of the previous windows.
+	Baseline float64
+
+	// The volume divided by the baseline.
+	Ratio float64
+
+	// The start of the current window.
+	WindowStart time.Time
+
+	// The time of the trade which exceeded the baseline.
+	Time time.Time
+}
+
+type volumeState struct {
+	start   int64
+	volume  float64
+	quote   float64
+	trades  int
+	history []float64
+	spiked  bool
+}
+
+// VolumeDetector keeps the trade volume of every market in windows of a fixed length and alerts whenever
+// the volume of the current window exceeds a multiple of the average volume of the previous windows.
+// It's safe for concurrent use.
+//
+// The windows are aligned to the timestamps of the trades, a market is only checked once its baseline is complete
+// and alerts at most once per window.
+type VolumeDetector struct {
+	emitter  *emitter[VolumeSpike]
+	window   int64
+	baseline int
+	multiple float64
+
+	mu     sync.Mutex
+	states map[string]*volumeState
+}
+
+// NewVolumeDetector creates a detector with windows of window (e.g: 1m) which alerts whenever the volume of
+// the current window exceeds multiple (e.g: 3) times the average of the previous baseline (e.g: 30) windows.
+func NewVolumeDetector(window time.Duration, baseline int, multiple float64, options ...Option[VolumeSpike]) (*VolumeDetector, error) {
+	if window < time.Millisecond {
+		return nil, errInvalidRule("window must be at least 1ms")
+	}
+	if baseline <= 0 {
+		return nil, errInvalidRule("baseline must be greater than 0")
+	}
+	if multiple <= 0 {
+		return nil, errInvalidRule("multiple must be greater than 0")
+	}
+
+	return &VolumeDetector{
+		emitter:  newEmitter(newConfig(options...)),
+		window:   window.Milliseconds(),
+		baseline: baseline,
+		multiple: multiple,
+		states:   make(map[string]*volumeState),
+	}, nil
+}
+
+// Alerts returns the channel which receives every spike, it's closed by Close.
+func (d *VolumeDetector) Alerts() <-chan VolumeSpike {
+	return d.emitter.eventch
+}
+
+// Consume adds every trade of tradechn until it's closed.
+func (d *VolumeDetector) Consume(tradechn <-chan ws.TradesEvent) {
+	for event := range tradechn {
+		d.Add(event.Market, event.Trade)
+	}
+}
+
+// Add adds trade of market to its current window, trades older than the current window are ignored.
+func (d *VolumeDetector) Add(market string, trade types.Trade) {
+	d.mu.Lock()
+	state, found := d.states[market]
+	if !found {
+		state = &volumeState{start: trade.Timestamp - trade.Timestamp%d.window}
+		d.states[market] = state
+	}
+
+	start := trade.Timestamp - trade.Timestamp%d.window
+	if start < state.start {
+		d.mu.Unlock()
+		return
+	}
+	if start > state.start {
+		d.roll(state, start)
+	}
+
+	state.volume += trade.Amount
+	state.quote += trade.Amount * trade.Price
+	state.trades++
+
+	if state.spiked || len(state.history) < d.baseline {
+		d.mu.Unlock()
+		return
+	}
+
+	var baseline float64
+	for _, volume := range state.history {
+		baseline += volume
+	}
+	baseline /= float64(len(state.history))
+
+	if baseline <= 0 || state.volume <= baseline*d.multiple {
+		d.mu.Unlock()
+		return
+	}
+	state.spiked = true
+
+	spike := VolumeSpike{
+		Market:      market,
+		Volume:      state.volume,
+		VolumeQuote: state.quote,
+		Trades:      state.trades,
+		Baseline:    baseline,
+		Ratio:       state.volume / baseline,
+		WindowStart: time.UnixMilli(state.start),
+		Time:        trade.Time(),
+	}
+	d.mu.Unlock()
+
+	d.emitter.emit(spike)
+}
+
+// Close closes the alerts channel, trades are ignored afterwards.
+func (d *VolumeDetector) Close() error {
+	d.emitter.close()
+	return nil
+}
+
+// roll completes the current window of state and starts the window at start, windows without trades have a volume of 0.
+func (d *VolumeDetector) roll(state *volumeState, start int64) {
+	state.history = append(state.history, state.volume)
+	for missing := (start-state.start)/d.window - 1; missing > 0 && len(state.history) <= d.baseline; missing-- {
+		state.history = append(state.history, 0)
+	}
+	if len(state.history) > d.baseline {
+		state.history = state.history[len(state.history)-d.baseline:]
+	}
+
+	state.start = start
+	state.volume = 0
+	state.quote = 0
+	state.trades = 0
+	state.spiked = false
+}
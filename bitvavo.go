@@ -23,6 +23,6 @@ func NewWsClient(options ...ws.Option) (ws.WsClient, error) {
 // NewHttpClient creates a new Bitvavo HTTP client to make unauthenticated requests.
 //
 // For authenticated requests, call ToAuthClient func on this HttpClient
-func NewHttpClient() http.HttpClient {
-	return http.NewHttpClient()
+func NewHttpClient(options ...http.Option) http.HttpClient {
+	return http.NewHttpClient(options...)
 }
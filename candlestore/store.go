@@ -0,0 +1,262 @@
+// Package candlestore keeps a rolling window of candles per market and interval, fed by the websocket
+// and backfilled with the REST api whenever candles are missing, so indicators and charts always get a gapless series.
+package candlestore
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+var errIntervalNotSupported = func(interval types.Interval) error {
+	return fmt.Errorf("interval %s is not supported, the length of its periods isn't fixed", interval)
+}
+
+// Store keeps the latest candles of every tracked market and interval. It's safe for concurrent use.
+//
+// Bitvavo doesn't return candles for periods without trades, those periods are filled with a flat candle
+// (open, high, low and close are the previous close and the volume is 0), so the series never has gaps.
+type Store struct {
+	client  http.HttpClient
+	candles ws.CandlesEventHandler
+	size    int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.RWMutex
+	series map[string]*series
+}
+
+type series struct {
+	market   string
+	interval types.Interval
+	duration time.Duration
+	candles  []types.Candle
+}
+
+// New creates a store which keeps the last size candles of every tracked market and interval,
+// client is used to backfill the candles and candles (if not nil) to receive the live candles.
+func New(client http.HttpClient, candles ws.CandlesEventHandler, size int) *Store {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Store{
+		client:  client,
+		candles: candles,
+		size:    size,
+		ctx:     ctx,
+		cancel:  cancel,
+		series:  make(map[string]*series),
+	}
+}
+
+// Track backfills the last candles of market (e.g: ETH-EUR) with interval (e.g: 5m) and subscribes to its live candles.
+func (s *Store) Track(ctx context.Context, market string, interval types.Interval) error {
+	if interval == types.Interval1M {
+		return errIntervalNotSupported(interval)
+	}
+	if err := interval.Validate(); err != nil {
+		return err
+	}
+	duration := interval.Duration()
+
+	key := seriesKey(market, interval)
+	s.mu.Lock()
+	if _, found := s.series[key]; found {
+		s.mu.Unlock()
+		return fmt.Errorf("already tracking %s with interval %s", market, interval)
+	}
+	series := &series{market: market, interval: interval, duration: duration}
+	s.series[key] = series
+	s.mu.Unlock()
+
+	var (
+		end   = time.Now().Truncate(duration).Add(duration)
+		start = end.Add(-duration * time.Duration(s.size))
+	)
+	if err := s.backfill(ctx, series, start, end); err != nil {
+		s.untrack(key)
+		return err
+	}
+
+	if s.candles == nil {
+		return nil
+	}
+
+	candlechn, err := s.candles.Subscribe([]string{market}, interval)
+	if err != nil {
+		s.untrack(key)
+		return err
+	}
+
+	s.wg.Add(1)
+	go s.consume(series, candlechn)
+
+	return nil
+}
+
+// Candles returns the candles of market with interval sorted by time (oldest first), false if it isn't tracked.
+func (s *Store) Candles(market string, interval types.Interval) ([]types.Candle, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	series, found := s.series[seriesKey(market, interval)]
+	if !found {
+		return nil, false
+	}
+	return slices.Clone(series.candles), true
+}
+
+// Last returns the last n candles of market with interval sorted by time (oldest first), false if it isn't tracked.
+func (s *Store) Last(market string, interval types.Interval, n int) ([]types.Candle, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	series, found := s.series[seriesKey(market, interval)]
+	if !found {
+		return nil, false
+	}
+	return slices.Clone(series.candles[max(0, len(series.candles)-n):]), true
+}
+
+// Close unsubscribes every tracked market and waits until the store has stopped.
+func (s *Store) Close() error {
+	s.mu.RLock()
+	all := make([]*series, 0, len(s.series))
+	for _, series := range s.series {
+		all = append(all, series)
+	}
+	s.mu.RUnlock()
+
+	var err error
+	if s.candles != nil {
+		for _, series := range all {
+			err = errors.Join(err, s.candles.Unsubscribe([]string{series.market}, series.interval))
+		}
+	}
+	s.cancel()
+	s.wg.Wait()
+
+	return err
+}
+
+func (s *Store) consume(series *series, candlechn <-chan ws.CandlesEvent) {
+	defer s.wg.Done()
+
+	for event := range candlechn {
+		s.mu.RLock()
+		var last int64
+		if len(series.candles) > 0 {
+			last = series.candles[len(series.candles)-1].Timestamp
+		}
+		s.mu.RUnlock()
+
+		// candles have been missed (e.g: during a reconnect)
+		if next := last + series.duration.Milliseconds(); last > 0 && event.Candle.Timestamp > next {
+			if err := s.backfill(s.ctx, series, time.UnixMilli(next), event.Candle.Time()); err != nil && s.ctx.Err() == nil {
+				log.Warn().Err(err).Str("market", series.market).Str("interval", series.interval.String()).Msg("failed to backfill missing candles")
+			}
+		}
+
+		s.mu.Lock()
+		series.upsert(event.Candle)
+		series.fillGaps()
+		series.trim(s.size)
+		s.mu.Unlock()
+	}
+}
+
+// backfill requests the candles of series between start and end and adds them to the series.
+func (s *Store) backfill(ctx context.Context, series *series, start time.Time, end time.Time) error {
+	candles, err := s.client.GetCandlesRangeWithContext(ctx, series.market, series.interval, start, end)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, candle := range candles {
+		series.upsert(candle)
+	}
+	series.fillGaps()
+	series.trim(s.size)
+
+	return nil
+}
+
+func (s *Store) untrack(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.series, key)
+}
+
+// upsert adds candle or replaces the candle with the same timestamp, the candles stay sorted by time.
+func (s *series) upsert(candle types.Candle) {
+	index, found := slices.BinarySearchFunc(s.candles, candle.Timestamp, func(c types.Candle, timestamp int64) int {
+		return cmp.Compare(c.Timestamp, timestamp)
+	})
+	if found {
+		s.candles[index] = candle
+		return
+	}
+	s.candles = slices.Insert(s.candles, index, candle)
+}
+
+// fillGaps inserts a flat candle for every period without a candle between the first and the last candle.
+func (s *series) fillGaps() {
+	if len(s.candles) < 2 {
+		return
+	}
+
+	step := s.duration.Milliseconds()
+	filled := make([]types.Candle, 0, len(s.candles))
+	for i, candle := range s.candles {
+		if i > 0 {
+			previous := filled[len(filled)-1]
+			for timestamp := previous.Timestamp + step; timestamp < candle.Timestamp; timestamp += step {
+				filled = append(filled, flatCandle(timestamp, previous))
+			}
+		}
+		filled = append(filled, candle)
+	}
+	s.candles = filled
+}
+
+// trim removes the oldest candles so at most size candles are kept.
+func (s *series) trim(size int) {
+	if len(s.candles) > size {
+		s.candles = slices.Delete(s.candles, 0, len(s.candles)-size)
+	}
+}
+
+// flatCandle returns a candle at timestamp without trades, every price is the close of previous.
+func flatCandle(timestamp int64, previous types.Candle) types.Candle {
+	return types.Candle{
+		Timestamp: timestamp,
+		Open:      previous.Close,
+		High:      previous.Close,
+		Low:       previous.Close,
+		Close:     previous.Close,
+		OpenStr:   previous.CloseStr,
+		HighStr:   previous.CloseStr,
+		LowStr:    previous.CloseStr,
+		CloseStr:  previous.CloseStr,
+		VolumeStr: "0",
+	}
+}
+
+func seriesKey(market string, interval types.Interval) string {
+	return fmt.Sprintf("%s_%s", market, interval)
+}
@@ -0,0 +1,147 @@
+package bitvavo
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+var (
+	errNoCredentials = errors.New("no credentials, use WithCredentials or WithCredentialsProvider")
+	errClientClosed  = errors.New("client is closed")
+)
+
+// Client combines the HTTP and the Websocket API of Bitvavo with shared credentials and configuration.
+//
+// The websocket connects on first use and shares the HTTP client (and its rate limit) for book snapshots.
+type Client struct {
+	http        http.HttpClient
+	auth        http.HttpClientAuth
+	credentials http.CredentialsProvider
+	wsOptions   []ws.Option
+
+	mu     sync.Mutex
+	ws     ws.WsClient
+	closed bool
+}
+
+type clientConfig struct {
+	credentials  http.CredentialsProvider
+	windowTimeMs []uint64
+	httpOptions  []http.Option
+	wsOptions    []ws.Option
+}
+
+type Option func(*clientConfig)
+
+// The apiKey and apiSecret for the authenticated HTTP requests and the account websocket subscription.
+// default: no credentials
+func WithCredentials(apiKey string, apiSecret string) Option {
+	return WithCredentialsProvider(http.StaticCredentials(apiKey, apiSecret))
+}
+
+// The provider of the credentials for the authenticated HTTP requests and the account websocket subscription.
+// default: no credentials
+func WithCredentialsProvider(credentials http.CredentialsProvider, windowTimeMs ...uint64) Option {
+	return func(c *clientConfig) {
+		c.credentials = credentials
+		c.windowTimeMs = windowTimeMs
+	}
+}
+
+// The options of the HTTP client.
+// default: no options
+func WithHttpOptions(options ...http.Option) Option {
+	return func(c *clientConfig) {
+		c.httpOptions = append(c.httpOptions, options...)
+	}
+}
+
+// The options of the websocket client.
+// default: no options
+func WithWsOptions(options ...ws.Option) Option {
+	return func(c *clientConfig) {
+		c.wsOptions = append(c.wsOptions, options...)
+	}
+}
+
+// NewClient creates a client for both the HTTP and the Websocket API of Bitvavo.
+func NewClient(options ...Option) *Client {
+	config := &clientConfig{}
+	for _, opt := range options {
+		opt(config)
+	}
+
+	client := &Client{
+		http:        http.NewHttpClient(config.httpOptions...),
+		credentials: config.credentials,
+	}
+	if client.credentials != nil {
+		client.auth = client.http.ToAuthClientWithCredentials(client.credentials, config.windowTimeMs...)
+	}
+	client.wsOptions = append([]ws.Option{ws.WithHttpClient(client.http)}, config.wsOptions...)
+
+	return client
+}
+
+// Http returns the client for unauthenticated HTTP requests.
+func (c *Client) Http() http.HttpClient {
+	return c.http
+}
+
+// Auth returns the client for authenticated HTTP requests, it returns an error without credentials.
+func (c *Client) Auth() (http.HttpClientAuth, error) {
+	if c.auth == nil {
+		return nil, errNoCredentials
+	}
+	return c.auth, nil
+}
+
+// Ws returns the websocket client, it connects on the first call.
+func (c *Client) Ws() (ws.WsClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, errClientClosed
+	}
+	if c.ws == nil {
+		wsclient, err := ws.NewWsClient(c.wsOptions...)
+		if err != nil {
+			return nil, err
+		}
+		c.ws = wsclient
+	}
+	return c.ws, nil
+}
+
+// Account returns the account event handler of the websocket with the credentials of the client.
+func (c *Client) Account() (ws.AccountEventHandler, error) {
+	if c.credentials == nil {
+		return nil, errNoCredentials
+	}
+
+	wsclient, err := c.Ws()
+	if err != nil {
+		return nil, err
+	}
+	return wsclient.AccountWithCredentials(c.credentials), nil
+}
+
+// Close closes the websocket client, if it's connected.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	if c.ws == nil {
+		return nil
+	}
+	return c.ws.Close()
+}
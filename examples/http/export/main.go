@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2"
+	"github.com/larscom/go-bitvavo/v2/export"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// Exports candles or public trades as CSV, e.g:
+//
+//	go run ./http/export -market ETH-EUR -interval 1h -start 2024-01-01 -end 2024-02-01 -out eth-eur.csv
+//	go run ./http/export -market ETH-EUR -trades -start 2024-01-01T12:00:00Z -end 2024-01-01T13:00:00Z
+func main() {
+	var (
+		market     = flag.String("market", "ETH-EUR", "the market to export")
+		interval   = flag.String("interval", "1h", "the interval of the candles")
+		trades     = flag.Bool("trades", false, "export the public trades instead of the candles")
+		start      = flag.String("start", "", "the start of the range (e.g: 2024-01-01 or 2024-01-01T12:00:00Z)")
+		end        = flag.String("end", "", "the end of the range, default: now")
+		columns    = flag.String("columns", "", "comma separated columns, default: all columns")
+		timeFormat = flag.String("time-format", "", "the layout of the timestamps (e.g: 2006-01-02T15:04:05Z07:00), default: milliseconds")
+		out        = flag.String("out", "", "the file to write to, default: stdout")
+	)
+	flag.Parse()
+
+	from, err := parseTime(*start, time.Time{})
+	if err != nil || from.IsZero() {
+		log.Fatalf("invalid start: %q", *start)
+	}
+	to, err := parseTime(*end, time.Now())
+	if err != nil {
+		log.Fatalf("invalid end: %q", *end)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	options := []export.Option{export.WithTimeFormat(*timeFormat)}
+	if *columns != "" {
+		options = append(options, export.WithColumns(strings.Split(*columns, ",")...))
+	}
+
+	var (
+		client = bitvavo.NewHttpClient()
+		n      int
+	)
+	if *trades {
+		n, err = export.Trades(context.Background(), client, w, *market, from, to, options...)
+	} else {
+		n, err = export.Candles(context.Background(), client, w, *market, types.Interval(*interval), from, to, options...)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("exported %d rows", n)
+}
+
+func parseTime(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -7,16 +7,14 @@ import (
 )
 
 func main() {
-	markets, err := bitvavo.NewHttpClient().GetMarkets()
+	markets, err := bitvavo.NewHttpClient().GetTradingMarkets()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	tradingMarkets := make([]string, 0)
-	for _, market := range markets {
-		if market.Status == "trading" {
-			tradingMarkets = append(tradingMarkets, market.Market)
-		}
+	tradingMarkets := make([]string, len(markets))
+	for i, market := range markets {
+		tradingMarkets[i] = market.Market
 	}
 
 	ws, err := bitvavo.NewWsClient()
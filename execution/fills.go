@@ -0,0 +1,186 @@
+package execution
+
+import (
+	"sync"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+// fillTracker collects the fills and the status of the orders placed by an executor.
+//
+// Fills are deduplicated by their id, because the same fill can be received from the order response,
+// the account stream and the order itself. Events which arrive before their order is tracked are kept,
+// so an event of the account stream which is faster than the response of the order isn't lost.
+type fillTracker struct {
+	mu       sync.Mutex
+	tracked  map[string]bool
+	statuses map[string]types.OrderStatus
+	fills    map[string]types.Fill
+
+	// receives a value whenever an order or fill has been updated, so an executor can react immediately.
+	changed chan struct{}
+}
+
+func newFillTracker() *fillTracker {
+	return &fillTracker{
+		tracked:  make(map[string]bool),
+		statuses: make(map[string]types.OrderStatus),
+		fills:    make(map[string]types.Fill),
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+// track starts counting the fills of order, including the fills it already has.
+func (f *fillTracker) track(order types.Order) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.tracked[order.OrderId] = true
+	f.updateLocked(order)
+}
+
+// update updates the status and the fills of order (e.g: an order event or the result of GetOrder)
+func (f *fillTracker) update(order types.Order) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.updateLocked(order)
+}
+
+func (f *fillTracker) updateLocked(order types.Order) {
+	if status, found := f.statuses[order.OrderId]; !found || isOpen(status) {
+		f.statuses[order.OrderId] = order.Status
+	}
+	for _, fill := range order.Fills {
+		f.addLocked(fill)
+	}
+	f.notify()
+}
+
+func (f *fillTracker) add(fill types.Fill) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.addLocked(fill)
+}
+
+func (f *fillTracker) addLocked(fill types.Fill) {
+	if fill.FillId == "" {
+		return
+	}
+	f.fills[fill.FillId] = fill
+	f.notify()
+}
+
+// notify signals changed without blocking, a pending signal covers every change since.
+func (f *fillTracker) notify() {
+	select {
+	case f.changed <- struct{}{}:
+	default:
+	}
+}
+
+// totals returns the filled amount in base currency, in quote currency and the fee paid of the tracked orders.
+func (f *fillTracker) totals() (amount float64, amountQuote float64, fee float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, fill := range f.fills {
+		if !f.tracked[fill.OrderId] {
+			continue
+		}
+		amount += fill.Amount
+		amountQuote += fill.Amount * fill.Price
+		fee += fill.Fee
+	}
+	return amount, amountQuote, fee
+}
+
+// position returns the filled amount in base currency of the tracked orders, positive for buys and negative for sells.
+func (f *fillTracker) position() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var position float64
+	for _, fill := range f.fills {
+		if !f.tracked[fill.OrderId] {
+			continue
+		}
+		if fill.Side == types.SideSell {
+			position -= fill.Amount
+		} else {
+			position += fill.Amount
+		}
+	}
+	return position
+}
+
+// filled returns the filled amount in base currency of orderId.
+func (f *fillTracker) filled(orderId string) float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var amount float64
+	for _, fill := range f.fills {
+		if fill.OrderId == orderId {
+			amount += fill.Amount
+		}
+	}
+	return amount
+}
+
+// isOpen returns true if orderId is tracked and can still be filled.
+func (f *fillTracker) isOpen(orderId string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.tracked[orderId] && isOpen(f.statuses[orderId])
+}
+
+// open returns the ids of the tracked orders which can still be filled.
+func (f *fillTracker) open() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	orderIds := make([]string, 0)
+	for orderId := range f.tracked {
+		if isOpen(f.statuses[orderId]) {
+			orderIds = append(orderIds, orderId)
+		}
+	}
+	return orderIds
+}
+
+// watch subscribes to the account stream of market and updates the tracker with every order and fill event
+// until the returned func is called, which unsubscribes again. Does nothing if account is nil.
+func (f *fillTracker) watch(account ws.AccountEventHandler, market string) (func() error, error) {
+	if account == nil {
+		return func() error { return nil }, nil
+	}
+
+	orderchn, fillchn, err := account.Subscribe([]string{market})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for event := range orderchn {
+			f.update(event.Order)
+		}
+	}()
+	go func() {
+		for event := range fillchn {
+			f.add(event.Fill)
+		}
+	}()
+
+	return func() error {
+		return account.Unsubscribe([]string{market})
+	}, nil
+}
+
+// isOpen returns true if an order with status can still be filled.
+func isOpen(status types.OrderStatus) bool {
+	return status == types.StatusNew || status == types.StatusPartiallyFilled || status == types.StatusAwaitingTrigger
+}
@@ -0,0 +1,214 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+// OCO (one-cancels-other) places a take-profit and a stop-loss order for the same amount,
+// as soon as one of them is filled the other one is canceled.
+//
+// Bitvavo doesn't support OCO orders, so it's emulated: both orders are placed on the exchange and each
+// reserves Amount of your balance until it's filled or canceled.
+type OCO struct {
+	// The market to trade (e.g: ETH-EUR)
+	Market string
+
+	// The side of both orders (e.g: sell to close a long position)
+	Side types.Side
+
+	// The amount of both orders in base currency.
+	Amount float64
+
+	// The price of the take-profit order, which is placed as a limit order.
+	TakeProfitPrice float64
+
+	// The price which triggers the stop-loss order.
+	StopLossPrice float64
+
+	// The limit price of the stop-loss order once it's triggered, a market order is placed if 0.
+	StopLossLimitPrice float64
+}
+
+// OCOResult is the outcome of an OCO.
+type OCOResult struct {
+	// The order id of the take-profit order.
+	TakeProfitId string
+
+	// The order id of the stop-loss order.
+	StopLossId string
+
+	// The filled amount in base currency of the take-profit order.
+	TakeProfitFilled float64
+
+	// The filled amount in base currency of the stop-loss order.
+	StopLossFilled float64
+}
+
+func (o OCO) validate() error {
+	if o.Market == "" {
+		return errors.New("market is required")
+	}
+	if o.Side != types.SideBuy && o.Side != types.SideSell {
+		return fmt.Errorf("invalid side: %s", o.Side)
+	}
+	if o.Amount <= 0 {
+		return errors.New("amount must be greater than 0")
+	}
+	if o.TakeProfitPrice <= 0 || o.StopLossPrice <= 0 {
+		return errors.New("take-profit price and stop-loss price must be greater than 0")
+	}
+	return nil
+}
+
+// Execute places both orders using client and returns after both are closed.
+//
+// The fills are monitored with the account stream of account, which is subscribed to the market during the execution,
+// so account can't have a subscription to the market already. If account is nil, both orders are polled every second instead.
+//
+// Whenever one order is partially filled, the amount of the other order is lowered by the filled amount,
+// so the total filled amount never exceeds Amount. Once one order is closed (e.g: filled or canceled by you)
+// the other order is canceled.
+//
+// Whenever ctx is done, the open orders are left as is and the result so far is returned with the error of ctx.
+func (o OCO) Execute(ctx context.Context, client http.HttpClientAuth, account ws.AccountEventHandler) (OCOResult, error) {
+	if err := o.validate(); err != nil {
+		return OCOResult{}, err
+	}
+
+	tracker := newFillTracker()
+
+	unwatch, err := tracker.watch(account, o.Market)
+	if err != nil {
+		return OCOResult{}, err
+	}
+	defer func() {
+		if err := unwatch(); err != nil {
+			log.Warn().Err(err).Str("market", o.Market).Msg("failed to unsubscribe from the account stream")
+		}
+	}()
+
+	takeProfit, err := client.NewOrderWithContext(ctx, o.Market, o.Side, types.OrderTypeLimit, types.OrderNew{
+		Amount:      o.Amount,
+		Price:       o.TakeProfitPrice,
+		TimeInForce: types.TimeInForceGTC,
+	})
+	if err != nil {
+		return OCOResult{}, fmt.Errorf("failed to place take-profit order: %w", err)
+	}
+	tracker.track(takeProfit)
+
+	stopLoss, err := client.NewOrderWithContext(ctx, o.Market, o.Side, o.stopLossType(), o.stopLoss())
+	if err != nil {
+		err = fmt.Errorf("failed to place stop-loss order: %w", err)
+		if _, cancelErr := client.CancelOrderWithContext(context.WithoutCancel(ctx), o.Market, takeProfit.OrderId); cancelErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to cancel take-profit order: %w", cancelErr))
+		}
+		return OCOResult{TakeProfitId: takeProfit.OrderId}, err
+	}
+	tracker.track(stopLoss)
+
+	var (
+		result  = OCOResult{TakeProfitId: takeProfit.OrderId, StopLossId: stopLoss.OrderId}
+		amounts = map[string]float64{takeProfit.OrderId: o.Amount, stopLoss.OrderId: o.Amount}
+	)
+
+	for {
+		if account == nil {
+			for _, orderId := range tracker.open() {
+				order, err := client.GetOrderWithContext(ctx, o.Market, orderId)
+				if err != nil {
+					return result, fmt.Errorf("failed to get order %s: %w", orderId, err)
+				}
+				tracker.update(order)
+			}
+		}
+
+		result.TakeProfitFilled = tracker.filled(takeProfit.OrderId)
+		result.StopLossFilled = tracker.filled(stopLoss.OrderId)
+
+		if err := o.reconcile(ctx, client, tracker, amounts, takeProfit.OrderId, result.StopLossFilled, stopLoss.OrderId, result.TakeProfitFilled); err != nil {
+			return result, err
+		}
+		if err := o.reconcile(ctx, client, tracker, amounts, stopLoss.OrderId, result.TakeProfitFilled, takeProfit.OrderId, result.StopLossFilled); err != nil {
+			return result, err
+		}
+
+		if len(tracker.open()) == 0 {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-tracker.changed:
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// reconcile adjusts the open order orderId to the state of its sibling: it's canceled if the sibling is closed,
+// or its amount is lowered to what's left of Amount after the fills of the sibling.
+func (o OCO) reconcile(
+	ctx context.Context,
+	client http.HttpClientAuth,
+	tracker *fillTracker,
+	amounts map[string]float64,
+	orderId string,
+	filled float64,
+	siblingId string,
+	siblingFilled float64,
+) error {
+	if !tracker.isOpen(orderId) {
+		return nil
+	}
+
+	amount := o.Amount - siblingFilled
+	if !tracker.isOpen(siblingId) || amount-filled <= 0 {
+		_, err := client.CancelOrderWithContext(ctx, o.Market, orderId)
+		if err != nil && !types.IsOrderNotFound(err) {
+			return fmt.Errorf("failed to cancel order %s: %w", orderId, err)
+		}
+		if err == nil {
+			tracker.update(types.Order{OrderId: orderId, Status: types.StatusCanceled})
+		}
+		return nil
+	}
+
+	if amount < amounts[orderId] {
+		_, err := client.UpdateOrderWithContext(ctx, o.Market, orderId, types.OrderUpdate{AmountRemaining: amount - filled})
+		if err != nil && !types.IsOrderNotFound(err) {
+			return fmt.Errorf("failed to update order %s: %w", orderId, err)
+		}
+		amounts[orderId] = amount
+	}
+	return nil
+}
+
+func (o OCO) stopLossType() types.OrderType {
+	if o.StopLossLimitPrice > 0 {
+		return types.OrderTypeStopLossLimit
+	}
+	return types.OrderTypeStopLoss
+}
+
+func (o OCO) stopLoss() types.OrderNew {
+	order := types.OrderNew{
+		Amount:           o.Amount,
+		TriggerAmount:    o.StopLossPrice,
+		TriggerType:      "price",
+		TriggerReference: "lastTrade",
+	}
+	if o.StopLossLimitPrice > 0 {
+		order.Price = o.StopLossLimitPrice
+		order.TimeInForce = types.TimeInForceGTC
+	}
+	return order
+}
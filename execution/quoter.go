@@ -0,0 +1,237 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/marketdata"
+	"github.com/larscom/go-bitvavo/v2/orderbook"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const defaultQuoteInterval = time.Second
+
+// ReferencePrice returns the price to quote around, false if there is no price (yet)
+type ReferencePrice func() (float64, bool)
+
+// TickerReference returns the mid price of the best bid and best ask of market in cache.
+func TickerReference(cache *marketdata.Cache, market string) ReferencePrice {
+	return func() (float64, bool) {
+		bid, ask, found := cache.BestBidAsk(market)
+		if !found {
+			return 0, false
+		}
+		return (bid.Price + ask.Price) / 2, true
+	}
+}
+
+// BookReference returns the mid price of the best bid and best ask of book, false while the book isn't synced.
+func BookReference(book *orderbook.Book) ReferencePrice {
+	return func() (float64, bool) {
+		if !book.Synced() {
+			return 0, false
+		}
+		bid, found := book.BestBid()
+		if !found {
+			return 0, false
+		}
+		ask, found := book.BestAsk()
+		if !found {
+			return 0, false
+		}
+		return (bid.Price + ask.Price) / 2, true
+	}
+}
+
+// Quoter keeps one bid and one ask (post-only limit orders) at a spread around a reference price.
+//
+// This is a skeleton for a market maker: it doesn't skew the quotes by inventory or volatility,
+// and the prices and amounts aren't rounded to the precision of the market.
+type Quoter struct {
+	// The market to quote (e.g: ETH-EUR)
+	Market string
+
+	// The amount in base currency of the bid and the ask.
+	Amount float64
+
+	// The distance between the bid and the ask relative to the reference price (e.g: 0.002 for 0.2%),
+	// the bid is placed at half the spread below and the ask at half the spread above the reference price.
+	Spread float64
+
+	// The price of a quote is only amended once it differs more than Threshold (e.g: 0.0005 for 0.05%) from the
+	// price it should have, to prevent amending on every tiny move of the reference price.
+	Threshold float64
+
+	// The maximum position in base currency built by the fills of the quotes. The bid is canceled while the position
+	// is at or above MaxInventory and the ask while it's at or below -MaxInventory, 0 means unlimited.
+	MaxInventory float64
+
+	// The price to quote around.
+	Reference ReferencePrice
+
+	// The interval in which the reference price is checked.
+	// default: 1s
+	Interval time.Duration
+}
+
+// quote is a bid or an ask placed by the quoter.
+type quote struct {
+	side    types.Side
+	orderId string
+	price   float64
+}
+
+func (q Quoter) validate() error {
+	if q.Market == "" {
+		return errors.New("market is required")
+	}
+	if q.Amount <= 0 {
+		return errors.New("amount must be greater than 0")
+	}
+	if q.Spread <= 0 {
+		return errors.New("spread must be greater than 0")
+	}
+	if q.Reference == nil {
+		return errors.New("reference price is required")
+	}
+	return nil
+}
+
+// Run quotes the market using client until ctx is done or a request fails, both quotes are canceled before it returns.
+//
+// The fills are monitored with the account stream of account, which is subscribed to the market while running,
+// so account can't have a subscription to the market already. If account is nil, the quotes are polled instead.
+func (q Quoter) Run(ctx context.Context, client http.HttpClientAuth, account ws.AccountEventHandler) error {
+	if err := q.validate(); err != nil {
+		return err
+	}
+
+	tracker := newFillTracker()
+
+	unwatch, err := tracker.watch(account, q.Market)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := unwatch(); err != nil {
+			log.Warn().Err(err).Str("market", q.Market).Msg("failed to unsubscribe from the account stream")
+		}
+	}()
+
+	var (
+		bid = &quote{side: types.SideBuy}
+		ask = &quote{side: types.SideSell}
+	)
+	defer func() {
+		for _, quote := range []*quote{bid, ask} {
+			if err := q.cancel(context.WithoutCancel(ctx), client, tracker, quote); err != nil {
+				log.Warn().Err(err).Str("market", q.Market).Msg("failed to cancel quote")
+			}
+		}
+	}()
+
+	interval := q.Interval
+	if interval <= 0 {
+		interval = defaultQuoteInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if account == nil {
+			for _, orderId := range tracker.open() {
+				order, err := client.GetOrderWithContext(ctx, q.Market, orderId)
+				if err != nil {
+					return fmt.Errorf("failed to get order %s: %w", orderId, err)
+				}
+				tracker.update(order)
+			}
+		}
+
+		if reference, found := q.Reference(); found {
+			position := tracker.position()
+
+			if err := q.requote(ctx, client, tracker, bid, reference*(1-q.Spread/2), q.MaxInventory <= 0 || position < q.MaxInventory); err != nil {
+				return err
+			}
+			if err := q.requote(ctx, client, tracker, ask, reference*(1+q.Spread/2), q.MaxInventory <= 0 || position > -q.MaxInventory); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tracker.changed:
+		case <-ticker.C:
+		}
+	}
+}
+
+// requote places quote at price if it isn't open, or amends its price if it moved more than the threshold.
+// The quote is canceled instead if it isn't allowed (e.g: the inventory limit has been reached)
+func (q Quoter) requote(
+	ctx context.Context,
+	client http.HttpClientAuth,
+	tracker *fillTracker,
+	quote *quote,
+	price float64,
+	allowed bool,
+) error {
+	if !allowed {
+		return q.cancel(ctx, client, tracker, quote)
+	}
+
+	if quote.orderId != "" && tracker.isOpen(quote.orderId) {
+		if math.Abs(price-quote.price)/quote.price <= q.Threshold {
+			return nil
+		}
+
+		order, err := client.UpdateOrderWithContext(ctx, q.Market, quote.orderId, types.OrderUpdate{Price: price})
+		if err == nil {
+			tracker.update(order)
+			quote.price = price
+			return nil
+		}
+		if !types.IsOrderNotFound(err) {
+			return fmt.Errorf("failed to amend %s quote: %w", quote.side, err)
+		}
+		// the quote has been filled or canceled in the meantime, place a new one
+	}
+
+	order, err := client.NewOrderWithContext(ctx, q.Market, quote.side, types.OrderTypeLimit, types.OrderNew{
+		Amount:      q.Amount,
+		Price:       price,
+		TimeInForce: types.TimeInForceGTC,
+		PostOnly:    true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to place %s quote: %w", quote.side, err)
+	}
+	tracker.track(order)
+
+	quote.orderId = order.OrderId
+	quote.price = price
+	return nil
+}
+
+// cancel cancels quote if it's open.
+func (q Quoter) cancel(ctx context.Context, client http.HttpClientAuth, tracker *fillTracker, quote *quote) error {
+	if quote.orderId == "" || !tracker.isOpen(quote.orderId) {
+		return nil
+	}
+
+	_, err := client.CancelOrderWithContext(ctx, q.Market, quote.orderId)
+	if err != nil && !types.IsOrderNotFound(err) {
+		return fmt.Errorf("failed to cancel %s quote: %w", quote.side, err)
+	}
+	tracker.update(types.Order{OrderId: quote.orderId, Status: types.StatusCanceled})
+	quote.orderId = ""
+	return nil
+}
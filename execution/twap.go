@@ -0,0 +1,249 @@
+// Package execution provides executors which manage orders over time on top of the authenticated client
+// (e.g: splitting a large order with TWAP, emulating OCO orders or quoting both sides of a market)
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// The interval in which the progress is reported, the open orders are polled as well when there is no account stream.
+	pollInterval = time.Second
+
+	// The interval in which the open orders are polled when there is an account stream, so an order still closes
+	// whenever one of its events has been missed (e.g: during a reconnect)
+	streamPollInterval = 30 * time.Second
+)
+
+// TWAP (time-weighted average price) places Slices orders of equal size in equal intervals over Duration.
+type TWAP struct {
+	// The market to trade (e.g: ETH-EUR)
+	Market string
+
+	// Buy or sell.
+	Side types.Side
+
+	// The total amount in base currency.
+	//
+	// Every slice is Amount / Slices rounded down to the amount precision of the market, the last slice gets the remainder.
+	Amount float64
+
+	// The time over which the slices are placed, the first slice is placed immediately.
+	Duration time.Duration
+
+	// The number of orders to place.
+	Slices int
+
+	// The limit price of every slice, a market order is placed for every slice if 0.
+	// The price is rounded to the tick size of the market.
+	Price float64
+
+	// The details of Market (see http.HttpClient.GetMarket) which are used to round the amount and price of the slices.
+	Details types.Market
+}
+
+// Progress is the state of an execution.
+type Progress struct {
+	// The number of slices which have been placed.
+	SlicesPlaced int
+
+	// The total number of slices.
+	Slices int
+
+	// The filled amount in base currency.
+	FilledAmount float64
+
+	// The filled amount in quote currency.
+	FilledAmountQuote float64
+
+	// The fee which has been paid for the fills.
+	FeePaid float64
+
+	// The ids of the placed orders.
+	OrderIds []string
+}
+
+// AveragePrice returns the average price of the fills, or 0 if nothing has been filled.
+func (p Progress) AveragePrice() float64 {
+	if p.FilledAmount == 0 {
+		return 0
+	}
+	return p.FilledAmountQuote / p.FilledAmount
+}
+
+func (t TWAP) validate() error {
+	if t.Market == "" {
+		return errors.New("market is required")
+	}
+	if t.Side != types.SideBuy && t.Side != types.SideSell {
+		return fmt.Errorf("invalid side: %s", t.Side)
+	}
+	if t.Amount <= 0 {
+		return errors.New("amount must be greater than 0")
+	}
+	if t.Slices <= 0 {
+		return errors.New("slices must be greater than 0")
+	}
+	if t.Duration < 0 {
+		return errors.New("duration can't be negative")
+	}
+	if t.Details.Market != t.Market {
+		return fmt.Errorf("details of market %s are required", t.Market)
+	}
+	if t.Details.RoundAmount(t.Amount/float64(t.Slices)) <= 0 {
+		return errors.New("amount of a slice is below the amount precision of the market")
+	}
+	return nil
+}
+
+// Execute places the slices using client and returns after every order has been filled or closed.
+//
+// The fills are monitored with the account stream of account, which is subscribed to the market during the execution,
+// so account can't have a subscription to the market already. If account is nil, the open orders are polled instead.
+//
+// The progress is sent on progress (if not nil) after every slice and every second in between.
+// Whenever ctx is done, the open orders are left as is and the progress so far is returned with the error of ctx.
+func (t TWAP) Execute(
+	ctx context.Context,
+	client http.HttpClientAuth,
+	account ws.AccountEventHandler,
+	progress chan<- Progress,
+) (Progress, error) {
+	if err := t.validate(); err != nil {
+		return Progress{}, err
+	}
+
+	var (
+		tracker  = newFillTracker()
+		interval = t.Duration / time.Duration(t.Slices)
+		state    = Progress{Slices: t.Slices, OrderIds: make([]string, 0, t.Slices)}
+		polledAt = time.Now()
+	)
+
+	unwatch, err := tracker.watch(account, t.Market)
+	if err != nil {
+		return state, err
+	}
+	defer func() {
+		if err := unwatch(); err != nil {
+			log.Warn().Err(err).Str("market", t.Market).Msg("failed to unsubscribe from the account stream")
+		}
+	}()
+
+	report := func() error {
+		state.FilledAmount, state.FilledAmountQuote, state.FeePaid = tracker.totals()
+		if progress == nil {
+			return nil
+		}
+		// the order ids are copied, so the receiver doesn't share them with the executor
+		snapshot := state
+		snapshot.OrderIds = slices.Clone(state.OrderIds)
+		select {
+		case progress <- snapshot:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// the open orders are polled every pollInterval if there is no account stream, every streamPollInterval otherwise
+	refresh := func() error {
+		if account == nil || time.Since(polledAt) >= streamPollInterval {
+			polledAt = time.Now()
+			for _, orderId := range tracker.open() {
+				order, err := client.GetOrderWithContext(ctx, t.Market, orderId)
+				if err != nil {
+					return fmt.Errorf("failed to get order %s: %w", orderId, err)
+				}
+				tracker.update(order)
+			}
+		}
+		return report()
+	}
+
+	for slice := range t.Slices {
+		if slice > 0 {
+			if err := wait(ctx, interval, refresh); err != nil {
+				return state, err
+			}
+		}
+
+		order, err := client.NewOrderWithContext(ctx, t.Market, t.Side, t.orderType(), t.slice(slice))
+		if err != nil {
+			return state, fmt.Errorf("failed to place slice %d of %d: %w", slice+1, t.Slices, err)
+		}
+		tracker.track(order)
+
+		state.SlicesPlaced++
+		state.OrderIds = append(state.OrderIds, order.OrderId)
+		if err := report(); err != nil {
+			return state, err
+		}
+	}
+
+	for len(tracker.open()) > 0 {
+		select {
+		case <-ctx.Done():
+			return state, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+		if err := refresh(); err != nil {
+			return state, err
+		}
+	}
+
+	return state, report()
+}
+
+// wait waits for d and refreshes the progress every pollInterval.
+func wait(ctx context.Context, d time.Duration, refresh func() error) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case <-ticker.C:
+			if err := refresh(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (t TWAP) orderType() types.OrderType {
+	if t.Price > 0 {
+		return types.OrderTypeLimit
+	}
+	return types.OrderTypeMarket
+}
+
+// slice returns the order of slice rounded to the precision of the market, the last slice gets the remainder of the amount.
+func (t TWAP) slice(slice int) types.OrderNew {
+	amount := t.Details.RoundAmount(t.Amount / float64(t.Slices))
+	if slice == t.Slices-1 {
+		amount = t.Details.RoundAmount(t.Amount - amount*float64(t.Slices-1))
+	}
+
+	order := types.OrderNew{Amount: amount}
+	if t.Price > 0 {
+		order.Price = t.Details.RoundPrice(t.Price)
+		order.TimeInForce = types.TimeInForceGTC
+	}
+	return order
+}
@@ -0,0 +1,109 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+// The interval in which an order is polled while waiting for it with an account stream, in case an event is missed.
+const fallbackPollInterval = 15 * time.Second
+
+// WaitForOrder blocks until the order of market (e.g: ETH-EUR) with orderId reaches one of statuses and returns it.
+// Without statuses it waits until the order reaches a final status (e.g: filled or canceled)
+//
+// The order is followed on the account stream (if account isn't nil) which is subscribed to market until it returns,
+// and polled with client as a fallback. It's polled every second without an account stream or whenever account
+// already has a subscription to market.
+func WaitForOrder(
+	ctx context.Context,
+	client http.HttpClientAuth,
+	account ws.AccountEventHandler,
+	market string,
+	orderId string,
+	statuses ...types.OrderStatus,
+) (types.Order, error) {
+	reached := func(order types.Order) bool {
+		if len(statuses) == 0 {
+			return order.Status.IsFinal()
+		}
+		return slices.Contains(statuses, order.Status)
+	}
+
+	orderchn, unsubscribe := readOrders(account, market)
+	defer unsubscribe()
+
+	interval := pollInterval
+	if orderchn != nil {
+		interval = fallbackPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	poll := func() (types.Order, bool, error) {
+		order, err := client.GetOrderWithContext(ctx, market, orderId)
+		if err != nil {
+			return order, false, fmt.Errorf("failed to get order %s: %w", orderId, err)
+		}
+		return order, reached(order), nil
+	}
+
+	if order, ok, err := poll(); err != nil || ok {
+		return order, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return types.Order{}, ctx.Err()
+		case event, ok := <-orderchn:
+			if !ok {
+				orderchn = nil
+				ticker.Reset(pollInterval)
+				continue
+			}
+			if event.Order.OrderId == orderId && reached(event.Order) {
+				return event.Order, nil
+			}
+		case <-ticker.C:
+			if order, ok, err := poll(); err != nil || ok {
+				return order, err
+			}
+		}
+	}
+}
+
+// readOrders subscribes to the order events of market and returns them with a func which unsubscribes again,
+// the channel is nil if account is nil or the subscription failed. Fill events are discarded.
+func readOrders(account ws.AccountEventHandler, market string) (<-chan ws.OrderEvent, func()) {
+	if account == nil {
+		return nil, func() {}
+	}
+
+	orderchn, fillchn, err := account.Subscribe([]string{market})
+	if err != nil {
+		log.Debug().Err(err).Str("market", market).Msg("failed to subscribe to the account stream, polling instead")
+		return nil, func() {}
+	}
+	go func() {
+		for range fillchn {
+		}
+	}()
+
+	return orderchn, func() {
+		// keep draining, so the stream doesn't block until the channel is closed
+		go func() {
+			for range orderchn {
+			}
+		}()
+		if err := account.Unsubscribe([]string{market}); err != nil {
+			log.Warn().Err(err).Str("market", market).Msg("failed to unsubscribe from the account stream")
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package expiry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+var errGoodTilDatePassed = func(goodTilDate time.Time) error {
+	return fmt.Errorf("good til date has already passed: %s", goodTilDate.Format(time.RFC3339))
+}
+
+// Client places orders with the wrapped client, the orders placed with NewOrderUntil are tracked in the scheduler
+// so they are canceled at their good til date. Every other method is passed through.
+type Client struct {
+	http.HttpClientAuth
+
+	scheduler *Scheduler
+}
+
+// NewClient creates a client which places orders with client and cancels them at their good til date with scheduler,
+// which should use the same account.
+func NewClient(client http.HttpClientAuth, scheduler *Scheduler) *Client {
+	return &Client{HttpClientAuth: client, scheduler: scheduler}
+}
+
+// NewOrderUntil places a new order which is canceled at goodTilDate if it's still open, this is emulated client-side
+// since the exchange only supports GTC, IOC and FOK.
+func (c *Client) NewOrderUntil(market string, side types.Side, orderType types.OrderType, order types.OrderNew, goodTilDate time.Time) (types.Order, error) {
+	return c.NewOrderUntilWithContext(context.Background(), market, side, orderType, order, goodTilDate)
+}
+
+func (c *Client) NewOrderUntilWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order types.OrderNew, goodTilDate time.Time) (types.Order, error) {
+	if !goodTilDate.After(c.scheduler.now()) {
+		return types.Order{}, errGoodTilDatePassed(goodTilDate)
+	}
+
+	placed, err := c.HttpClientAuth.NewOrderWithContext(ctx, market, side, orderType, order)
+	if err != nil {
+		return placed, err
+	}
+
+	if !placed.Status.IsFinal() {
+		c.scheduler.TrackUntil(market, placed.OrderId, goodTilDate)
+	}
+	return placed, nil
+}
+
+func (c *Client) ReplaceOrder(market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	return c.ReplaceOrderWithContext(context.Background(), market, orderId, order, reduceByFilled...)
+}
+
+func (c *Client) ReplaceOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	result, err := http.ReplaceOrderWith(ctx, c, market, orderId, order, len(reduceByFilled) > 0 && reduceByFilled[0])
+	if result.Previous.Status.IsFinal() {
+		c.scheduler.Untrack(orderId)
+	}
+	return result, err
+}
@@ -0,0 +1,397 @@
+// Package expiry cancels orders automatically once their time to live has elapsed,
+// since the exchange only supports orders which are good until canceled.
+package expiry
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultReconcileInterval = time.Minute
+	defaultBuffSize          = 50
+
+	// The wait before the cancel of an expired order is retried after it failed.
+	retryInterval = 5 * time.Second
+)
+
+// Expiration is an order which is canceled at ExpiresAt.
+type Expiration struct {
+	// The market of the order (e.g: ETH-EUR)
+	Market string `json:"market"`
+
+	// The id of the order.
+	OrderId string `json:"orderId"`
+
+	// The time the order is canceled if it's still open.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Result is the outcome of an expired order.
+type Result struct {
+	Expiration
+
+	// Whether the order has been canceled, false if it was already closed (e.g: filled) or the cancel failed.
+	Canceled bool
+
+	// The reason why the cancel failed, it's retried until the order is closed.
+	Err error
+}
+
+type entry struct {
+	Expiration
+	trackedAt time.Time
+	retryAt   time.Time
+}
+
+// due returns the time the order should be canceled.
+func (e entry) due() time.Time {
+	if e.retryAt.After(e.ExpiresAt) {
+		return e.retryAt
+	}
+	return e.ExpiresAt
+}
+
+// Scheduler cancels tracked orders which are still open when they expire. It's safe for concurrent use.
+//
+// Orders which are closed in the meantime are untracked by reconciling the open orders with the REST api every interval
+// (e.g: after a reconnect of the websocket) or immediately when an account stream is consumed.
+type Scheduler struct {
+	client            http.HttpClientAuth
+	store             Store
+	reconcileInterval time.Duration
+	buffSize          uint64
+	now               func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry
+
+	wakechn   chan struct{}
+	results   chan Result
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+type Option func(*Scheduler)
+
+// The interval in which the tracked orders are reconciled with the open orders, 0 disables it.
+// default: 1m
+func WithReconcileInterval(interval time.Duration) Option {
+	return func(s *Scheduler) {
+		s.reconcileInterval = interval
+	}
+}
+
+// The buffer size of the results channel, results are dropped when it's full.
+// default: 50
+func WithBuffSize(buffSize uint64) Option {
+	return func(s *Scheduler) {
+		s.buffSize = buffSize
+	}
+}
+
+// Persist every tracked order in store, so they can be restored after a restart (see: Restore)
+// default: not persisted
+func WithStore(store Store) Option {
+	return func(s *Scheduler) {
+		s.store = store
+	}
+}
+
+// New creates a scheduler which cancels expired orders with client.
+func New(client http.HttpClientAuth, options ...Option) *Scheduler {
+	scheduler := &Scheduler{
+		client:            client,
+		reconcileInterval: defaultReconcileInterval,
+		buffSize:          defaultBuffSize,
+		now:               time.Now,
+		entries:           make(map[string]entry),
+		wakechn:           make(chan struct{}, 1),
+	}
+	for _, opt := range options {
+		opt(scheduler)
+	}
+	scheduler.results = make(chan Result, scheduler.buffSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.cancel = cancel
+
+	scheduler.wg.Add(1)
+	go scheduler.run(ctx)
+
+	return scheduler
+}
+
+// Track cancels the order of market (e.g: ETH-EUR) with orderId once ttl has elapsed.
+func (s *Scheduler) Track(market string, orderId string, ttl time.Duration) {
+	s.TrackUntil(market, orderId, s.now().Add(ttl))
+}
+
+// TrackUntil cancels the order of market (e.g: ETH-EUR) with orderId at expiresAt, replacing a previous expiration of the order.
+func (s *Scheduler) TrackUntil(market string, orderId string, expiresAt time.Time) {
+	expiration := Expiration{Market: market, OrderId: orderId, ExpiresAt: expiresAt}
+	s.track(expiration)
+	s.persist(expiration)
+}
+
+// Restore tracks every order of the store (see: WithStore) and reconciles them with the open orders,
+// call it once after a restart.
+func (s *Scheduler) Restore(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+
+	expirations, err := s.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	for _, expiration := range expirations {
+		s.track(expiration)
+	}
+	return s.Reconcile(ctx)
+}
+
+func (s *Scheduler) track(expiration Expiration) {
+	s.mu.Lock()
+	s.entries[expiration.OrderId] = entry{Expiration: expiration, trackedAt: s.now()}
+	s.mu.Unlock()
+
+	s.wake()
+}
+
+// Untrack stops tracking the order with orderId, it returns false if it wasn't tracked.
+func (s *Scheduler) Untrack(orderId string) bool {
+	s.mu.Lock()
+
+	_, found := s.entries[orderId]
+	delete(s.entries, orderId)
+	s.mu.Unlock()
+
+	if found {
+		s.forget(orderId)
+	}
+	return found
+}
+
+// Tracked returns every tracked order, the first to expire first.
+func (s *Scheduler) Tracked() []Expiration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expirations := make([]Expiration, 0, len(s.entries))
+	for _, entry := range s.entries {
+		expirations = append(expirations, entry.Expiration)
+	}
+	slices.SortFunc(expirations, func(a, b Expiration) int {
+		return a.ExpiresAt.Compare(b.ExpiresAt)
+	})
+	return expirations
+}
+
+// Results returns a channel which receives the outcome of every expired order.
+// The channel is closed when the scheduler is closed.
+func (s *Scheduler) Results() <-chan Result {
+	return s.results
+}
+
+// Reconcile untracks every order which isn't open anymore, orders which are tracked while reconciling are kept.
+func (s *Scheduler) Reconcile(ctx context.Context) error {
+	startedAt := s.now()
+
+	orders, err := s.client.GetOrdersOpenWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	open := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		open[order.OrderId] = true
+	}
+
+	s.mu.Lock()
+	closed := make([]string, 0)
+	for orderId, entry := range s.entries {
+		if !open[orderId] && !entry.trackedAt.After(startedAt) {
+			delete(s.entries, orderId)
+			closed = append(closed, orderId)
+		}
+	}
+	s.mu.Unlock()
+
+	s.forget(closed...)
+	return nil
+}
+
+// Consume untracks every order which reaches a final status (e.g: filled) on the account stream,
+// until orderchn is closed or ctx is done.
+func (s *Scheduler) Consume(ctx context.Context, orderchn <-chan ws.OrderEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-orderchn:
+			if !ok {
+				return
+			}
+			if event.Order.Status.IsFinal() {
+				s.Untrack(event.Order.OrderId)
+			}
+		}
+	}
+}
+
+// Close stops the scheduler, the tracked orders are left as is.
+func (s *Scheduler) Close() {
+	s.closeOnce.Do(func() {
+		s.cancel()
+		s.wg.Wait()
+		close(s.results)
+	})
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	var reconcilechn <-chan time.Time
+	if s.reconcileInterval > 0 {
+		ticker := time.NewTicker(s.reconcileInterval)
+		defer ticker.Stop()
+		reconcilechn = ticker.C
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reconcilechn:
+			if err := s.Reconcile(ctx); err != nil && ctx.Err() == nil {
+				log.Warn().Err(err).Msg("failed to reconcile the open orders")
+			}
+		case <-s.wakechn:
+		case <-timer.C:
+			s.expire(ctx)
+		}
+
+		timer.Stop()
+		select {
+		case <-timer.C:
+		default:
+		}
+		if next, found := s.next(); found {
+			timer.Reset(max(0, next.Sub(s.now())))
+		}
+	}
+}
+
+// next returns the earliest time an order should be canceled, false if no orders are tracked.
+func (s *Scheduler) next() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		next  time.Time
+		found bool
+	)
+	for _, entry := range s.entries {
+		if due := entry.due(); !found || due.Before(next) {
+			next = due
+			found = true
+		}
+	}
+	return next, found
+}
+
+// expire cancels every order which is due.
+func (s *Scheduler) expire(ctx context.Context) {
+	now := s.now()
+
+	s.mu.Lock()
+	due := make([]entry, 0)
+	for _, entry := range s.entries {
+		if !entry.due().After(now) {
+			due = append(due, entry)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, entry := range due {
+		result := Result{Expiration: entry.Expiration}
+
+		_, err := s.client.CancelOrderWithContext(ctx, entry.Market, entry.OrderId)
+		switch {
+		case err == nil:
+			result.Canceled = true
+		case types.IsOrderNotFound(err):
+		case ctx.Err() != nil:
+			return
+		default:
+			result.Err = err
+			log.Warn().Err(err).Str("orderId", entry.OrderId).Msg("failed to cancel expired order, retrying")
+		}
+
+		s.mu.Lock()
+		current, found := s.entries[entry.OrderId]
+		found = found && current.ExpiresAt.Equal(entry.ExpiresAt)
+		if found && result.Err != nil {
+			current.retryAt = s.now().Add(retryInterval)
+			s.entries[entry.OrderId] = current
+		} else if found {
+			delete(s.entries, entry.OrderId)
+		}
+		s.mu.Unlock()
+
+		if found && result.Err == nil {
+			s.forget(entry.OrderId)
+		}
+
+		s.publish(result)
+	}
+}
+
+func (s *Scheduler) publish(result Result) {
+	select {
+	case s.results <- result:
+	default:
+		log.Warn().Str("orderId", result.OrderId).Msg("results channel is full, dropping result")
+	}
+}
+
+// persist saves expiration in the store (if any), failures are logged because the order is tracked in memory regardless.
+func (s *Scheduler) persist(expiration Expiration) {
+	if s.store == nil {
+		return
+	}
+	if err := s.store.Save(context.Background(), expiration); err != nil {
+		log.Err(err).Str("orderId", expiration.OrderId).Msg("Failed to save expiration")
+	}
+}
+
+// forget deletes the orders with orderIds from the store (if any)
+func (s *Scheduler) forget(orderIds ...string) {
+	if s.store == nil {
+		return
+	}
+	for _, orderId := range orderIds {
+		if err := s.store.Delete(context.Background(), orderId); err != nil {
+			log.Err(err).Str("orderId", orderId).Msg("Failed to delete expiration")
+		}
+	}
+}
+
+func (s *Scheduler) wake() {
+	select {
+	case s.wakechn <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,121 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/goccy/go-json"
+)
+
+// Store persists the tracked orders of a scheduler, so they survive a restart.
+type Store interface {
+	// Save stores expiration, replacing a previous expiration of the same order.
+	Save(ctx context.Context, expiration Expiration) error
+
+	// Delete removes the expiration of the order with orderId.
+	Delete(ctx context.Context, orderId string) error
+
+	// Load returns every stored expiration.
+	Load(ctx context.Context) ([]Expiration, error)
+}
+
+// FileStore stores the expirations as JSON in a single file, which is rewritten on every change.
+// It's safe for concurrent use.
+type FileStore struct {
+	path string
+
+	mu sync.Mutex
+}
+
+var _ Store = (*FileStore)(nil)
+
+// NewFileStore creates a store which writes to the file at path, the file is created on the first save.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (f *FileStore) Save(ctx context.Context, expiration Expiration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	expirations, err := f.read()
+	if err != nil {
+		return err
+	}
+	expirations[expiration.OrderId] = expiration
+	return f.write(expirations)
+}
+
+func (f *FileStore) Delete(ctx context.Context, orderId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	expirations, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, found := expirations[orderId]; !found {
+		return nil
+	}
+	delete(expirations, orderId)
+	return f.write(expirations)
+}
+
+func (f *FileStore) Load(ctx context.Context) ([]Expiration, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	expirations, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Expiration, 0, len(expirations))
+	for _, expiration := range expirations {
+		result = append(result, expiration)
+	}
+	return result, nil
+}
+
+func (f *FileStore) read() (map[string]Expiration, error) {
+	expirations := make(map[string]Expiration)
+
+	bytes, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return expirations, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bytes, &expirations); err != nil {
+		return nil, err
+	}
+	return expirations, nil
+}
+
+// write replaces the file with a temporary file, so it's never left half written.
+func (f *FileStore) write(expirations map[string]Expiration) error {
+	bytes, err := json.Marshal(expirations)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bytes); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
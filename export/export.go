@@ -0,0 +1,214 @@
+// Package export downloads historical market data (candles and public trades) and writes it as CSV.
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// The columns of a candle.
+const (
+	ColumnTimestamp = "timestamp"
+	ColumnOpen      = "open"
+	ColumnHigh      = "high"
+	ColumnLow       = "low"
+	ColumnClose     = "close"
+	ColumnVolume    = "volume"
+)
+
+// The columns of a trade, besides ColumnTimestamp.
+const (
+	ColumnId     = "id"
+	ColumnPrice  = "price"
+	ColumnAmount = "amount"
+	ColumnSide   = "side"
+)
+
+var (
+	// CandleColumns are the default columns of Candles.
+	CandleColumns = []string{ColumnTimestamp, ColumnOpen, ColumnHigh, ColumnLow, ColumnClose, ColumnVolume}
+
+	// TradeColumns are the default columns of Trades.
+	TradeColumns = []string{ColumnTimestamp, ColumnId, ColumnPrice, ColumnAmount, ColumnSide}
+)
+
+type config struct {
+	columns    []string
+	timeFormat string
+	location   *time.Location
+	header     bool
+}
+
+type Option func(*config)
+
+// The columns to write and their order (e.g: timestamp, close)
+// default: CandleColumns or TradeColumns
+func WithColumns(columns ...string) Option {
+	return func(c *config) {
+		c.columns = columns
+	}
+}
+
+// The layout of the timestamps (e.g: time.RFC3339), an empty layout writes the timestamps as milliseconds since 1 Jan 1970.
+// default: milliseconds
+func WithTimeFormat(layout string) Option {
+	return func(c *config) {
+		c.timeFormat = layout
+	}
+}
+
+// The location in which the timestamps are formatted, only used together with WithTimeFormat.
+// default: UTC
+func WithLocation(location *time.Location) Option {
+	return func(c *config) {
+		c.location = location
+	}
+}
+
+// Write the column names as the first row.
+// default: true
+func WithHeader(header bool) Option {
+	return func(c *config) {
+		c.header = header
+	}
+}
+
+var candleValues = map[string]func(candle types.Candle) string{
+	ColumnOpen:   func(candle types.Candle) string { return candle.OpenStr },
+	ColumnHigh:   func(candle types.Candle) string { return candle.HighStr },
+	ColumnLow:    func(candle types.Candle) string { return candle.LowStr },
+	ColumnClose:  func(candle types.Candle) string { return candle.CloseStr },
+	ColumnVolume: func(candle types.Candle) string { return candle.VolumeStr },
+}
+
+var tradeValues = map[string]func(trade types.Trade) string{
+	ColumnId:     func(trade types.Trade) string { return trade.Id },
+	ColumnPrice:  func(trade types.Trade) string { return trade.PriceStr },
+	ColumnAmount: func(trade types.Trade) string { return trade.AmountStr },
+	ColumnSide:   func(trade types.Trade) string { return string(trade.Side) },
+}
+
+// Candles downloads the candles of market with interval (e.g: 1h) between start and end using client
+// and writes them to w, oldest first. It returns the number of candles which have been written.
+//
+// The range is requested in chunks which wait for the rate limit to reset whenever it gets low (see: GetCandlesRange)
+func Candles(
+	ctx context.Context,
+	client http.HttpClient,
+	w io.Writer,
+	market string,
+	interval types.Interval,
+	start time.Time,
+	end time.Time,
+	options ...Option,
+) (int, error) {
+	config := newConfig(CandleColumns, options...)
+	if err := validateColumns(config.columns, candleValues); err != nil {
+		return 0, err
+	}
+
+	candles, err := client.GetCandlesRangeWithContext(ctx, market, interval, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	return writeCSV(w, config, candles, candleValues, func(candle types.Candle) int64 { return candle.Timestamp })
+}
+
+// Trades downloads the public trades of market between start and end using client
+// and writes them to w, oldest first. It returns the number of trades which have been written.
+//
+// The range is requested in pages which wait for the rate limit to reset whenever it gets low (see: GetTradesRange)
+func Trades(
+	ctx context.Context,
+	client http.HttpClient,
+	w io.Writer,
+	market string,
+	start time.Time,
+	end time.Time,
+	options ...Option,
+) (int, error) {
+	config := newConfig(TradeColumns, options...)
+	if err := validateColumns(config.columns, tradeValues); err != nil {
+		return 0, err
+	}
+
+	trades, err := client.GetTradesRangeWithContext(ctx, market, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	return writeCSV(w, config, trades, tradeValues, func(trade types.Trade) int64 { return trade.Timestamp })
+}
+
+func newConfig(columns []string, options ...Option) *config {
+	config := &config{
+		columns:  columns,
+		location: time.UTC,
+		header:   true,
+	}
+	for _, opt := range options {
+		opt(config)
+	}
+	return config
+}
+
+func validateColumns[T any](columns []string, values map[string]func(item T) string) error {
+	if len(columns) == 0 {
+		return errors.New("at least one column is required")
+	}
+	for _, column := range columns {
+		if _, found := values[column]; !found && column != ColumnTimestamp {
+			return fmt.Errorf("unknown column: %s", column)
+		}
+	}
+	return nil
+}
+
+func (c *config) formatTimestamp(timestamp int64) string {
+	if c.timeFormat == "" {
+		return strconv.FormatInt(timestamp, 10)
+	}
+	return time.UnixMilli(timestamp).In(c.location).Format(c.timeFormat)
+}
+
+func writeCSV[T any](
+	w io.Writer,
+	config *config,
+	items []T,
+	values map[string]func(item T) string,
+	timestamp func(item T) int64,
+) (int, error) {
+	writer := csv.NewWriter(w)
+
+	if config.header {
+		if err := writer.Write(config.columns); err != nil {
+			return 0, err
+		}
+	}
+
+	record := make([]string, len(config.columns))
+	for i, item := range items {
+		for j, column := range config.columns {
+			if column == ColumnTimestamp {
+				record[j] = config.formatTimestamp(timestamp(item))
+			} else {
+				record[j] = values[column](item)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return i, err
+		}
+	}
+
+	writer.Flush()
+	return len(items), writer.Error()
+}
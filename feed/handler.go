@@ -0,0 +1,330 @@
+// Package feed implements the event handler interfaces of the websocket client on top of any other source of events
+// (e.g: recorded market data or polling), so consumers written against the websocket interfaces run unchanged.
+package feed
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/larscom/go-bitvavo/v2/util"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const defaultBuffSize = 50
+
+var (
+	errNoSubscriptionActive = func(market string) error {
+		return fmt.Errorf("no subscription active for market: %s", market)
+	}
+	errSubscriptionAlreadyActive = func(market string) error {
+		return fmt.Errorf("subscription already active for market: %s", market)
+	}
+)
+
+// Producer produces the events of markets by calling emit for every event, until ctx is done or there are no more events.
+// Emit returns false whenever the subscription has been stopped, the producer should return immediately.
+type Producer[T any] func(ctx context.Context, markets []string, emit func(event T) bool)
+
+// Handler implements ws.EventHandler by running a producer for every subscription.
+//
+// The channel of a subscription is closed whenever its markets are unsubscribed or the producer returns.
+type Handler[T any] struct {
+	market  func(event T) string
+	produce Producer[T]
+
+	mu   sync.Mutex
+	subs map[string]*subscription[T]
+}
+
+var _ ws.EventHandler[ws.TickerEvent] = (*Handler[ws.TickerEvent])(nil)
+
+// NewHandler creates a handler which runs produce for every subscription, market returns the market of an event.
+func NewHandler[T any](market func(event T) string, produce Producer[T]) *Handler[T] {
+	return &Handler[T]{
+		market:  market,
+		produce: produce,
+		subs:    make(map[string]*subscription[T]),
+	}
+}
+
+// subscription is a running producer for one or more markets.
+type subscription[T any] struct {
+	mu      sync.RWMutex
+	markets map[string]bool
+	readers map[string][]chan<- T
+	cancel  context.CancelFunc
+}
+
+func (s *subscription[T]) has(market string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.markets[market]
+}
+
+// publish sends event to the readers of market, the lock is held so no reader is closed while sending.
+func (s *subscription[T]) publish(ctx context.Context, market string, event T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, reader := range s.readers[market] {
+		select {
+		case reader <- event:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Handler[T]) Subscribe(markets []string, buffSize ...uint64) (<-chan T, error) {
+	return h.SubscribeFunc(markets, h.produce, buffSize...)
+}
+
+// SubscribeFunc is the same as Subscribe, but the events are produced by produce instead of the producer of the handler
+// (e.g: to transform the events of a subscription)
+func (h *Handler[T]) SubscribeFunc(markets []string, produce Producer[T], buffSize ...uint64) (<-chan T, error) {
+	markets = uniqueMarkets(markets)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, market := range markets {
+		if _, found := h.subs[market]; found {
+			return nil, errSubscriptionAlreadyActive(market)
+		}
+	}
+
+	var (
+		size        = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
+		outchn      = make(chan T, int(size)*len(markets))
+		ctx, cancel = context.WithCancel(context.Background())
+		sub         = &subscription[T]{
+			markets: make(map[string]bool, len(markets)),
+			readers: make(map[string][]chan<- T),
+			cancel:  cancel,
+		}
+	)
+	for _, market := range markets {
+		sub.markets[market] = true
+		h.subs[market] = sub
+	}
+
+	go h.run(ctx, sub, markets, produce, outchn)
+
+	return outchn, nil
+}
+
+func (h *Handler[T]) run(ctx context.Context, sub *subscription[T], markets []string, produce Producer[T], outchn chan<- T) {
+	defer func() {
+		sub.cancel()
+		h.remove(sub)
+		close(outchn)
+	}()
+
+	produce(ctx, markets, func(event T) bool {
+		market := h.market(event)
+		if !sub.has(market) {
+			return ctx.Err() == nil
+		}
+
+		select {
+		case outchn <- event:
+		case <-ctx.Done():
+			return false
+		}
+
+		return sub.publish(ctx, market, event)
+	})
+}
+
+// remove deletes every market of sub which is still subscribed and closes its readers.
+func (h *Handler[T]) remove(sub *subscription[T]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	for market := range sub.markets {
+		if h.subs[market] == sub {
+			delete(h.subs, market)
+		}
+	}
+	sub.markets = nil
+	for _, readers := range sub.readers {
+		for _, reader := range readers {
+			close(reader)
+		}
+	}
+	sub.readers = nil
+}
+
+func (h *Handler[T]) SubscribeSeq(markets []string, buffSize ...uint64) (iter.Seq[T], error) {
+	markets = uniqueMarkets(markets)
+
+	outchn, err := h.Subscribe(markets, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(T) bool) {
+		for event := range outchn {
+			if !yield(event) {
+				if err := h.unsubscribeActive(markets); err != nil {
+					log.Err(err).Msg("Failed to unsubscribe after the iteration stopped")
+				}
+				go drain(outchn)
+				return
+			}
+		}
+	}, nil
+}
+
+func (h *Handler[T]) SubscribeGroups(groups []ws.MarketGroup, buffSize ...uint64) (<-chan T, error) {
+	markets := make([]string, 0)
+	for _, group := range groups {
+		markets = append(markets, group.Markets...)
+	}
+
+	outchn, err := h.Subscribe(markets, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		go func() {
+			<-group.Context.Done()
+			if err := h.unsubscribeActive(group.Markets); err != nil {
+				log.Err(err).Msg("Failed to unsubscribe group")
+			}
+		}()
+	}
+
+	return outchn, nil
+}
+
+func (h *Handler[T]) Reader(markets []string, buffSize ...uint64) (<-chan T, error) {
+	markets = uniqueMarkets(markets)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, market := range markets {
+		if _, found := h.subs[market]; !found {
+			return nil, errNoSubscriptionActive(market)
+		}
+	}
+
+	var (
+		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
+		outchn = make(chan T, int(size)*len(markets))
+		wg     sync.WaitGroup
+	)
+	for _, market := range markets {
+		inchn := make(chan T, size)
+
+		sub := h.subs[market]
+		sub.mu.Lock()
+		sub.readers[market] = append(sub.readers[market], inchn)
+		sub.mu.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range inchn {
+				outchn <- event
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(outchn)
+	}()
+
+	return outchn, nil
+}
+
+func (h *Handler[T]) Unsubscribe(markets []string) error {
+	markets = uniqueMarkets(markets)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, market := range markets {
+		if _, found := h.subs[market]; !found {
+			return errNoSubscriptionActive(market)
+		}
+	}
+
+	bySub := make(map[*subscription[T]][]string)
+	for _, market := range markets {
+		sub := h.subs[market]
+		bySub[sub] = append(bySub[sub], market)
+		delete(h.subs, market)
+	}
+
+	for sub, markets := range bySub {
+		// the producer is stopped first whenever every market is unsubscribed, so it doesn't block on a reader
+		sub.mu.RLock()
+		empty := len(sub.markets) == len(markets)
+		sub.mu.RUnlock()
+		if empty {
+			sub.cancel()
+		}
+
+		sub.mu.Lock()
+		for _, market := range markets {
+			delete(sub.markets, market)
+			for _, reader := range sub.readers[market] {
+				close(reader)
+			}
+			delete(sub.readers, market)
+		}
+		sub.mu.Unlock()
+	}
+	return nil
+}
+
+func (h *Handler[T]) UnsubscribeAll() error {
+	h.mu.Lock()
+	markets := make([]string, 0, len(h.subs))
+	for market := range h.subs {
+		markets = append(markets, market)
+	}
+	h.mu.Unlock()
+
+	return h.Unsubscribe(markets)
+}
+
+// unsubscribeActive unsubscribes the markets which are still subscribed.
+func (h *Handler[T]) unsubscribeActive(markets []string) error {
+	h.mu.Lock()
+	active := make([]string, 0, len(markets))
+	for _, market := range markets {
+		if _, found := h.subs[market]; found {
+			active = append(active, market)
+		}
+	}
+	h.mu.Unlock()
+
+	if len(active) == 0 {
+		return nil
+	}
+	return h.Unsubscribe(active)
+}
+
+func uniqueMarkets(markets []string) []string {
+	unique := slices.Clone(markets)
+	slices.Sort(unique)
+	return slices.Compact(unique)
+}
+
+func drain[T any](chn <-chan T) {
+	for range chn {
+	}
+}
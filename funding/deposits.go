@@ -0,0 +1,191 @@
+// Package funding watches the deposits and withdrawals of your account with the REST api,
+// since the websocket doesn't push funding events.
+package funding
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultInterval = time.Minute
+	defaultBuffSize = 50
+)
+
+// DepositEvent is a deposit which has been detected or whose status changed.
+type DepositEvent struct {
+	// The deposit itself.
+	Deposit types.DepositHistory
+
+	// Whether the deposit has been detected for the first time.
+	New bool
+
+	// The status before the change, empty for a new deposit.
+	PreviousStatus string
+}
+
+// DepositWatcher polls the deposit history and emits an event for every new deposit and every status change.
+// It's safe for concurrent use.
+type DepositWatcher struct {
+	client   http.HttpClientAuth
+	symbol   string
+	interval time.Duration
+	buffSize uint64
+	since    time.Time
+
+	// the status per deposit of the previous poll, nil until the first poll succeeded
+	known map[string]string
+
+	events    chan DepositEvent
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+type DepositOption func(*DepositWatcher)
+
+// Only watch the deposits of symbol (e.g: EUR)
+// default: every symbol
+func WithSymbol(symbol string) DepositOption {
+	return func(w *DepositWatcher) {
+		w.symbol = symbol
+	}
+}
+
+// The interval in which the deposit history is polled.
+// default: 1m
+func WithInterval(interval time.Duration) DepositOption {
+	return func(w *DepositWatcher) {
+		w.interval = interval
+	}
+}
+
+// The buffer size of the events channel, events are dropped when it's full.
+// default: 50
+func WithBuffSize(buffSize uint64) DepositOption {
+	return func(w *DepositWatcher) {
+		w.buffSize = buffSize
+	}
+}
+
+// Emit the deposits which have been received since since on the first poll (e.g: to catch up after a restart)
+// default: the deposits which exist on the first poll are not emitted
+func WithSince(since time.Time) DepositOption {
+	return func(w *DepositWatcher) {
+		w.since = since
+	}
+}
+
+// NewDepositWatcher creates a watcher which polls the deposit history with client immediately and then every interval.
+func NewDepositWatcher(client http.HttpClientAuth, options ...DepositOption) *DepositWatcher {
+	watcher := &DepositWatcher{
+		client:   client,
+		interval: defaultInterval,
+		buffSize: defaultBuffSize,
+	}
+	for _, opt := range options {
+		opt(watcher)
+	}
+	watcher.events = make(chan DepositEvent, watcher.buffSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher.cancel = cancel
+
+	watcher.wg.Add(1)
+	go watcher.run(ctx)
+
+	return watcher
+}
+
+// Events returns a channel which receives the new deposits and status changes, oldest first.
+// The channel is closed when the watcher is closed.
+func (w *DepositWatcher) Events() <-chan DepositEvent {
+	return w.events
+}
+
+// Close stops the watcher.
+func (w *DepositWatcher) Close() {
+	w.closeOnce.Do(func() {
+		w.cancel()
+		w.wg.Wait()
+		close(w.events)
+	})
+}
+
+func (w *DepositWatcher) run(ctx context.Context) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx); err != nil && ctx.Err() == nil {
+			log.Warn().Err(err).Msg("failed to poll the deposit history")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches the deposit history and emits what changed since the previous poll.
+func (w *DepositWatcher) poll(ctx context.Context) error {
+	deposits, err := w.client.GetDepositHistoryWithContext(ctx, &types.DepositHistoryParams{Symbol: w.symbol})
+	if err != nil {
+		return err
+	}
+
+	first := w.known == nil
+	known := make(map[string]string, len(deposits))
+
+	slices.SortStableFunc(deposits, func(a, b types.DepositHistory) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+
+	for _, deposit := range deposits {
+		key := depositKey(deposit)
+		known[key] = deposit.Status
+
+		if first {
+			if !w.since.IsZero() && !deposit.Time().Before(w.since) {
+				w.publish(DepositEvent{Deposit: deposit, New: true})
+			}
+			continue
+		}
+
+		previous, found := w.known[key]
+		switch {
+		case !found:
+			w.publish(DepositEvent{Deposit: deposit, New: true})
+		case previous != deposit.Status:
+			w.publish(DepositEvent{Deposit: deposit, PreviousStatus: previous})
+		}
+	}
+
+	w.known = known
+	return nil
+}
+
+func (w *DepositWatcher) publish(event DepositEvent) {
+	select {
+	case w.events <- event:
+	default:
+		log.Warn().Str("symbol", event.Deposit.Symbol).Msg("events channel is full, dropping deposit event")
+	}
+}
+
+// depositKey identifies a deposit, fiat deposits don't have a transaction id.
+func depositKey(deposit types.DepositHistory) string {
+	return fmt.Sprintf("%s/%d/%s/%s/%g", deposit.Symbol, deposit.Timestamp, deposit.TxId, deposit.Address, deposit.Amount)
+}
@@ -0,0 +1,111 @@
+package funding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// The interval in which the withdrawal history is polled while watching a withdrawal.
+const withdrawalPollInterval = 10 * time.Second
+
+const (
+	WithdrawalStatusAwaitingProcessing        = "awaiting_processing"
+	WithdrawalStatusAwaitingEmailConfirmation = "awaiting_email_confirmation"
+	WithdrawalStatusAwaitingBitvavoInspection = "awaiting_bitvavo_inspection"
+	WithdrawalStatusApproved                  = "approved"
+	WithdrawalStatusSending                   = "sending"
+	WithdrawalStatusInMempool                 = "in_mempool"
+	WithdrawalStatusProcessed                 = "processed"
+	WithdrawalStatusCompleted                 = "completed"
+	WithdrawalStatusCanceled                  = "canceled"
+)
+
+// ErrWithdrawalCanceled is returned by WatchWithdrawal whenever the withdrawal has been canceled.
+var ErrWithdrawalCanceled = errors.New("withdrawal has been canceled")
+
+// Match returns true if withdrawal is the withdrawal which is watched.
+type Match func(withdrawal types.WithdrawalHistory) bool
+
+// ByTxId matches the withdrawal with the transaction id txId.
+func ByTxId(txId string) Match {
+	return func(withdrawal types.WithdrawalHistory) bool {
+		return withdrawal.TxId == txId
+	}
+}
+
+// ByAddress matches the first withdrawal of amount to address which has been received at or after after,
+// use it right after Withdraw since the transaction id isn't known until the withdrawal is sent.
+func ByAddress(address string, amount float64, after time.Time) Match {
+	return func(withdrawal types.WithdrawalHistory) bool {
+		return withdrawal.Address == address && withdrawal.Amount == amount && !withdrawal.Time().Before(after)
+	}
+}
+
+// WatchWithdrawal polls the withdrawal history of symbol (e.g: BTC) until the withdrawal which matches reaches
+// a terminal status (completed or canceled) and returns it, ErrWithdrawalCanceled is returned whenever it has been canceled.
+//
+// The withdrawal is polled every 10 seconds, onStatus (if not nil) is invoked whenever its status changes
+// (e.g: awaiting_processing -> sending -> in_mempool -> completed), including the status it has when it's found.
+func WatchWithdrawal(
+	ctx context.Context,
+	client http.HttpClientAuth,
+	symbol string,
+	match Match,
+	onStatus func(withdrawal types.WithdrawalHistory),
+) (types.WithdrawalHistory, error) {
+	ticker := time.NewTicker(withdrawalPollInterval)
+	defer ticker.Stop()
+
+	var status string
+	for {
+		withdrawal, found, err := findWithdrawal(ctx, client, symbol, match)
+		if err != nil {
+			return types.WithdrawalHistory{}, err
+		}
+
+		if found && withdrawal.Status != status {
+			status = withdrawal.Status
+			if onStatus != nil {
+				onStatus(withdrawal)
+			}
+		}
+
+		switch {
+		case found && status == WithdrawalStatusCompleted:
+			return withdrawal, nil
+		case found && status == WithdrawalStatusCanceled:
+			return withdrawal, ErrWithdrawalCanceled
+		}
+
+		select {
+		case <-ctx.Done():
+			return withdrawal, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// findWithdrawal returns the oldest withdrawal of symbol which matches, false if there is none.
+func findWithdrawal(ctx context.Context, client http.HttpClientAuth, symbol string, match Match) (types.WithdrawalHistory, bool, error) {
+	withdrawals, err := client.GetWithdrawalHistoryWithContext(ctx, &types.WithdrawalHistoryParams{Symbol: symbol})
+	if err != nil {
+		return types.WithdrawalHistory{}, false, fmt.Errorf("failed to get withdrawal history: %w", err)
+	}
+
+	var (
+		oldest types.WithdrawalHistory
+		found  bool
+	)
+	for _, withdrawal := range withdrawals {
+		if match(withdrawal) && (!found || withdrawal.Timestamp < oldest.Timestamp) {
+			oldest = withdrawal
+			found = true
+		}
+	}
+	return oldest, found, nil
+}
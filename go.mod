@@ -1,6 +1,6 @@
 module github.com/larscom/go-bitvavo/v2
 
-go 1.22.0
+go 1.23.0
 
 require (
 	github.com/deckarep/golang-set/v2 v2.6.0
@@ -10,11 +10,12 @@ require (
 	github.com/mhmtszr/concurrent-swiss-map v1.0.6
 	github.com/orsinium-labs/enum v1.3.0
 	github.com/rs/zerolog v1.32.0
+	github.com/shopspring/decimal v1.4.0
 )
 
 require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/net v0.22.0 // indirect
-	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
 )
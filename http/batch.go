@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// The rate limit which NewOrders leaves for other requests, it waits for the reset otherwise.
+const batchOrdersReserve = 50
+
+// ErrOrderSkipped is the error of the orders which NewOrders didn't place, because a previous order failed in fail fast mode.
+var ErrOrderSkipped = errors.New("order skipped, a previous order failed")
+
+type OrderResult struct {
+	// The placed order, empty if it failed.
+	Order types.Order
+
+	// The reason why the order wasn't placed, nil if it was placed.
+	Err error
+}
+
+func (c *httpClientAuth) NewOrders(orders []types.OrderNew, failFast ...bool) ([]OrderResult, error) {
+	return c.NewOrdersWithContext(context.Background(), orders, failFast...)
+}
+
+func (c *httpClientAuth) NewOrdersWithContext(ctx context.Context, orders []types.OrderNew, failFast ...bool) ([]OrderResult, error) {
+	return NewOrdersWith(ctx, orders, len(failFast) > 0 && failFast[0], func(ctx context.Context, order types.OrderNew) (types.Order, error) {
+		if err := c.client.waitForBudget(ctx, endpointWeights["POST /order"], batchOrdersReserve, false); err != nil {
+			return types.Order{}, err
+		}
+		return c.NewOrderWithContext(ctx, order.Market, order.Side, order.OrderType, order)
+	})
+}
+
+// NewOrdersWith places orders one after the other with newOrder and returns the result of every order in input order.
+//
+// In fail fast mode the remaining orders are skipped (see: ErrOrderSkipped) after the first failure.
+// The returned error joins the errors of the orders which failed, nil if every order is placed.
+func NewOrdersWith(
+	ctx context.Context,
+	orders []types.OrderNew,
+	failFast bool,
+	newOrder func(ctx context.Context, order types.OrderNew) (types.Order, error),
+) ([]OrderResult, error) {
+	var (
+		results = make([]OrderResult, len(orders))
+		errs    = make([]error, 0)
+		failed  bool
+	)
+
+	for i, order := range orders {
+		if failed && failFast {
+			results[i].Err = ErrOrderSkipped
+			continue
+		}
+
+		placed, err := newOrder(ctx, order)
+		if err != nil {
+			failed = true
+			results[i].Err = err
+			errs = append(errs, fmt.Errorf("order %d (%s): %w", i, order.Market, err))
+			continue
+		}
+		results[i].Order = placed
+	}
+
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// Cache the markets and assets for ttl, so GetMarkets, GetMarket, GetAssets and GetAsset
+// don't burn rate limit on data that barely changes.
+//
+// Concurrent requests for expired data share a single refresh.
+// default: no cache
+func WithCache(ttl time.Duration) Option {
+	return func(c *httpClient) {
+		c.markets = newCache[[]types.Market](ttl)
+		c.assets = newCache[[]types.Asset](ttl)
+	}
+}
+
+// Cache the tickers of all markets for ttl (e.g: 250ms to 1s), so GetTickerPrices, GetTickerBooks and GetTickers24h
+// return instantly for high read dashboards.
+//
+// Whenever the tickers have expired, the stale tickers are returned while they are refreshed in the background.
+// Only the first request waits for the tickers.
+// default: no cache
+func WithTickerCache(ttl time.Duration) Option {
+	return func(c *httpClient) {
+		c.tickerPrices = newCache[[]types.TickerPrice](ttl)
+		c.tickerBooks = newCache[[]types.TickerBook](ttl)
+		c.tickers24h = newCache[[]types.Ticker24h](ttl)
+	}
+}
+
+type cache[T any] struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	value     T
+	expiresAt time.Time
+	refresh   *cacheRefresh[T]
+}
+
+type cacheRefresh[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func newCache[T any](ttl time.Duration) *cache[T] {
+	return &cache[T]{ttl: ttl}
+}
+
+// get returns the cached value, or calls fetch if the value has expired.
+// Only one fetch runs at a time, other callers wait for its result.
+func (c *cache[T]) get(ctx context.Context, fetch func(ctx context.Context) (T, error)) (T, error) {
+	c.mu.Lock()
+	if time.Now().Before(c.expiresAt) {
+		value := c.value
+		c.mu.Unlock()
+		return value, nil
+	}
+
+	refresh := c.refresh
+	if refresh == nil {
+		refresh = &cacheRefresh[T]{done: make(chan struct{})}
+		c.refresh = refresh
+		// the refresh is shared, so it shouldn't be canceled by the context of the first caller
+		go c.doRefresh(context.WithoutCancel(ctx), refresh, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-refresh.done:
+		return refresh.value, refresh.err
+	case <-ctx.Done():
+		var empty T
+		return empty, ctx.Err()
+	}
+}
+
+// getStale returns the cached value, even if it has expired (stale-while-revalidate)
+// An expired value is refreshed in the background, only the first call waits for the value.
+func (c *cache[T]) getStale(ctx context.Context, fetch func(ctx context.Context) (T, error)) (T, error) {
+	c.mu.Lock()
+	if c.expiresAt.IsZero() {
+		c.mu.Unlock()
+		return c.get(ctx, fetch)
+	}
+
+	value := c.value
+	if time.Now().After(c.expiresAt) && c.refresh == nil {
+		c.refresh = &cacheRefresh[T]{done: make(chan struct{})}
+		go c.doRefresh(context.WithoutCancel(ctx), c.refresh, fetch)
+	}
+	c.mu.Unlock()
+
+	return value, nil
+}
+
+func (c *cache[T]) doRefresh(ctx context.Context, refresh *cacheRefresh[T], fetch func(ctx context.Context) (T, error)) {
+	refresh.value, refresh.err = fetch(ctx)
+
+	c.mu.Lock()
+	if refresh.err == nil {
+		c.value = refresh.value
+		c.expiresAt = time.Now().Add(c.ttl)
+	}
+	c.refresh = nil
+	c.mu.Unlock()
+
+	close(refresh.done)
+}
+
+func getCachedTickers[T any](ctx context.Context, cache *cache[[]T], fetch func(ctx context.Context) ([]T, error)) ([]T, error) {
+	items, err := cache.getStale(ctx, fetch)
+	return slices.Clone(items), err
+}
+
+func (c *httpClient) getCachedMarkets(ctx context.Context) ([]types.Market, error) {
+	markets, err := c.markets.get(ctx, c.fetchMarkets)
+	return slices.Clone(markets), err
+}
+
+func (c *httpClient) getCachedMarket(ctx context.Context, market string) (types.Market, error) {
+	markets, err := c.markets.get(ctx, c.fetchMarkets)
+	if err != nil {
+		return types.Market{}, err
+	}
+
+	index := slices.IndexFunc(markets, func(m types.Market) bool { return m.Market == market })
+	if index < 0 {
+		// let bitvavo respond with the proper error
+		return c.fetchMarket(ctx, market)
+	}
+
+	return markets[index], nil
+}
+
+func (c *httpClient) getCachedAssets(ctx context.Context) ([]types.Asset, error) {
+	assets, err := c.assets.get(ctx, c.fetchAssets)
+	return slices.Clone(assets), err
+}
+
+func (c *httpClient) getCachedAsset(ctx context.Context, symbol string) (types.Asset, error) {
+	assets, err := c.assets.get(ctx, c.fetchAssets)
+	if err != nil {
+		return types.Asset{}, err
+	}
+
+	index := slices.IndexFunc(assets, func(a types.Asset) bool { return a.Symbol == symbol })
+	if index < 0 {
+		// let bitvavo respond with the proper error
+		return c.fetchAsset(ctx, symbol)
+	}
+
+	return assets[index], nil
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+const (
+	// The max number of candles Bitvavo returns per request.
+	maxCandlesLimit = 1440
+
+	// The rate limit which GetCandlesRange leaves for other requests, it waits for the reset otherwise.
+	candlesRangeReserve = 50
+)
+
+func (c *httpClient) GetCandlesRange(market string, interval types.Interval, start time.Time, end time.Time) ([]types.Candle, error) {
+	return c.GetCandlesRangeWithContext(context.Background(), market, interval, start, end)
+}
+
+func (c *httpClient) GetCandlesRangeWithContext(ctx context.Context, market string, interval types.Interval, start time.Time, end time.Time) ([]types.Candle, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		chunk   = interval.Duration() * maxCandlesLimit
+		candles = make(map[int64]types.Candle)
+	)
+
+	for from := start; from.Before(end); from = from.Add(chunk) {
+		to := from.Add(chunk)
+		if to.After(end) {
+			to = end
+		}
+
+		if err := c.waitForBudget(ctx, requestWeightCandles, candlesRangeReserve, false); err != nil {
+			return nil, err
+		}
+
+		page, err := c.GetCandlesWithContext(ctx, market, interval, &types.CandleParams{
+			Limit: maxCandlesLimit,
+			Start: from,
+			End:   to,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, candle := range page {
+			if candle.Timestamp >= start.UnixMilli() && candle.Timestamp < end.UnixMilli() {
+				candles[candle.Timestamp] = candle
+			}
+		}
+	}
+
+	sorted := make([]types.Candle, 0, len(candles))
+	for _, candle := range candles {
+		sorted = append(sorted, candle)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp < sorted[j].Timestamp })
+
+	return sorted, nil
+}
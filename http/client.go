@@ -7,11 +7,10 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/goccy/go-json"
-	"github.com/larscom/go-bitvavo/v2/crypto"
 	"github.com/larscom/go-bitvavo/v2/types"
 	"github.com/larscom/go-bitvavo/v2/util"
 	"github.com/rs/zerolog/log"
@@ -22,7 +21,6 @@ type OptionalParams interface {
 }
 
 var (
-	client      = http.DefaultClient
 	emptyParams = make(url.Values)
 	emptyBody   = make([]byte, 0)
 )
@@ -31,24 +29,30 @@ func httpDelete[T any](
 	ctx context.Context,
 	url string,
 	params url.Values,
-	updateRateLimit func(ratelimit int64),
-	updateRateLimitResetAt func(resetAt time.Time),
+	client *httpClient,
 	config *authConfig,
 ) (T, error) {
 	req, _ := http.NewRequestWithContext(ctx, "DELETE", createRequestUrl(url, params), nil)
-	return httpDo[T](req, emptyBody, updateRateLimit, updateRateLimitResetAt, config)
+	return httpDo[T](client, req, emptyBody, config)
 }
 
 func httpGet[T any](
 	ctx context.Context,
 	url string,
 	params url.Values,
-	updateRateLimit func(ratelimit int64),
-	updateRateLimitResetAt func(resetAt time.Time),
+	client *httpClient,
 	config *authConfig,
 ) (T, error) {
-	req, _ := http.NewRequestWithContext(ctx, "GET", createRequestUrl(url, params), nil)
-	return httpDo[T](req, emptyBody, updateRateLimit, updateRateLimitResetAt, config)
+	requestUrl := createRequestUrl(url, params)
+	do := func(ctx context.Context) (T, error) {
+		req, _ := http.NewRequestWithContext(ctx, "GET", requestUrl, nil)
+		return httpDo[T](client, req, emptyBody, config)
+	}
+
+	if config != nil {
+		return do(ctx)
+	}
+	return coalesce(ctx, client, requestUrl, do)
 }
 
 func httpPost[T any](
@@ -56,8 +60,7 @@ func httpPost[T any](
 	url string,
 	body any,
 	params url.Values,
-	updateRateLimit func(ratelimit int64),
-	updateRateLimitResetAt func(resetAt time.Time),
+	client *httpClient,
 	config *authConfig,
 ) (T, error) {
 	payload, err := json.Marshal(body)
@@ -68,7 +71,7 @@ func httpPost[T any](
 	log.Debug().Str("body", string(payload)).Msg("created request body")
 
 	req, _ := http.NewRequestWithContext(ctx, "POST", createRequestUrl(url, params), bytes.NewBuffer(payload))
-	return httpDo[T](req, payload, updateRateLimit, updateRateLimitResetAt, config)
+	return httpDo[T](client, req, payload, config)
 }
 
 func httpPut[T any](
@@ -76,8 +79,7 @@ func httpPut[T any](
 	url string,
 	body any,
 	params url.Values,
-	updateRateLimit func(ratelimit int64),
-	updateRateLimitResetAt func(resetAt time.Time),
+	client *httpClient,
 	config *authConfig,
 ) (T, error) {
 	payload, err := json.Marshal(body)
@@ -88,38 +90,73 @@ func httpPut[T any](
 	log.Debug().Str("body", string(payload)).Msg("created request body")
 
 	req, _ := http.NewRequestWithContext(ctx, "PUT", createRequestUrl(url, params), bytes.NewBuffer(payload))
-	return httpDo[T](req, payload, updateRateLimit, updateRateLimitResetAt, config)
+	return httpDo[T](client, req, payload, config)
 }
 
 func httpDo[T any](
+	client *httpClient,
 	request *http.Request,
 	body []byte,
-	updateRateLimit func(ratelimit int64),
-	updateRateLimitResetAt func(resetAt time.Time),
 	config *authConfig,
 ) (T, error) {
+	var data T
+	err := httpExecute(client, request, body, config, func(response *http.Response) error {
+		var err error
+		data, err = unwrapBody[T](response)
+		return err
+	})
+	return data, err
+}
+
+// httpExecute executes the request and calls handle with the successful response, the body is closed afterwards.
+func httpExecute(
+	client *httpClient,
+	request *http.Request,
+	body []byte,
+	config *authConfig,
+	handle func(response *http.Response) error,
+) (err error) {
 	log.Debug().Str("method", request.Method).Str("url", request.URL.String()).Msg("executing request")
 
-	var empty T
-	if err := applyHeaders(request, body, config); err != nil {
-		return empty, err
+	client.applyAcceptEncoding(request)
+	for key, values := range client.header {
+		request.Header[key] = values
 	}
 
-	response, err := client.Do(request)
+	request, cancel := client.withTimeout(request)
+	defer cancel()
+
+	request, endTrace := client.startTrace(request)
+	var response *http.Response
+	defer func() { endTrace(response, err) }()
+
+	response, err = client.do(request, body, config)
 	if err != nil {
-		return empty, err
+		return err
 	}
 	defer response.Body.Close()
 
-	if err := updateRateLimits(response, updateRateLimit, updateRateLimitResetAt); err != nil {
-		return empty, err
+	if err := decompress(response); err != nil {
+		return err
+	}
+
+	if err := client.updateRateLimits(response); err != nil {
+		return err
 	}
 
 	if response.StatusCode > http.StatusIMUsed {
-		return empty, unwrapErr(response)
+		err := unwrapErr(response)
+		if types.IsRequestExpired(err) {
+			client.invalidateClock()
+		}
+		return err
 	}
 
-	return unwrapBody[T](response)
+	if err := handle(response); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func unwrapBody[T any](response *http.Response) (T, error) {
@@ -150,41 +187,57 @@ func unwrapErr(response *http.Response) error {
 	return bitvavoErr
 }
 
-func updateRateLimits(
-	response *http.Response,
-	updateRateLimit func(ratelimit int64),
-	updateRateLimitResetAt func(resetAt time.Time),
-) error {
+func (c *httpClient) updateRateLimits(response *http.Response) error {
 	for key, value := range response.Header {
 		if key == headerRatelimit {
 			if len(value) == 0 {
 				return fmt.Errorf("header: %s didn't contain a value", headerRatelimit)
 			}
-			updateRateLimit(util.MustInt64(value[0]))
+			remaining, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("header: %s didn't contain a valid value: %w", headerRatelimit, err)
+			}
+			c.updateRateLimit(remaining)
 		}
 		if key == headerRatelimitResetAt {
 			if len(value) == 0 {
 				return fmt.Errorf("header: %s didn't contain a value", headerRatelimitResetAt)
 			}
-			updateRateLimitResetAt(time.UnixMilli(util.MustInt64(value[0])))
+			resetAt, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("header: %s didn't contain a valid value: %w", headerRatelimitResetAt, err)
+			}
+			c.updateRateLimitResetAt(time.UnixMilli(resetAt))
 		}
 	}
 	return nil
 }
 
-func applyHeaders(request *http.Request, body []byte, config *authConfig) error {
+// withTimeout applies the default timeout of the client to request, unless its context already has a deadline.
+func (c *httpClient) withTimeout(request *http.Request) (*http.Request, context.CancelFunc) {
+	ctx := request.Context()
+	if _, hasDeadline := ctx.Deadline(); c.timeout <= 0 || hasDeadline {
+		return request, func() {}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	return request.WithContext(ctx), cancel
+}
+
+func (c *httpClient) applyHeaders(request *http.Request, body []byte, config *authConfig) error {
 	if config == nil {
 		return nil
 	}
 
-	timestamp := time.Now().UnixMilli()
+	apiKey, apiSecret, err := config.credentials.Credentials(request.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get credentials: %w", err)
+	}
+	timestamp := c.now(request.Context()).UnixMilli()
 
 	request.Header.Set("Accept", "application/json")
 	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set(headerAccessKey, config.apiKey)
-	request.Header.Set(headerAccessSignature, crypto.CreateSignature(request.Method, strings.Replace(request.URL.String(), bitvavoURL, "", 1), body, timestamp, config.apiSecret))
-	request.Header.Set(headerAccessTimestamp, fmt.Sprint(timestamp))
-	request.Header.Set(headerAccessWindow, fmt.Sprint(config.windowTimeMs))
+	SignRequest(request, body, apiKey, apiSecret, timestamp, config.windowTimeMs)
 
 	return nil
 }
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const defaultClockSyncInterval = 5 * time.Minute
+
+type clock struct {
+	mu       sync.Mutex
+	interval time.Duration
+	offset   time.Duration
+	syncedAt time.Time
+	syncing  bool
+}
+
+// Synchronise the local clock with the server time of Bitvavo every interval, the offset is applied to the
+// timestamp of every signature so authenticated requests don't fail whenever the local clock drifts outside the access window.
+//
+// Set interval to 0 to disable synchronisation and use the local clock as is.
+// default: 5m
+func WithClockSync(interval time.Duration) Option {
+	return func(c *httpClient) {
+		c.clock.interval = interval
+	}
+}
+
+// now returns the local time corrected by the offset of the server time, it synchronises the clock first whenever the offset expired.
+//
+// Only one caller synchronises the clock at a time, the other callers use the previous offset meanwhile.
+func (c *httpClient) now(ctx context.Context) time.Time {
+	if c.clock.interval <= 0 {
+		return time.Now()
+	}
+
+	c.clock.mu.Lock()
+	refresh := !c.clock.syncing && time.Since(c.clock.syncedAt) >= c.clock.interval
+	if refresh {
+		c.clock.syncing = true
+	}
+	c.clock.mu.Unlock()
+
+	if refresh {
+		c.syncClock(ctx)
+	}
+
+	c.clock.mu.Lock()
+	defer c.clock.mu.Unlock()
+
+	return time.Now().Add(c.clock.offset)
+}
+
+// syncClock measures the offset between the local time and the server time, the caller must have set c.clock.syncing.
+//
+// The server time is assumed to be halfway the round trip. Whenever it fails, the previous offset is kept until the next interval.
+func (c *httpClient) syncClock(ctx context.Context) {
+	start := time.Now()
+	serverTime, err := c.GetTimeWithContext(ctx)
+	end := time.Now()
+
+	c.clock.mu.Lock()
+	defer c.clock.mu.Unlock()
+
+	c.clock.syncing = false
+	c.clock.syncedAt = end
+
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to synchronise the clock with the server time")
+		return
+	}
+
+	localTime := start.Add(end.Sub(start) / 2)
+	c.clock.offset = time.UnixMilli(serverTime).Sub(localTime)
+
+	log.Debug().Dur("offset", c.clock.offset).Msg("synchronised the clock with the server time")
+}
+
+// invalidateClock synchronises the clock on the next authenticated request (e.g: the request was rejected because of the timestamp)
+func (c *httpClient) invalidateClock() {
+	c.clock.mu.Lock()
+	defer c.clock.mu.Unlock()
+
+	c.clock.syncedAt = time.Time{}
+}
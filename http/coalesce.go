@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Coalesce identical concurrent GET requests to the public endpoints (e.g: GetMarkets from many goroutines at once)
+// into a single request, every caller receives the same result. This protects the rate limit in fan out heavy services.
+//
+// The result is shared between the callers, so don't modify the slices of a coalesced result.
+// The response metadata (see: CaptureResponseMetadata) is only captured for the caller which started the request.
+// default: false
+func WithCoalescing(coalescing bool) Option {
+	return func(c *httpClient) {
+		if coalescing {
+			c.flights = newFlightGroup()
+		} else {
+			c.flights = nil
+		}
+	}
+}
+
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+type flight struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{flights: make(map[string]*flight)}
+}
+
+// do calls fn once for every key at a time, concurrent callers with the same key wait for its result.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+	g.mu.Lock()
+	f, found := g.flights[key]
+	if !found {
+		f = &flight{done: make(chan struct{})}
+		g.flights[key] = f
+		// the flight is shared, so it shouldn't be canceled by the context of the first caller
+		go g.run(context.WithoutCancel(ctx), key, f, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, f *flight, fn func(ctx context.Context) (any, error)) {
+	f.value, f.err = fn(ctx)
+
+	g.mu.Lock()
+	delete(g.flights, key)
+	g.mu.Unlock()
+
+	close(f.done)
+}
+
+// coalesce executes request as a flight of the client, if coalescing is enabled.
+func coalesce[T any](ctx context.Context, client *httpClient, url string, do func(ctx context.Context) (T, error)) (T, error) {
+	if client.flights == nil {
+		return do(ctx)
+	}
+
+	var empty T
+	// the type is part of the key, the decimal client requests the same url into another type
+	key := fmt.Sprintf("%T %s", empty, url)
+	value, err := client.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+		return do(ctx)
+	})
+	if err != nil {
+		return empty, err
+	}
+	return value.(T), nil
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerContentEncoding = "Content-Encoding"
+)
+
+// Request gzip compressed responses, which reduces the latency of large responses (e.g: all tickers or deep order books)
+// on slow links. Compressed responses are decompressed transparently, whichever transport is used.
+// default: true
+func WithCompression(compression bool) Option {
+	return func(c *httpClient) {
+		c.compression = compression
+	}
+}
+
+func (c *httpClient) applyAcceptEncoding(request *http.Request) {
+	if c.compression {
+		request.Header.Set(headerAcceptEncoding, "gzip")
+	} else {
+		request.Header.Set(headerAcceptEncoding, "identity")
+	}
+}
+
+// decompress replaces the body of a gzip compressed response with a decompressing reader.
+func decompress(response *http.Response) error {
+	if !strings.EqualFold(response.Header.Get(headerContentEncoding), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(response.Body)
+	if err != nil {
+		return err
+	}
+
+	response.Body = &gzipBody{reader: reader, body: response.Body}
+	response.Header.Del(headerContentEncoding)
+	response.ContentLength = -1
+	response.Uncompressed = true
+
+	return nil
+}
+
+type gzipBody struct {
+	reader *gzip.Reader
+	body   io.ReadCloser
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	return b.reader.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	b.reader.Close()
+	return b.body.Close()
+}
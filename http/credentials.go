@@ -0,0 +1,23 @@
+package http
+
+import "context"
+
+// CredentialsProvider provides the apiKey and apiSecret for authenticated requests.
+// It's called for every request, so keys can be rotated (e.g: from a vault) without creating a new client.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (apiKey string, apiSecret string, err error)
+}
+
+// CredentialsProviderFunc is an adapter to use an ordinary function as CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (apiKey string, apiSecret string, err error)
+
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (string, string, error) {
+	return f(ctx)
+}
+
+// StaticCredentials returns a CredentialsProvider which always provides the same apiKey and apiSecret.
+func StaticCredentials(apiKey string, apiSecret string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (string, string, error) {
+		return apiKey, apiSecret, nil
+	})
+}
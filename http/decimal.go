@@ -0,0 +1,399 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/typesdec"
+)
+
+// HttpClientDec makes the requests of HttpClient which contain prices and amounts,
+// with decimal types (see: typesdec) so no precision is lost.
+type HttpClientDec interface {
+	// GetOrderBook returns a book with bids and asks for market, see HttpClient.GetOrderBook
+	GetOrderBook(market string, depth ...uint64) (typesdec.Book, error)
+	GetOrderBookWithContext(ctx context.Context, market string, depth ...uint64) (typesdec.Book, error)
+
+	// GetTrades returns the list of all trades made by all Bitvavo users for market, see HttpClient.GetTrades
+	GetTrades(market string, opt ...OptionalParams) ([]typesdec.Trade, error)
+	GetTradesWithContext(ctx context.Context, market string, opt ...OptionalParams) ([]typesdec.Trade, error)
+
+	// GetCandles returns the OHLCV data for market with interval, see HttpClient.GetCandles
+	GetCandles(market string, interval types.Interval, opt ...OptionalParams) ([]typesdec.Candle, error)
+	GetCandlesWithContext(ctx context.Context, market string, interval types.Interval, opt ...OptionalParams) ([]typesdec.Candle, error)
+
+	// GetTickerPrices returns price of the latest trades on Bitvavo for all markets.
+	GetTickerPrices() ([]typesdec.TickerPrice, error)
+	GetTickerPricesWithContext(ctx context.Context) ([]typesdec.TickerPrice, error)
+
+	// GetTickerPrice returns price of the latest trades on Bitvavo for a single market (e.g: ETH-EUR).
+	GetTickerPrice(market string) (typesdec.TickerPrice, error)
+	GetTickerPriceWithContext(ctx context.Context, market string) (typesdec.TickerPrice, error)
+
+	// GetTickerBooks returns the highest buy and the lowest sell prices currently available for all markets.
+	GetTickerBooks() ([]typesdec.TickerBook, error)
+	GetTickerBooksWithContext(ctx context.Context) ([]typesdec.TickerBook, error)
+
+	// GetTickerBook returns the highest buy and the lowest sell prices currently available for a single market (e.g: ETH-EUR).
+	GetTickerBook(market string) (typesdec.TickerBook, error)
+	GetTickerBookWithContext(ctx context.Context, market string) (typesdec.TickerBook, error)
+
+	// GetTickers24h returns the 24 hour ticker for all markets.
+	GetTickers24h() ([]typesdec.Ticker24h, error)
+	GetTickers24hWithContext(ctx context.Context) ([]typesdec.Ticker24h, error)
+
+	// GetTicker24h returns the 24 hour ticker for a single market (e.g: ETH-EUR).
+	GetTicker24h(market string) (typesdec.Ticker24h, error)
+	GetTicker24hWithContext(ctx context.Context, market string) (typesdec.Ticker24h, error)
+}
+
+// HttpClientAuthDec makes the requests of HttpClientAuth which contain prices and amounts,
+// with decimal types (see: typesdec) so no precision is lost.
+type HttpClientAuthDec interface {
+	// GetBalance returns the balance on the account, see HttpClientAuth.GetBalance
+	GetBalance(symbol ...string) ([]typesdec.Balance, error)
+	GetBalanceWithContext(ctx context.Context, symbol ...string) ([]typesdec.Balance, error)
+
+	// GetTrades returns historic trades for your account for market (e.g: ETH-EUR)
+	GetTrades(market string, opt ...OptionalParams) ([]typesdec.TradeHistoric, error)
+	GetTradesWithContext(ctx context.Context, market string, opt ...OptionalParams) ([]typesdec.TradeHistoric, error)
+
+	// GetOrders returns data for multiple orders at once for market (e.g: ETH-EUR)
+	GetOrders(market string, opt ...OptionalParams) ([]typesdec.Order, error)
+	GetOrdersWithContext(ctx context.Context, market string, opt ...OptionalParams) ([]typesdec.Order, error)
+
+	// GetOrdersOpen returns all open orders for market (e.g: ETH-EUR) or all open orders if no market is given.
+	GetOrdersOpen(market ...string) ([]typesdec.Order, error)
+	GetOrdersOpenWithContext(ctx context.Context, market ...string) ([]typesdec.Order, error)
+
+	// GetOrder returns the order by market and ID
+	GetOrder(market string, orderId string) (typesdec.Order, error)
+	GetOrderWithContext(ctx context.Context, market string, orderId string) (typesdec.Order, error)
+
+	// NewOrder places a new order on the exchange.
+	NewOrder(market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error)
+	NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error)
+
+	// UpdateOrder updates an existing order on the exchange.
+	UpdateOrder(market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error)
+	UpdateOrderWithContext(ctx context.Context, market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error)
+}
+
+type httpClientDec struct {
+	client *httpClient
+}
+
+type httpClientAuthDec struct {
+	client *httpClient
+	config *authConfig
+}
+
+func (c *httpClient) ToDecimalClient() HttpClientDec {
+	return &httpClientDec{client: c}
+}
+
+func (c *httpClientAuth) ToDecimalClient() HttpClientAuthDec {
+	return &httpClientAuthDec{client: c.client, config: c.config}
+}
+
+func (c *httpClientDec) GetOrderBook(market string, depth ...uint64) (typesdec.Book, error) {
+	return c.GetOrderBookWithContext(context.Background(), market, depth...)
+}
+
+func (c *httpClientDec) GetOrderBookWithContext(ctx context.Context, market string, depth ...uint64) (typesdec.Book, error) {
+	params := make(url.Values)
+	if len(depth) > 0 {
+		params.Add("depth", fmt.Sprint(depth[0]))
+	}
+
+	return httpGet[typesdec.Book](
+		ctx,
+		fmt.Sprintf("%s/%s/book", bitvavoURL, market),
+		params,
+		c.client,
+		nil,
+	)
+}
+
+func (c *httpClientDec) GetTrades(market string, opt ...OptionalParams) ([]typesdec.Trade, error) {
+	return c.GetTradesWithContext(context.Background(), market, opt...)
+}
+
+func (c *httpClientDec) GetTradesWithContext(ctx context.Context, market string, opt ...OptionalParams) ([]typesdec.Trade, error) {
+	params := make(url.Values)
+	if len(opt) > 0 {
+		params = opt[0].Params()
+	}
+
+	return httpGet[[]typesdec.Trade](
+		ctx,
+		fmt.Sprintf("%s/%s/trades", bitvavoURL, market),
+		params,
+		c.client,
+		nil,
+	)
+}
+
+func (c *httpClientDec) GetCandles(market string, interval types.Interval, opt ...OptionalParams) ([]typesdec.Candle, error) {
+	return c.GetCandlesWithContext(context.Background(), market, interval, opt...)
+}
+
+func (c *httpClientDec) GetCandlesWithContext(ctx context.Context, market string, interval types.Interval, opt ...OptionalParams) ([]typesdec.Candle, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := make(url.Values)
+	if len(opt) > 0 {
+		params = opt[0].Params()
+	}
+	params.Add("interval", interval.String())
+
+	return httpGet[[]typesdec.Candle](
+		ctx,
+		fmt.Sprintf("%s/%s/candles", bitvavoURL, market),
+		params,
+		c.client,
+		nil,
+	)
+}
+
+func (c *httpClientDec) GetTickerPrices() ([]typesdec.TickerPrice, error) {
+	return c.GetTickerPricesWithContext(context.Background())
+}
+
+func (c *httpClientDec) GetTickerPricesWithContext(ctx context.Context) ([]typesdec.TickerPrice, error) {
+	params := emptyParams
+
+	return httpGet[[]typesdec.TickerPrice](
+		ctx,
+		fmt.Sprintf("%s/ticker/price", bitvavoURL),
+		params,
+		c.client,
+		nil,
+	)
+}
+
+func (c *httpClientDec) GetTickerPrice(market string) (typesdec.TickerPrice, error) {
+	return c.GetTickerPriceWithContext(context.Background(), market)
+}
+
+func (c *httpClientDec) GetTickerPriceWithContext(ctx context.Context, market string) (typesdec.TickerPrice, error) {
+	params := make(url.Values)
+	params.Add("market", market)
+
+	return httpGet[typesdec.TickerPrice](
+		ctx,
+		fmt.Sprintf("%s/ticker/price", bitvavoURL),
+		params,
+		c.client,
+		nil,
+	)
+}
+
+func (c *httpClientDec) GetTickerBooks() ([]typesdec.TickerBook, error) {
+	return c.GetTickerBooksWithContext(context.Background())
+}
+
+func (c *httpClientDec) GetTickerBooksWithContext(ctx context.Context) ([]typesdec.TickerBook, error) {
+	params := emptyParams
+
+	return httpGet[[]typesdec.TickerBook](
+		ctx,
+		fmt.Sprintf("%s/ticker/book", bitvavoURL),
+		params,
+		c.client,
+		nil,
+	)
+}
+
+func (c *httpClientDec) GetTickerBook(market string) (typesdec.TickerBook, error) {
+	return c.GetTickerBookWithContext(context.Background(), market)
+}
+
+func (c *httpClientDec) GetTickerBookWithContext(ctx context.Context, market string) (typesdec.TickerBook, error) {
+	params := make(url.Values)
+	params.Add("market", market)
+
+	return httpGet[typesdec.TickerBook](
+		ctx,
+		fmt.Sprintf("%s/ticker/book", bitvavoURL),
+		params,
+		c.client,
+		nil,
+	)
+}
+
+func (c *httpClientDec) GetTickers24h() ([]typesdec.Ticker24h, error) {
+	return c.GetTickers24hWithContext(context.Background())
+}
+
+func (c *httpClientDec) GetTickers24hWithContext(ctx context.Context) ([]typesdec.Ticker24h, error) {
+	params := emptyParams
+
+	return httpGet[[]typesdec.Ticker24h](
+		ctx,
+		fmt.Sprintf("%s/ticker/24h", bitvavoURL),
+		params,
+		c.client,
+		nil,
+	)
+}
+
+func (c *httpClientDec) GetTicker24h(market string) (typesdec.Ticker24h, error) {
+	return c.GetTicker24hWithContext(context.Background(), market)
+}
+
+func (c *httpClientDec) GetTicker24hWithContext(ctx context.Context, market string) (typesdec.Ticker24h, error) {
+	params := make(url.Values)
+	params.Add("market", market)
+
+	return httpGet[typesdec.Ticker24h](
+		ctx,
+		fmt.Sprintf("%s/ticker/24h", bitvavoURL),
+		params,
+		c.client,
+		nil,
+	)
+}
+
+func (c *httpClientAuthDec) GetBalance(symbol ...string) ([]typesdec.Balance, error) {
+	return c.GetBalanceWithContext(context.Background(), symbol...)
+}
+
+func (c *httpClientAuthDec) GetBalanceWithContext(ctx context.Context, symbol ...string) ([]typesdec.Balance, error) {
+	params := make(url.Values)
+	if len(symbol) > 0 {
+		params.Add("symbol", symbol[0])
+	}
+
+	return httpGet[[]typesdec.Balance](
+		ctx,
+		fmt.Sprintf("%s/balance", bitvavoURL),
+		params,
+		c.client,
+		c.config,
+	)
+}
+
+func (c *httpClientAuthDec) GetTrades(market string, opt ...OptionalParams) ([]typesdec.TradeHistoric, error) {
+	return c.GetTradesWithContext(context.Background(), market, opt...)
+}
+
+func (c *httpClientAuthDec) GetTradesWithContext(ctx context.Context, market string, opt ...OptionalParams) ([]typesdec.TradeHistoric, error) {
+	params := make(url.Values)
+	if len(opt) > 0 {
+		params = opt[0].Params()
+	}
+	params.Add("market", market)
+
+	return httpGet[[]typesdec.TradeHistoric](
+		ctx,
+		fmt.Sprintf("%s/trades", bitvavoURL),
+		params,
+		c.client,
+		c.config,
+	)
+}
+
+func (c *httpClientAuthDec) GetOrders(market string, opt ...OptionalParams) ([]typesdec.Order, error) {
+	return c.GetOrdersWithContext(context.Background(), market, opt...)
+}
+
+func (c *httpClientAuthDec) GetOrdersWithContext(ctx context.Context, market string, opt ...OptionalParams) ([]typesdec.Order, error) {
+	params := make(url.Values)
+	if len(opt) > 0 {
+		params = opt[0].Params()
+	}
+	params.Add("market", market)
+
+	return httpGet[[]typesdec.Order](
+		ctx,
+		fmt.Sprintf("%s/orders", bitvavoURL),
+		params,
+		c.client,
+		c.config,
+	)
+}
+
+func (c *httpClientAuthDec) GetOrdersOpen(market ...string) ([]typesdec.Order, error) {
+	return c.GetOrdersOpenWithContext(context.Background(), market...)
+}
+
+func (c *httpClientAuthDec) GetOrdersOpenWithContext(ctx context.Context, market ...string) ([]typesdec.Order, error) {
+	params := make(url.Values)
+	if len(market) > 0 {
+		params.Add("market", market[0])
+	}
+
+	return httpGet[[]typesdec.Order](
+		ctx,
+		fmt.Sprintf("%s/ordersOpen", bitvavoURL),
+		params,
+		c.client,
+		c.config,
+	)
+}
+
+func (c *httpClientAuthDec) GetOrder(market string, orderId string) (typesdec.Order, error) {
+	return c.GetOrderWithContext(context.Background(), market, orderId)
+}
+
+func (c *httpClientAuthDec) GetOrderWithContext(ctx context.Context, market string, orderId string) (typesdec.Order, error) {
+	params := make(url.Values)
+	params.Add("market", market)
+	params.Add("orderId", orderId)
+
+	return httpGet[typesdec.Order](
+		ctx,
+		fmt.Sprintf("%s/order", bitvavoURL),
+		params,
+		c.client,
+		c.config,
+	)
+}
+
+func (c *httpClientAuthDec) NewOrder(market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error) {
+	return c.NewOrderWithContext(context.Background(), market, side, orderType, order)
+}
+
+func (c *httpClientAuthDec) NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error) {
+	order.Market = market
+	order.Side = side
+	order.OrderType = orderType
+	if order.OperatorId == 0 {
+		order.OperatorId = c.client.operatorId
+	}
+
+	return httpPost[typesdec.Order](
+		ctx,
+		fmt.Sprintf("%s/order", bitvavoURL),
+		order,
+		emptyParams,
+		c.client,
+		c.config,
+	)
+}
+
+func (c *httpClientAuthDec) UpdateOrder(market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error) {
+	return c.UpdateOrderWithContext(context.Background(), market, orderId, order)
+}
+
+func (c *httpClientAuthDec) UpdateOrderWithContext(ctx context.Context, market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error) {
+	order.Market = market
+	order.OrderId = orderId
+	if order.OperatorId == 0 {
+		order.OperatorId = c.client.operatorId
+	}
+
+	return httpPut[typesdec.Order](
+		ctx,
+		fmt.Sprintf("%s/order", bitvavoURL),
+		order,
+		emptyParams,
+		c.client,
+		c.config,
+	)
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog/log"
+)
+
+// Do signs and executes a request to any endpoint of Bitvavo with client, and unmarshals the response into T.
+// So you can call an endpoint the moment Bitvavo ships it (e.g: Do[map[string]any](ctx, client, "GET", "/account/fees", params, nil))
+//
+// The path is relative to the api version (e.g: /order), params is added as query and body (if not nil) is sent as JSON.
+// The request goes through the same rate limit, retries, hooks and middleware as any other request of client.
+func Do[T any](
+	ctx context.Context,
+	client HttpClientAuth,
+	method string,
+	path string,
+	params url.Values,
+	body any,
+) (T, error) {
+	var empty T
+
+	payload := emptyBody
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return empty, err
+		}
+		log.Debug().Str("body", string(payload)).Msg("created request body")
+	}
+
+	raw, err := client.DoRaw(ctx, method, path, params, payload)
+	if err != nil {
+		return empty, err
+	}
+
+	var data T
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return empty, err
+	}
+	return data, nil
+}
+
+func (c *httpClientAuth) DoRaw(ctx context.Context, method string, path string, params url.Values, payload []byte) ([]byte, error) {
+	requestUrl := createRequestUrl(fmt.Sprintf("%s/%s", bitvavoURL, strings.TrimPrefix(path, "/")), params)
+	var reader io.Reader
+	if len(payload) > 0 {
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), requestUrl, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := httpDo[json.RawMessage](c.client, req, payload, c.config)
+	if err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
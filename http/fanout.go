@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+const (
+	// The max number of concurrent requests of a single fan out.
+	maxFanOutWorkers = 5
+
+	// The rate limit which a fan out leaves for other requests, it waits for the reset otherwise.
+	fanOutReserve = 50
+)
+
+func (c *httpClient) GetTickers24hFor(markets []string) (map[string]types.Ticker24h, error) {
+	return c.GetTickers24hForWithContext(context.Background(), markets)
+}
+
+func (c *httpClient) GetTickers24hForWithContext(ctx context.Context, markets []string) (map[string]types.Ticker24h, error) {
+	return fanOut(ctx, c, markets, endpointWeights["GET /ticker/24h"], c.GetTicker24hWithContext)
+}
+
+func (c *httpClient) GetOrderBooks(markets []string, depth ...uint64) (map[string]types.Book, error) {
+	return c.GetOrderBooksWithContext(context.Background(), markets, depth...)
+}
+
+func (c *httpClient) GetOrderBooksWithContext(ctx context.Context, markets []string, depth ...uint64) (map[string]types.Book, error) {
+	return fanOut(ctx, c, markets, endpointWeights["GET /{market}/book"], func(ctx context.Context, market string) (types.Book, error) {
+		return c.GetOrderBookWithContext(ctx, market, depth...)
+	})
+}
+
+// fanOut fetches every market concurrently with a bounded number of workers, keyed by market.
+// Each request waits until the rate limit (minus weight) is above the reserve, the first error cancels the other requests.
+func fanOut[T any](
+	ctx context.Context,
+	client *httpClient,
+	markets []string,
+	weight int64,
+	fetch func(ctx context.Context, market string) (T, error),
+) (map[string]T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		results  = make(map[string]T, len(markets))
+		marketch = make(chan string)
+	)
+
+	for range min(maxFanOutWorkers, len(markets)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for market := range marketch {
+				result, err := fetchMarket(ctx, client, market, weight, fetch)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				if err == nil {
+					results[market] = result
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]bool, len(markets))
+send:
+	for _, market := range markets {
+		if seen[market] {
+			continue
+		}
+		seen[market] = true
+
+		select {
+		case <-ctx.Done():
+			break send
+		case marketch <- market:
+		}
+	}
+	close(marketch)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func fetchMarket[T any](
+	ctx context.Context,
+	client *httpClient,
+	market string,
+	weight int64,
+	fetch func(ctx context.Context, market string) (T, error),
+) (T, error) {
+	if err := client.waitForBudget(ctx, weight, fanOutReserve, false); err != nil {
+		var empty T
+		return empty, err
+	}
+
+	result, err := fetch(ctx, market)
+	if err != nil {
+		return result, fmt.Errorf("market %s: %w", market, err)
+	}
+	return result, nil
+}
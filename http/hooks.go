@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http"
+	"time"
+)
+
+// Call hook before every attempt of a request is sent, after the request has been signed.
+// The hook shouldn't read the body of the request.
+// default: no hook
+func OnRequest(hook func(request *http.Request)) Option {
+	return func(c *httpClient) {
+		c.onRequest = append(c.onRequest, hook)
+	}
+}
+
+// Call hook after every attempt of a request with its response and latency, the response is nil whenever the attempt
+// failed without a response (e.g: a network error). The hook shouldn't read the body of the response.
+// default: no hook
+func OnAttempt(hook func(request *http.Request, response *http.Response, latency time.Duration)) Option {
+	return func(c *httpClient) {
+		c.onAttempt = append(c.onAttempt, hook)
+	}
+}
+
+// Call hook after every response is received with the latency of the attempt.
+// The hook shouldn't read the body of the response.
+// default: no hook
+func OnResponse(hook func(response *http.Response, latency time.Duration)) Option {
+	return func(c *httpClient) {
+		c.onResponse = append(c.onResponse, hook)
+	}
+}
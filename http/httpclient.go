@@ -3,8 +3,9 @@ package http
 import (
 	"context"
 	"fmt"
+	"iter"
+	"net/http"
 	"net/url"
-	"sync"
 	"time"
 
 	"github.com/larscom/go-bitvavo/v2/types"
@@ -13,6 +14,7 @@ import (
 
 const (
 	bitvavoURL          = "https://api.bitvavo.com/v2"
+	bitvavoPath         = "/v2"
 	maxWindowTimeMs     = 60000
 	defaultWindowTimeMs = 10000
 
@@ -33,6 +35,13 @@ type HttpClient interface {
 	// GetRateLimitResetAt returns the time (local time) when the counter resets.
 	GetRateLimitResetAt() time.Time
 
+	// RateLimitEstimate returns the estimated remaining rate limit.
+	// The weight of each request is subtracted locally before it is sent, the estimate
+	// is corrected by the remaining rate limit of every response.
+	//
+	// Default value: 1000
+	RateLimitEstimate() int64
+
 	// ToAuthClient returns a client for authenticated requests.
 	// You need to provide an apiKey and an apiSecret which you can create in the bitvavo dashboard.
 	//
@@ -42,6 +51,15 @@ type HttpClient interface {
 	// Whenever you go higher than the max value of 60000 the value will be set to 60000.
 	ToAuthClient(apiKey string, apiSecret string, windowTimeMs ...uint64) HttpClientAuth
 
+	// ToAuthClientWithCredentials returns a client for authenticated requests, the apiKey and apiSecret
+	// are provided by credentials for every request (e.g: to rotate keys)
+	//
+	// WindowTimeMs is the same as for ToAuthClient.
+	ToAuthClientWithCredentials(credentials CredentialsProvider, windowTimeMs ...uint64) HttpClientAuth
+
+	// ToDecimalClient returns a client which returns prices and amounts as decimal.Decimal instead of float64.
+	ToDecimalClient() HttpClientDec
+
 	// GetTime returns the current server time in milliseconds since 1 Jan 1970
 	GetTime() (int64, error)
 	GetTimeWithContext(ctx context.Context) (int64, error)
@@ -56,6 +74,18 @@ type HttpClient interface {
 	GetMarket(market string) (types.Market, error)
 	GetMarketWithContext(ctx context.Context, market string) (types.Market, error)
 
+	// GetMarketsWhere returns the markets for which filter returns true.
+	GetMarketsWhere(filter func(market types.Market) bool) ([]types.Market, error)
+	GetMarketsWhereWithContext(ctx context.Context, filter func(market types.Market) bool) ([]types.Market, error)
+
+	// GetMarketsByQuote returns the markets with quote currency (e.g: EUR)
+	GetMarketsByQuote(quote string) ([]types.Market, error)
+	GetMarketsByQuoteWithContext(ctx context.Context, quote string) ([]types.Market, error)
+
+	// GetTradingMarkets returns the markets with status trading.
+	GetTradingMarkets() ([]types.Market, error)
+	GetTradingMarketsWithContext(ctx context.Context) ([]types.Market, error)
+
 	// GetAssets returns information on the supported assets
 	GetAssets() ([]types.Asset, error)
 	GetAssetsWithContext(ctx context.Context) ([]types.Asset, error)
@@ -64,6 +94,11 @@ type HttpClient interface {
 	GetAsset(symbol string) (types.Asset, error)
 	GetAssetWithContext(ctx context.Context, symbol string) (types.Asset, error)
 
+	// EstimateWithdrawalCost returns the fee, the net amount which is received and the total amount which
+	// is deducted from your balance for withdrawing amount of symbol (e.g: ETH)
+	EstimateWithdrawalCost(symbol string, amount float64) (types.WithdrawalCost, error)
+	EstimateWithdrawalCostWithContext(ctx context.Context, symbol string, amount float64) (types.WithdrawalCost, error)
+
 	// GetOrderBook returns a book with bids and asks for market.
 	// That is, the buy and sell orders made by all Bitvavo users in a specific market (e.g: ETH-EUR).
 	// The orders in the return parameters are sorted by price
@@ -79,12 +114,34 @@ type HttpClient interface {
 	GetTrades(market string, params ...OptionalParams) ([]types.Trade, error)
 	GetTradesWithContext(ctx context.Context, market string, params ...OptionalParams) ([]types.Trade, error)
 
+	// GetTradesSeq is the same as GetTrades, but the trades are decoded one by one while the response
+	// is read, so large responses are never buffered entirely. A failure is yielded as the last item.
+	GetTradesSeq(market string, params ...OptionalParams) iter.Seq2[types.Trade, error]
+	GetTradesSeqWithContext(ctx context.Context, market string, params ...OptionalParams) iter.Seq2[types.Trade, error]
+
 	// GetCandles returns the Open, High, Low, Close, Volume (OHLCV) data you use to create candlestick charts
 	// for market with interval time between each candlestick (e.g: market=ETH-EUR interval=5m)
 	//
 	// Optionally provide extra params (see: CandleParams)
-	GetCandles(market string, interval string, params ...OptionalParams) ([]types.Candle, error)
-	GetCandlesWithContext(ctx context.Context, market string, interval string, params ...OptionalParams) ([]types.Candle, error)
+	GetCandles(market string, interval types.Interval, params ...OptionalParams) ([]types.Candle, error)
+	GetCandlesWithContext(ctx context.Context, market string, interval types.Interval, params ...OptionalParams) ([]types.Candle, error)
+
+	// GetCandlesSeq is the same as GetCandles, but the candles are decoded one by one while the response
+	// is read, so large responses are never buffered entirely. A failure is yielded as the last item.
+	GetCandlesSeq(market string, interval types.Interval, params ...OptionalParams) iter.Seq2[types.Candle, error]
+	GetCandlesSeqWithContext(ctx context.Context, market string, interval types.Interval, params ...OptionalParams) iter.Seq2[types.Candle, error]
+
+	// GetCandlesRange returns the candles for market with interval between start and end, sorted by time.
+	// The range is split into chunks of at most 1440 candles which are requested one after the other,
+	// it waits for the rate limit to reset whenever the remaining rate limit gets low.
+	GetCandlesRange(market string, interval types.Interval, start time.Time, end time.Time) ([]types.Candle, error)
+	GetCandlesRangeWithContext(ctx context.Context, market string, interval types.Interval, start time.Time, end time.Time) ([]types.Candle, error)
+
+	// GetTradesRange returns the trades made by all Bitvavo users for market between start and end, sorted by time (oldest first)
+	// The trades are requested in pages of at most 1000 trades one after the other,
+	// it waits for the rate limit to reset whenever the remaining rate limit gets low.
+	GetTradesRange(market string, start time.Time, end time.Time) ([]types.Trade, error)
+	GetTradesRangeWithContext(ctx context.Context, market string, start time.Time, end time.Time) ([]types.Trade, error)
 
 	// GetTickerPrices returns price of the latest trades on Bitvavo for all markets.
 	GetTickerPrices() ([]types.TickerPrice, error)
@@ -111,25 +168,132 @@ type HttpClient interface {
 	// GetTicker24h returns high, low, open, last, and volume information for trades and orders for a single market over the previous 24 hours.
 	GetTicker24h(market string) (types.Ticker24h, error)
 	GetTicker24hWithContext(ctx context.Context, market string) (types.Ticker24h, error)
+
+	// GetTickers24hFor returns the ticker 24h of multiple markets (e.g: ETH-EUR, BTC-EUR) keyed by market.
+	// The markets are requested concurrently (max 5 at once) and it waits for the rate limit to reset
+	// whenever the remaining rate limit gets low.
+	GetTickers24hFor(markets []string) (map[string]types.Ticker24h, error)
+	GetTickers24hForWithContext(ctx context.Context, markets []string) (map[string]types.Ticker24h, error)
+
+	// GetOrderBooks returns the book of multiple markets (e.g: ETH-EUR, BTC-EUR) keyed by market.
+	// The markets are requested concurrently (max 5 at once) and it waits for the rate limit to reset
+	// whenever the remaining rate limit gets low.
+	//
+	// Optionally provide the depth (single value) to return the top depth orders only.
+	GetOrderBooks(markets []string, depth ...uint64) (map[string]types.Book, error)
+	GetOrderBooksWithContext(ctx context.Context, markets []string, depth ...uint64) (map[string]types.Book, error)
 }
 
 type httpClient struct {
-	mu               sync.RWMutex
-	ratelimit        int64
-	ratelimitResetAt time.Time
+	ratelimiter      *RateLimiter
+	httpclient       *http.Client
+	transport        http.RoundTripper
+	timeout          time.Duration
+	header           http.Header
+	compression      bool
+	middleware       []Middleware
+	doer             Doer
+	onRequest        []func(request *http.Request)
+	onResponse       []func(response *http.Response, latency time.Duration)
+	onAttempt        []func(request *http.Request, response *http.Response, latency time.Duration)
+	tracer           Tracer
+	retryPolicy      *RetryPolicy
+	rateLimitWait    bool
+	ratelimitGuard   *rateLimitGuard
+	operatorId       int64
+	withdrawalGuards []WithdrawalGuard
+	idempotencyStore IdempotencyStore
+	markets          *cache[[]types.Market]
+	assets           *cache[[]types.Asset]
+	tickerPrices     *cache[[]types.TickerPrice]
+	tickerBooks      *cache[[]types.TickerBook]
+	tickers24h       *cache[[]types.Ticker24h]
+	flights          *flightGroup
+	clock            clock
 
 	authClient *httpClientAuth
 }
 
-func NewHttpClient() HttpClient {
+func NewHttpClient(options ...Option) HttpClient {
 	client := &httpClient{
-		ratelimit: -1,
+		ratelimiter: NewRateLimiter(),
+		httpclient:  http.DefaultClient,
+		header:      make(http.Header),
+		compression: true,
+		clock:       clock{interval: defaultClockSyncInterval},
+	}
+	for _, opt := range options {
+		opt(client)
 	}
+	if client.transport != nil {
+		httpclient := *client.httpclient
+		httpclient.Transport = client.transport
+		client.httpclient = &httpclient
+	}
+	client.doer = client.chain()
 
 	return client
 }
 
+type Option func(*httpClient)
+
+// The http.Client which executes the requests, so you can set timeouts, proxies, connection pools and instrumentation.
+// The transport of WithTransport takes precedence over the transport of httpclient, regardless of the order of the options.
+// default: http.DefaultClient
+func WithHTTPClient(httpclient *http.Client) Option {
+	return func(c *httpClient) {
+		c.httpclient = httpclient
+	}
+}
+
+// The default timeout of every request (including retries), whenever the context of the request has no deadline.
+// A deadline of the context always takes precedence, so you can still override it per request.
+// default: no timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *httpClient) {
+		c.timeout = timeout
+	}
+}
+
+// The User-Agent header which is sent with every request, so Bitvavo support can identify your integration.
+// default: the User-Agent of net/http
+func WithUserAgent(userAgent string) Option {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// A header which is sent with every request (e.g: a tracing header for a gateway)
+// default: no extra headers
+func WithHeader(key string, value string) Option {
+	return func(c *httpClient) {
+		c.header.Add(key, value)
+	}
+}
+
+// The operatorId which is sent with every order placement, update and cancellation,
+// unless the order itself has an operatorId. Bitvavo requires it for some accounts.
+// default: no operatorId
+func WithOperatorId(operatorId int64) Option {
+	return func(c *httpClient) {
+		c.operatorId = operatorId
+	}
+}
+
+// The transport of the http.Client which executes the requests.
+//
+// It takes precedence over the transport of the client of WithHTTPClient, regardless of the order of the options.
+// The transport is set on a copy of that client, so the client itself isn't changed.
+// default: the transport of the http.Client (see: WithHTTPClient)
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *httpClient) {
+		c.transport = transport
+	}
+}
+
 func (c *httpClient) ToAuthClient(apiKey string, apiSecret string, windowTimeMs ...uint64) HttpClientAuth {
+	return c.ToAuthClientWithCredentials(StaticCredentials(apiKey, apiSecret), windowTimeMs...)
+}
+
+func (c *httpClient) ToAuthClientWithCredentials(credentials CredentialsProvider, windowTimeMs ...uint64) HttpClientAuth {
 	if c.hasAuthClient() {
 		return c.authClient
 	}
@@ -144,20 +308,19 @@ func (c *httpClient) ToAuthClient(apiKey string, apiSecret string, windowTimeMs
 
 	config := &authConfig{
 		windowTimeMs: windowTime,
-		apiKey:       apiKey,
-		apiSecret:    apiSecret,
+		credentials:  credentials,
 	}
 
-	c.authClient = newHttpClientAuth(c.updateRateLimit, c.updateRateLimitResetAt, config)
+	c.authClient = newHttpClientAuth(c, config)
 	return c.authClient
 }
 
 func (c *httpClient) GetRateLimit() int64 {
-	return c.ratelimit
+	return c.ratelimiter.Remaining()
 }
 
 func (c *httpClient) GetRateLimitResetAt() time.Time {
-	return c.ratelimitResetAt
+	return c.ratelimiter.ResetAt()
 }
 
 func (c *httpClient) GetTime() (int64, error) {
@@ -169,8 +332,7 @@ func (c *httpClient) GetTimeWithContext(ctx context.Context) (int64, error) {
 		ctx,
 		fmt.Sprintf("%s/time", bitvavoURL),
 		emptyParams,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 	if err != nil {
@@ -185,12 +347,18 @@ func (c *httpClient) GetMarkets() ([]types.Market, error) {
 }
 
 func (c *httpClient) GetMarketsWithContext(ctx context.Context) ([]types.Market, error) {
+	if c.markets != nil {
+		return c.getCachedMarkets(ctx)
+	}
+	return c.fetchMarkets(ctx)
+}
+
+func (c *httpClient) fetchMarkets(ctx context.Context) ([]types.Market, error) {
 	return httpGet[[]types.Market](
 		ctx,
 		fmt.Sprintf("%s/markets", bitvavoURL),
 		emptyParams,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
@@ -200,6 +368,13 @@ func (c *httpClient) GetMarket(market string) (types.Market, error) {
 }
 
 func (c *httpClient) GetMarketWithContext(ctx context.Context, market string) (types.Market, error) {
+	if c.markets != nil {
+		return c.getCachedMarket(ctx, market)
+	}
+	return c.fetchMarket(ctx, market)
+}
+
+func (c *httpClient) fetchMarket(ctx context.Context, market string) (types.Market, error) {
 	params := make(url.Values)
 	params.Add("market", market)
 
@@ -207,8 +382,7 @@ func (c *httpClient) GetMarketWithContext(ctx context.Context, market string) (t
 		ctx,
 		fmt.Sprintf("%s/markets", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
@@ -218,12 +392,18 @@ func (c *httpClient) GetAssets() ([]types.Asset, error) {
 }
 
 func (c *httpClient) GetAssetsWithContext(ctx context.Context) ([]types.Asset, error) {
+	if c.assets != nil {
+		return c.getCachedAssets(ctx)
+	}
+	return c.fetchAssets(ctx)
+}
+
+func (c *httpClient) fetchAssets(ctx context.Context) ([]types.Asset, error) {
 	return httpGet[[]types.Asset](
 		ctx,
 		fmt.Sprintf("%s/assets", bitvavoURL),
 		emptyParams,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
@@ -233,6 +413,13 @@ func (c *httpClient) GetAsset(symbol string) (types.Asset, error) {
 }
 
 func (c *httpClient) GetAssetWithContext(ctx context.Context, symbol string) (types.Asset, error) {
+	if c.assets != nil {
+		return c.getCachedAsset(ctx, symbol)
+	}
+	return c.fetchAsset(ctx, symbol)
+}
+
+func (c *httpClient) fetchAsset(ctx context.Context, symbol string) (types.Asset, error) {
 	params := make(url.Values)
 	params.Add("symbol", symbol)
 
@@ -240,12 +427,23 @@ func (c *httpClient) GetAssetWithContext(ctx context.Context, symbol string) (ty
 		ctx,
 		fmt.Sprintf("%s/assets", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
 
+func (c *httpClient) EstimateWithdrawalCost(symbol string, amount float64) (types.WithdrawalCost, error) {
+	return c.EstimateWithdrawalCostWithContext(context.Background(), symbol, amount)
+}
+
+func (c *httpClient) EstimateWithdrawalCostWithContext(ctx context.Context, symbol string, amount float64) (types.WithdrawalCost, error) {
+	asset, err := c.GetAssetWithContext(ctx, symbol)
+	if err != nil {
+		return types.WithdrawalCost{}, err
+	}
+	return asset.EstimateWithdrawalCost(amount), nil
+}
+
 func (c *httpClient) GetOrderBook(market string, depth ...uint64) (types.Book, error) {
 	return c.GetOrderBookWithContext(context.Background(), market, depth...)
 }
@@ -260,8 +458,7 @@ func (c *httpClient) GetOrderBookWithContext(ctx context.Context, market string,
 		ctx,
 		fmt.Sprintf("%s/%s/book", bitvavoURL, market),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
@@ -279,29 +476,31 @@ func (c *httpClient) GetTradesWithContext(ctx context.Context, market string, op
 		ctx,
 		fmt.Sprintf("%s/%s/trades", bitvavoURL, market),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
 
-func (c *httpClient) GetCandles(market string, interval string, opt ...OptionalParams) ([]types.Candle, error) {
+func (c *httpClient) GetCandles(market string, interval types.Interval, opt ...OptionalParams) ([]types.Candle, error) {
 	return c.GetCandlesWithContext(context.Background(), market, interval, opt...)
 }
 
-func (c *httpClient) GetCandlesWithContext(ctx context.Context, market string, interval string, opt ...OptionalParams) ([]types.Candle, error) {
+func (c *httpClient) GetCandlesWithContext(ctx context.Context, market string, interval types.Interval, opt ...OptionalParams) ([]types.Candle, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+
 	params := make(url.Values)
 	if len(opt) > 0 {
 		params = opt[0].Params()
 	}
-	params.Add("interval", interval)
+	params.Add("interval", interval.String())
 
 	return httpGet[[]types.Candle](
 		ctx,
 		fmt.Sprintf("%s/%s/candles", bitvavoURL, market),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
@@ -311,12 +510,18 @@ func (c *httpClient) GetTickerPrices() ([]types.TickerPrice, error) {
 }
 
 func (c *httpClient) GetTickerPricesWithContext(ctx context.Context) ([]types.TickerPrice, error) {
+	if c.tickerPrices != nil {
+		return getCachedTickers(ctx, c.tickerPrices, c.fetchTickerPrices)
+	}
+	return c.fetchTickerPrices(ctx)
+}
+
+func (c *httpClient) fetchTickerPrices(ctx context.Context) ([]types.TickerPrice, error) {
 	return httpGet[[]types.TickerPrice](
 		ctx,
 		fmt.Sprintf("%s/ticker/price", bitvavoURL),
 		emptyParams,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
@@ -333,8 +538,7 @@ func (c *httpClient) GetTickerPriceWithContext(ctx context.Context, market strin
 		ctx,
 		fmt.Sprintf("%s/ticker/price", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
@@ -344,12 +548,18 @@ func (c *httpClient) GetTickerBooks() ([]types.TickerBook, error) {
 }
 
 func (c *httpClient) GetTickerBooksWithContext(ctx context.Context) ([]types.TickerBook, error) {
+	if c.tickerBooks != nil {
+		return getCachedTickers(ctx, c.tickerBooks, c.fetchTickerBooks)
+	}
+	return c.fetchTickerBooks(ctx)
+}
+
+func (c *httpClient) fetchTickerBooks(ctx context.Context) ([]types.TickerBook, error) {
 	return httpGet[[]types.TickerBook](
 		ctx,
 		fmt.Sprintf("%s/ticker/book", bitvavoURL),
 		emptyParams,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
@@ -366,8 +576,7 @@ func (c *httpClient) GetTickerBookWithContext(ctx context.Context, market string
 		ctx,
 		fmt.Sprintf("%s/ticker/book", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
@@ -377,12 +586,18 @@ func (c *httpClient) GetTickers24h() ([]types.Ticker24h, error) {
 }
 
 func (c *httpClient) GetTickers24hWithContext(ctx context.Context) ([]types.Ticker24h, error) {
+	if c.tickers24h != nil {
+		return getCachedTickers(ctx, c.tickers24h, c.fetchTickers24h)
+	}
+	return c.fetchTickers24h(ctx)
+}
+
+func (c *httpClient) fetchTickers24h(ctx context.Context) ([]types.Ticker24h, error) {
 	return httpGet[[]types.Ticker24h](
 		ctx,
 		fmt.Sprintf("%s/ticker/24h", bitvavoURL),
 		emptyParams,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
@@ -399,22 +614,17 @@ func (c *httpClient) GetTicker24hWithContext(ctx context.Context, market string)
 		ctx,
 		fmt.Sprintf("%s/ticker/24h", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c,
 		nil,
 	)
 }
 
 func (c *httpClient) updateRateLimit(ratelimit int64) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.ratelimit = ratelimit
+	c.ratelimiter.updateRemaining(ratelimit)
 }
 
 func (c *httpClient) updateRateLimitResetAt(resetAt time.Time) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.ratelimitResetAt = resetAt
+	c.ratelimiter.updateResetAt(resetAt)
 }
 
 func (c *httpClient) hasAuthClient() bool {
@@ -3,7 +3,7 @@ package http
 import (
 	"context"
 	"fmt"
-	"time"
+	"iter"
 
 	"net/url"
 
@@ -11,6 +11,9 @@ import (
 )
 
 type HttpClientAuth interface {
+	// ToDecimalClient returns a client which returns prices and amounts as decimal.Decimal instead of float64.
+	ToDecimalClient() HttpClientAuthDec
+
 	// GetBalance returns the balance on the account.
 	// Optionally provide the symbol to filter for in uppercase (e.g: ETH)
 	GetBalance(symbol ...string) ([]types.Balance, error)
@@ -20,18 +23,52 @@ type HttpClientAuth interface {
 	GetAccount() (types.Account, error)
 	GetAccountWithContext(ctx context.Context) (types.Account, error)
 
+	// GetFees returns your fee tier and the fees for market (e.g: ETH-EUR)
+	GetFees(market string) (types.MarketFee, error)
+	GetFeesWithContext(ctx context.Context, market string) (types.MarketFee, error)
+
+	// EstimateFee returns the expected fee and total of an order for market (e.g: ETH-EUR) of amount at price,
+	// with the taker fee if taker is true or else the maker fee of your fee tier.
+	EstimateFee(market string, side types.Side, amount float64, price float64, taker bool) (types.FeeEstimate, error)
+	EstimateFeeWithContext(ctx context.Context, market string, side types.Side, amount float64, price float64, taker bool) (types.FeeEstimate, error)
+
+	// GetPortfolioValue returns the value of every asset on the account and the total value in quote currency (e.g: EUR)
+	// It combines the balance with the latest prices of all markets.
+	GetPortfolioValue(quote string) (types.Portfolio, error)
+	GetPortfolioValueWithContext(ctx context.Context, quote string) (types.Portfolio, error)
+
 	// GetTrades returns historic trades for your account for market (e.g: ETH-EUR)
 	//
 	// Optionally provide extra params (see: TradeParams)
 	GetTrades(market string, params ...OptionalParams) ([]types.TradeHistoric, error)
 	GetTradesWithContext(ctx context.Context, market string, params ...OptionalParams) ([]types.TradeHistoric, error)
 
+	// GetTradesSeq is the same as GetTrades, but the trades are decoded one by one while the response
+	// is read, so large responses are never buffered entirely. A failure is yielded as the last item.
+	GetTradesSeq(market string, params ...OptionalParams) iter.Seq2[types.TradeHistoric, error]
+	GetTradesSeqWithContext(ctx context.Context, market string, params ...OptionalParams) iter.Seq2[types.TradeHistoric, error]
+
+	// GetAllTrades returns every historic trade for your account for market (e.g: ETH-EUR), newest first.
+	// It follows the pages with tradeIdTo until exhaustion, each page is a separate request.
+	GetAllTrades(market string) ([]types.TradeHistoric, error)
+	GetAllTradesWithContext(ctx context.Context, market string) ([]types.TradeHistoric, error)
+
 	// GetOrders returns data for multiple orders at once for market (e.g: ETH-EUR)
 	//
 	// Optionally provide extra params (see: OrderParams)
 	GetOrders(market string, params ...OptionalParams) ([]types.Order, error)
 	GetOrdersWithContext(ctx context.Context, market string, params ...OptionalParams) ([]types.Order, error)
 
+	// GetOrdersSeq is the same as GetOrders, but the orders are decoded one by one while the response
+	// is read, so large responses are never buffered entirely. A failure is yielded as the last item.
+	GetOrdersSeq(market string, params ...OptionalParams) iter.Seq2[types.Order, error]
+	GetOrdersSeqWithContext(ctx context.Context, market string, params ...OptionalParams) iter.Seq2[types.Order, error]
+
+	// GetAllOrders returns every order for market (e.g: ETH-EUR), newest first.
+	// It follows the pages with orderIdTo until exhaustion, each page is a separate request.
+	GetAllOrders(market string) ([]types.Order, error)
+	GetAllOrdersWithContext(ctx context.Context, market string) ([]types.Order, error)
+
 	// GetOrdersOpen returns all open orders for market (e.g: ETH-EUR) or all open orders
 	// if no market is given.
 	GetOrdersOpen(market ...string) ([]types.Order, error)
@@ -41,6 +78,10 @@ type HttpClientAuth interface {
 	GetOrder(market string, orderId string) (types.Order, error)
 	GetOrderWithContext(ctx context.Context, market string, orderId string) (types.Order, error)
 
+	// GetOrderByClientId returns the order by market and your own clientOrderId
+	GetOrderByClientId(market string, clientOrderId string) (types.Order, error)
+	GetOrderByClientIdWithContext(ctx context.Context, market string, clientOrderId string) (types.Order, error)
+
 	// CancelOrders cancels multiple orders at once.
 	// Either for an entire market (e.g: ETH-EUR) or for the entire account if you
 	// omit the market.
@@ -49,17 +90,38 @@ type HttpClientAuth interface {
 	CancelOrders(market ...string) ([]string, error)
 	CancelOrdersWithContext(ctx context.Context, market ...string) ([]string, error)
 
+	// CancelMarketOrders cancels the orders of multiple markets (e.g: ETH-EUR, BTC-EUR), one request per market.
+	//
+	// It returns the orderId's of which are canceled, grouped by market.
+	// Whenever a market fails, the orders of the markets canceled so far are returned with the error.
+	CancelMarketOrders(markets []string) (map[string][]string, error)
+	CancelMarketOrdersWithContext(ctx context.Context, markets []string) (map[string][]string, error)
+
 	// CancelOrder cancels a single order by ID for the specific market (e.g: ETH-EUR)
 	//
 	// It returns the canceled orderId if it was canceled
 	CancelOrder(market string, orderId string) (string, error)
 	CancelOrderWithContext(ctx context.Context, market string, orderId string) (string, error)
 
+	// CancelOrderByClientId cancels a single order by your own clientOrderId for the specific market (e.g: ETH-EUR)
+	//
+	// It returns the canceled orderId if it was canceled
+	CancelOrderByClientId(market string, clientOrderId string) (string, error)
+	CancelOrderByClientIdWithContext(ctx context.Context, market string, clientOrderId string) (string, error)
+
 	// NewOrder places a new order on the exchange.
 	//
 	// It returns the new order if it was successfully created
-	NewOrder(market string, side string, orderType string, order types.OrderNew) (types.Order, error)
-	NewOrderWithContext(ctx context.Context, market string, side string, orderType string, order types.OrderNew) (types.Order, error)
+	NewOrder(market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error)
+	NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error)
+
+	// NewOrders places multiple orders (e.g: a ladder) one after the other, the market, side and orderType are taken from each order.
+	// It waits for the rate limit to reset whenever the remaining rate limit gets low.
+	//
+	// It returns the result (order or error) of every order in input order and an error which joins the errors of the failed orders.
+	// Set failFast to skip the remaining orders after the first failure, otherwise it places every order (best effort)
+	NewOrders(orders []types.OrderNew, failFast ...bool) ([]OrderResult, error)
+	NewOrdersWithContext(ctx context.Context, orders []types.OrderNew, failFast ...bool) ([]OrderResult, error)
 
 	// UpdateOrder updates an existing order on the exchange.
 	//
@@ -67,11 +129,26 @@ type HttpClientAuth interface {
 	UpdateOrder(market string, orderId string, order types.OrderUpdate) (types.Order, error)
 	UpdateOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderUpdate) (types.Order, error)
 
+	// ReplaceOrder cancels the order by market (e.g: ETH-EUR) and ID, waits until the cancel is confirmed and places order in market.
+	//
+	// Whenever the order has been filled before it could be canceled, order isn't placed and ErrOrderFilled is returned.
+	// Set reduceByFilled to lower the amount (or amountQuote) of order by what has been filled of the canceled order.
+	// It returns the final state of the canceled order and the replacement order (if placed)
+	ReplaceOrder(market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (ReplaceResult, error)
+	ReplaceOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (ReplaceResult, error)
+
 	// GetDepositAsset returns deposit address (with paymentid for some assets)
 	// or bank account information to increase your balance for a specific symbol (e.g: ETH)
 	GetDepositAsset(symbol string) (types.DepositAsset, error)
 	GetDepositAssetWithContext(ctx context.Context, symbol string) (types.DepositAsset, error)
 
+	// GetDepositFiat returns the bank account information (IBAN, BIC and description) to wire
+	// a fiat deposit for a specific symbol (e.g: EUR) to increase your balance.
+	//
+	// It returns an error if symbol isn't deposited by bank transfer.
+	GetDepositFiat(symbol string) (types.DepositFiat, error)
+	GetDepositFiatWithContext(ctx context.Context, symbol string) (types.DepositFiat, error)
+
 	// GetDepositHistory returns the deposit history of the account.
 	//
 	// Optionally provide extra params (see: DepositHistoryParams)
@@ -84,33 +161,41 @@ type HttpClientAuth interface {
 	GetWithdrawalHistory(params ...OptionalParams) ([]types.WithdrawalHistory, error)
 	GetWithdrawalHistoryWithContext(ctx context.Context, params ...OptionalParams) ([]types.WithdrawalHistory, error)
 
+	// GetTransactionHistory returns a page of the transaction history of the account
+	// (e.g: deposits, withdrawals, trades, staking rewards and affiliate payouts)
+	//
+	// Optionally provide extra params (see: TransactionHistoryParams)
+	GetTransactionHistory(params ...OptionalParams) (types.TransactionHistory, error)
+	GetTransactionHistoryWithContext(ctx context.Context, params ...OptionalParams) (types.TransactionHistory, error)
+
 	// Withdraw requests a withdrawal to an external cryptocurrency address or verified bank account.
 	// Please note that 2FA and address confirmation by e-mail are disabled for API withdrawals.
+	//
+	// The withdrawal guards of the client (see: WithWithdrawalGuard) are checked first, it returns ErrWithdrawalBlocked if any of them blocks it.
 	Withdraw(symbol string, amount float64, address string, withdrawal types.Withdrawal) (types.WithDrawalResponse, error)
 	WithdrawWithContext(ctx context.Context, symbol string, amount float64, address string, withdrawal types.Withdrawal) (types.WithDrawalResponse, error)
+
+	// DoRaw signs and executes a request to any endpoint of Bitvavo and returns the body of the response (see: Do)
+	// The path is relative to the api version (e.g: /order), params is added as query and payload (if not empty) is sent as body.
+	DoRaw(ctx context.Context, method string, path string, params url.Values, payload []byte) ([]byte, error)
 }
 
+var errNoFiatDeposit = func(symbol string) error { return fmt.Errorf("symbol: %s has no fiat deposit", symbol) }
+
 type httpClientAuth struct {
-	config                 *authConfig
-	updateRateLimit        func(ratelimit int64)
-	updateRateLimitResetAt func(resetAt time.Time)
+	config *authConfig
+	client *httpClient
 }
 
 type authConfig struct {
-	apiKey       string
-	apiSecret    string
+	credentials  CredentialsProvider
 	windowTimeMs uint64
 }
 
-func newHttpClientAuth(
-	updateRateLimit func(ratelimit int64),
-	updateRateLimitResetAt func(resetAt time.Time),
-	config *authConfig,
-) *httpClientAuth {
+func newHttpClientAuth(client *httpClient, config *authConfig) *httpClientAuth {
 	return &httpClientAuth{
-		updateRateLimit:        updateRateLimit,
-		updateRateLimitResetAt: updateRateLimitResetAt,
-		config:                 config,
+		client: client,
+		config: config,
 	}
 }
 
@@ -128,12 +213,29 @@ func (c *httpClientAuth) GetBalanceWithContext(ctx context.Context, symbol ...st
 		ctx,
 		fmt.Sprintf("%s/balance", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
 		c.config,
 	)
 }
 
+func (c *httpClientAuth) GetPortfolioValue(quote string) (types.Portfolio, error) {
+	return c.GetPortfolioValueWithContext(context.Background(), quote)
+}
+
+func (c *httpClientAuth) GetPortfolioValueWithContext(ctx context.Context, quote string) (types.Portfolio, error) {
+	balances, err := c.GetBalanceWithContext(ctx)
+	if err != nil {
+		return types.Portfolio{}, err
+	}
+
+	prices, err := c.client.GetTickerPricesWithContext(ctx)
+	if err != nil {
+		return types.Portfolio{}, err
+	}
+
+	return types.NewPortfolio(quote, balances, prices), nil
+}
+
 func (c *httpClientAuth) GetAccount() (types.Account, error) {
 	return c.GetAccountWithContext(context.Background())
 }
@@ -143,12 +245,40 @@ func (c *httpClientAuth) GetAccountWithContext(ctx context.Context) (types.Accou
 		ctx,
 		fmt.Sprintf("%s/account", bitvavoURL),
 		emptyParams,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
+		c.config,
+	)
+}
+
+func (c *httpClientAuth) GetFees(market string) (types.MarketFee, error) {
+	return c.GetFeesWithContext(context.Background(), market)
+}
+
+func (c *httpClientAuth) GetFeesWithContext(ctx context.Context, market string) (types.MarketFee, error) {
+	params := make(url.Values)
+	params.Add("market", market)
+
+	return httpGet[types.MarketFee](
+		ctx,
+		fmt.Sprintf("%s/account/fees", bitvavoURL),
+		params,
+		c.client,
 		c.config,
 	)
 }
 
+func (c *httpClientAuth) EstimateFee(market string, side types.Side, amount float64, price float64, taker bool) (types.FeeEstimate, error) {
+	return c.EstimateFeeWithContext(context.Background(), market, side, amount, price, taker)
+}
+
+func (c *httpClientAuth) EstimateFeeWithContext(ctx context.Context, market string, side types.Side, amount float64, price float64, taker bool) (types.FeeEstimate, error) {
+	fees, err := c.GetFeesWithContext(ctx, market)
+	if err != nil {
+		return types.FeeEstimate{}, err
+	}
+	return fees.Estimate(side, amount, price, taker), nil
+}
+
 func (c *httpClientAuth) GetOrders(market string, opt ...OptionalParams) ([]types.Order, error) {
 	return c.GetOrdersWithContext(context.Background(), market, opt...)
 }
@@ -164,8 +294,7 @@ func (c *httpClientAuth) GetOrdersWithContext(ctx context.Context, market string
 		ctx,
 		fmt.Sprintf("%s/orders", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
 		c.config,
 	)
 }
@@ -184,8 +313,7 @@ func (c *httpClientAuth) GetOrdersOpenWithContext(ctx context.Context, market ..
 		ctx,
 		fmt.Sprintf("%s/ordersOpen", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
 		c.config,
 	)
 }
@@ -203,8 +331,25 @@ func (c *httpClientAuth) GetOrderWithContext(ctx context.Context, market string,
 		ctx,
 		fmt.Sprintf("%s/order", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
+		c.config,
+	)
+}
+
+func (c *httpClientAuth) GetOrderByClientId(market string, clientOrderId string) (types.Order, error) {
+	return c.GetOrderByClientIdWithContext(context.Background(), market, clientOrderId)
+}
+
+func (c *httpClientAuth) GetOrderByClientIdWithContext(ctx context.Context, market string, clientOrderId string) (types.Order, error) {
+	params := make(url.Values)
+	params.Add("market", market)
+	params.Add("clientOrderId", clientOrderId)
+
+	return httpGet[types.Order](
+		ctx,
+		fmt.Sprintf("%s/order", bitvavoURL),
+		params,
+		c.client,
 		c.config,
 	)
 }
@@ -219,12 +364,13 @@ func (c *httpClientAuth) CancelOrdersWithContext(ctx context.Context, market ...
 		params.Add("market", market[0])
 	}
 
+	c.addOperatorId(params)
+
 	resp, err := httpDelete[[]map[string]string](
 		ctx,
 		fmt.Sprintf("%s/orders", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
 		c.config,
 	)
 	if err != nil {
@@ -239,6 +385,27 @@ func (c *httpClientAuth) CancelOrdersWithContext(ctx context.Context, market ...
 	return orderIds, nil
 }
 
+func (c *httpClientAuth) CancelMarketOrders(markets []string) (map[string][]string, error) {
+	return c.CancelMarketOrdersWithContext(context.Background(), markets)
+}
+
+func (c *httpClientAuth) CancelMarketOrdersWithContext(ctx context.Context, markets []string) (map[string][]string, error) {
+	canceled := make(map[string][]string, len(markets))
+	for _, market := range markets {
+		if _, found := canceled[market]; found {
+			continue
+		}
+
+		orderIds, err := c.CancelOrdersWithContext(ctx, market)
+		if err != nil {
+			return canceled, fmt.Errorf("failed to cancel orders for market %s: %w", market, err)
+		}
+		canceled[market] = orderIds
+	}
+
+	return canceled, nil
+}
+
 func (c *httpClientAuth) CancelOrder(market string, orderId string) (string, error) {
 	return c.CancelOrderWithContext(context.Background(), market, orderId)
 }
@@ -247,13 +414,13 @@ func (c *httpClientAuth) CancelOrderWithContext(ctx context.Context, market stri
 	params := make(url.Values)
 	params.Add("market", market)
 	params.Add("orderId", orderId)
+	c.addOperatorId(params)
 
 	resp, err := httpDelete[map[string]string](
 		ctx,
 		fmt.Sprintf("%s/order", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
 		c.config,
 	)
 	if err != nil {
@@ -263,23 +430,52 @@ func (c *httpClientAuth) CancelOrderWithContext(ctx context.Context, market stri
 	return resp["orderId"], nil
 }
 
-func (c *httpClientAuth) NewOrder(market string, side string, orderType string, order types.OrderNew) (types.Order, error) {
-	return c.NewOrderWithContext(context.Background(), market, side, orderType, order)
+func (c *httpClientAuth) CancelOrderByClientId(market string, clientOrderId string) (string, error) {
+	return c.CancelOrderByClientIdWithContext(context.Background(), market, clientOrderId)
 }
 
-func (c *httpClientAuth) NewOrderWithContext(ctx context.Context, market string, side string, orderType string, order types.OrderNew) (types.Order, error) {
-	order.Market = market
-	order.Side = side
-	order.OrderType = orderType
-	return httpPost[types.Order](
+func (c *httpClientAuth) CancelOrderByClientIdWithContext(ctx context.Context, market string, clientOrderId string) (string, error) {
+	params := make(url.Values)
+	params.Add("market", market)
+	params.Add("clientOrderId", clientOrderId)
+	c.addOperatorId(params)
+
+	resp, err := httpDelete[map[string]string](
 		ctx,
 		fmt.Sprintf("%s/order", bitvavoURL),
-		order,
-		emptyParams,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		params,
+		c.client,
 		c.config,
 	)
+	if err != nil {
+		return "", err
+	}
+
+	return resp["orderId"], nil
+}
+
+func (c *httpClientAuth) NewOrder(market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	return c.NewOrderWithContext(context.Background(), market, side, orderType, order)
+}
+
+func (c *httpClientAuth) NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	order.Market = market
+	order.Side = side
+	order.OrderType = orderType
+	if order.OperatorId == 0 {
+		order.OperatorId = c.client.operatorId
+	}
+
+	return newOrderIdempotent(ctx, c, c.client.idempotencyStore, order, func(ctx context.Context, order types.OrderNew) (types.Order, error) {
+		return httpPost[types.Order](
+			ctx,
+			fmt.Sprintf("%s/order", bitvavoURL),
+			order,
+			emptyParams,
+			c.client,
+			c.config,
+		)
+	})
 }
 
 func (c *httpClientAuth) UpdateOrder(market string, orderId string, order types.OrderUpdate) (types.Order, error) {
@@ -289,14 +485,16 @@ func (c *httpClientAuth) UpdateOrder(market string, orderId string, order types.
 func (c *httpClientAuth) UpdateOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderUpdate) (types.Order, error) {
 	order.Market = market
 	order.OrderId = orderId
+	if order.OperatorId == 0 {
+		order.OperatorId = c.client.operatorId
+	}
 
 	return httpPut[types.Order](
 		ctx,
 		fmt.Sprintf("%s/order", bitvavoURL),
 		order,
 		emptyParams,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
 		c.config,
 	)
 }
@@ -316,8 +514,7 @@ func (c *httpClientAuth) GetTradesWithContext(ctx context.Context, market string
 		ctx,
 		fmt.Sprintf("%s/trades", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
 		c.config,
 	)
 }
@@ -334,12 +531,28 @@ func (c *httpClientAuth) GetDepositAssetWithContext(ctx context.Context, symbol
 		ctx,
 		fmt.Sprintf("%s/deposit", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
 		c.config,
 	)
 }
 
+func (c *httpClientAuth) GetDepositFiat(symbol string) (types.DepositFiat, error) {
+	return c.GetDepositFiatWithContext(context.Background(), symbol)
+}
+
+func (c *httpClientAuth) GetDepositFiatWithContext(ctx context.Context, symbol string) (types.DepositFiat, error) {
+	deposit, err := c.GetDepositAssetWithContext(ctx, symbol)
+	if err != nil {
+		return types.DepositFiat{}, err
+	}
+
+	fiat, ok := deposit.Fiat()
+	if !ok {
+		return types.DepositFiat{}, errNoFiatDeposit(symbol)
+	}
+	return fiat, nil
+}
+
 func (c *httpClientAuth) GetDepositHistory(opt ...OptionalParams) ([]types.DepositHistory, error) {
 	return c.GetDepositHistoryWithContext(context.Background(), opt...)
 }
@@ -353,8 +566,7 @@ func (c *httpClientAuth) GetDepositHistoryWithContext(ctx context.Context, opt .
 		ctx,
 		fmt.Sprintf("%s/depositHistory", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
 		c.config,
 	)
 }
@@ -372,8 +584,25 @@ func (c *httpClientAuth) GetWithdrawalHistoryWithContext(ctx context.Context, op
 		ctx,
 		fmt.Sprintf("%s/withdrawalHistory", bitvavoURL),
 		params,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
+		c.config,
+	)
+}
+
+func (c *httpClientAuth) GetTransactionHistory(opt ...OptionalParams) (types.TransactionHistory, error) {
+	return c.GetTransactionHistoryWithContext(context.Background(), opt...)
+}
+
+func (c *httpClientAuth) GetTransactionHistoryWithContext(ctx context.Context, opt ...OptionalParams) (types.TransactionHistory, error) {
+	params := make(url.Values)
+	if len(opt) > 0 {
+		params = opt[0].Params()
+	}
+	return httpGet[types.TransactionHistory](
+		ctx,
+		fmt.Sprintf("%s/account/history", bitvavoURL),
+		params,
+		c.client,
 		c.config,
 	)
 }
@@ -387,13 +616,23 @@ func (c *httpClientAuth) WithdrawWithContext(ctx context.Context, symbol string,
 	withdrawal.Amount = amount
 	withdrawal.Address = address
 
+	if err := c.client.checkWithdrawal(ctx, withdrawal); err != nil {
+		return types.WithDrawalResponse{}, err
+	}
+
 	return httpPost[types.WithDrawalResponse](
 		ctx,
 		fmt.Sprintf("%s/withdrawal", bitvavoURL),
 		withdrawal,
 		emptyParams,
-		c.updateRateLimit,
-		c.updateRateLimitResetAt,
+		c.client,
 		c.config,
 	)
 }
+
+// addOperatorId adds the operatorId of the client to params, if any.
+func (c *httpClientAuth) addOperatorId(params url.Values) {
+	if c.client.operatorId != 0 {
+		params.Add("operatorId", fmt.Sprint(c.client.operatorId))
+	}
+}
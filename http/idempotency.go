@@ -0,0 +1,218 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// IdempotencyRecord is an order submission by clientOrderId.
+type IdempotencyRecord struct {
+	// Your own identifier of the order.
+	ClientOrderId string `json:"clientOrderId"`
+
+	// The market of the order (e.g: ETH-EUR)
+	Market string `json:"market"`
+
+	// The id of the order once the exchange acknowledged it, empty while the outcome of the submission is unknown.
+	OrderId string `json:"orderId,omitempty"`
+
+	// The time the order was first submitted.
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+// IdempotencyStore remembers the orders which have been submitted by clientOrderId,
+// so a retry of NewOrder (e.g: after a crash or an ambiguous network failure) doesn't submit the same order twice.
+type IdempotencyStore interface {
+	// Get returns the record of clientOrderId, false if it hasn't been submitted.
+	Get(ctx context.Context, clientOrderId string) (IdempotencyRecord, bool, error)
+
+	// Put stores record, replacing a previous record of the same clientOrderId.
+	Put(ctx context.Context, record IdempotencyRecord) error
+
+	// Delete removes the record of clientOrderId.
+	Delete(ctx context.Context, clientOrderId string) error
+}
+
+// Consult store before every new order with a clientOrderId. An order which has been submitted before isn't submitted again,
+// instead the order is fetched by its clientOrderId. Whenever the outcome of a previous submission is unknown, the order
+// is only submitted if the exchange doesn't know the clientOrderId.
+// default: no store
+func WithIdempotencyStore(store IdempotencyStore) Option {
+	return func(c *httpClient) {
+		c.idempotencyStore = store
+	}
+}
+
+// newOrderIdempotent submits order with submit unless its clientOrderId has been submitted before.
+func newOrderIdempotent(
+	ctx context.Context,
+	client HttpClientAuth,
+	store IdempotencyStore,
+	order types.OrderNew,
+	submit func(ctx context.Context, order types.OrderNew) (types.Order, error),
+) (types.Order, error) {
+	if store == nil || order.ClientOrderId == "" {
+		return submit(ctx, order)
+	}
+
+	record, found, err := store.Get(ctx, order.ClientOrderId)
+	if err != nil {
+		return types.Order{}, err
+	}
+	if found {
+		existing, err := client.GetOrderByClientIdWithContext(ctx, record.Market, record.ClientOrderId)
+		if err == nil {
+			if record.OrderId == "" {
+				record.OrderId = existing.OrderId
+				if err := store.Put(ctx, record); err != nil {
+					return existing, err
+				}
+			}
+			return existing, nil
+		}
+		if !types.IsOrderNotFound(err) || record.OrderId != "" {
+			return types.Order{}, err
+		}
+		// the previous submission never reached the exchange, so it's safe to submit it again
+	} else {
+		record = IdempotencyRecord{ClientOrderId: order.ClientOrderId, Market: order.Market, SubmittedAt: time.Now()}
+		if err := store.Put(ctx, record); err != nil {
+			return types.Order{}, err
+		}
+	}
+
+	placed, err := submit(ctx, order)
+	if err != nil {
+		if !types.IsAmbiguous(err) {
+			if deleteErr := store.Delete(context.WithoutCancel(ctx), order.ClientOrderId); deleteErr != nil {
+				return types.Order{}, errors.Join(err, deleteErr)
+			}
+		}
+		return types.Order{}, err
+	}
+
+	record.OrderId = placed.OrderId
+	return placed, store.Put(context.WithoutCancel(ctx), record)
+}
+
+// MemoryIdempotencyStore keeps the records in memory, so duplicates are only prevented within the same process.
+// It's safe for concurrent use.
+type MemoryIdempotencyStore struct {
+	mu      sync.RWMutex
+	records map[string]IdempotencyRecord
+}
+
+var _ IdempotencyStore = (*MemoryIdempotencyStore)(nil)
+
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{records: make(map[string]IdempotencyRecord)}
+}
+
+func (m *MemoryIdempotencyStore) Get(ctx context.Context, clientOrderId string) (IdempotencyRecord, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	record, found := m.records[clientOrderId]
+	return record, found, nil
+}
+
+func (m *MemoryIdempotencyStore) Put(ctx context.Context, record IdempotencyRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records[record.ClientOrderId] = record
+	return nil
+}
+
+func (m *MemoryIdempotencyStore) Delete(ctx context.Context, clientOrderId string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.records, clientOrderId)
+	return nil
+}
+
+// FileIdempotencyStore keeps the records in memory and writes them as JSON to a single file on every change,
+// so duplicates are prevented across restarts. It's safe for concurrent use.
+type FileIdempotencyStore struct {
+	path string
+
+	mu      sync.RWMutex
+	records map[string]IdempotencyRecord
+}
+
+var _ IdempotencyStore = (*FileIdempotencyStore)(nil)
+
+// NewFileIdempotencyStore creates a store which reads the records of the file at path (if it exists) and writes to it.
+func NewFileIdempotencyStore(path string) (*FileIdempotencyStore, error) {
+	store := &FileIdempotencyStore{path: path, records: make(map[string]IdempotencyRecord)}
+
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bytes, &store.records); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (f *FileIdempotencyStore) Get(ctx context.Context, clientOrderId string) (IdempotencyRecord, bool, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	record, found := f.records[clientOrderId]
+	return record, found, nil
+}
+
+func (f *FileIdempotencyStore) Put(ctx context.Context, record IdempotencyRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.records[record.ClientOrderId] = record
+	return f.write()
+}
+
+func (f *FileIdempotencyStore) Delete(ctx context.Context, clientOrderId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, found := f.records[clientOrderId]; !found {
+		return nil
+	}
+	delete(f.records, clientOrderId)
+	return f.write()
+}
+
+// write replaces the file with a temporary file, so it's never left half written.
+func (f *FileIdempotencyStore) write() error {
+	bytes, err := json.Marshal(f.records)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bytes); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"context"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+func (c *httpClient) GetMarketsWhere(filter func(market types.Market) bool) ([]types.Market, error) {
+	return c.GetMarketsWhereWithContext(context.Background(), filter)
+}
+
+func (c *httpClient) GetMarketsWhereWithContext(ctx context.Context, filter func(market types.Market) bool) ([]types.Market, error) {
+	markets, err := c.GetMarketsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]types.Market, 0, len(markets))
+	for _, market := range markets {
+		if filter(market) {
+			filtered = append(filtered, market)
+		}
+	}
+	return filtered, nil
+}
+
+func (c *httpClient) GetMarketsByQuote(quote string) ([]types.Market, error) {
+	return c.GetMarketsByQuoteWithContext(context.Background(), quote)
+}
+
+func (c *httpClient) GetMarketsByQuoteWithContext(ctx context.Context, quote string) ([]types.Market, error) {
+	return c.GetMarketsWhereWithContext(ctx, func(market types.Market) bool { return market.Quote == quote })
+}
+
+func (c *httpClient) GetTradingMarkets() ([]types.Market, error) {
+	return c.GetTradingMarketsWithContext(context.Background())
+}
+
+func (c *httpClient) GetTradingMarketsWithContext(ctx context.Context) ([]types.Market, error) {
+	return c.GetMarketsWhereWithContext(ctx, func(market types.Market) bool { return market.Status == types.MarketStatusTrading })
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMetadata is the metadata of a response, useful for observability and support tickets with Bitvavo.
+type ResponseMetadata struct {
+	// The method of the request (e.g: GET)
+	Method string
+
+	// The url of the request.
+	URL string
+
+	// The HTTP status code of the response, 0 if no response was received.
+	StatusCode int
+
+	// The raw headers of the response.
+	Header http.Header
+
+	// The remaining rate limit according to the response.
+	//
+	// Default value: -1
+	RateLimitRemaining int64
+
+	// The time (local time) when the rate limit counter resets according to the response.
+	RateLimitResetAt time.Time
+
+	// The time between sending the request and receiving the response headers.
+	Latency time.Duration
+
+	// The number of attempts, which is more than 1 if the request has been retried.
+	Attempts uint64
+}
+
+type metadataKey struct{}
+
+// CaptureResponseMetadata returns a context which captures the metadata of the (last) response into metadata.
+// Pass the context to any of the WithContext methods, metadata is filled when the method returns.
+func CaptureResponseMetadata(ctx context.Context, metadata *ResponseMetadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+func (m *ResponseMetadata) capture(request *http.Request, response *http.Response, latency time.Duration, attempts uint64) {
+	*m = ResponseMetadata{
+		Method:             request.Method,
+		URL:                request.URL.String(),
+		RateLimitRemaining: -1,
+		Latency:            latency,
+		Attempts:           attempts,
+	}
+	if response == nil {
+		return
+	}
+
+	m.StatusCode = response.StatusCode
+	m.Header = response.Header.Clone()
+	if ratelimit, err := strconv.ParseInt(response.Header.Get(headerRatelimit), 10, 64); err == nil {
+		m.RateLimitRemaining = ratelimit
+	}
+	if resetAt, err := strconv.ParseInt(response.Header.Get(headerRatelimitResetAt), 10, 64); err == nil {
+		m.RateLimitResetAt = time.UnixMilli(resetAt)
+	}
+}
@@ -0,0 +1,124 @@
+// Package metrics collects prometheus metrics of the requests of the http client.
+//
+// It's a separate module (github.com/larscom/go-bitvavo/v2/http/metrics) instead of a WithMetrics option of the http package,
+// so the client itself doesn't depend on prometheus. Pass the option of New to http.NewHttpClient.
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	httpc "github.com/larscom/go-bitvavo/v2/http"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	namespace       = "bitvavo_http"
+	headerRatelimit = "Bitvavo-Ratelimit-Remaining"
+)
+
+type metrics struct {
+	requests  *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+	ratelimit prometheus.Gauge
+}
+
+// New returns an option for the http client which collects prometheus metrics of every request and registers them with registerer:
+//
+// bitvavo_http_requests_total: the number of responses by method, endpoint and status.
+//
+// bitvavo_http_request_duration_seconds: the latency of the requests by method and endpoint.
+//
+// bitvavo_http_errors_total: the number of requests which failed without a response (e.g: network errors) by method and endpoint.
+//
+// bitvavo_http_ratelimit_remaining: the remaining rate limit according to the last response.
+//
+// Multiple clients can share the same registerer, they share the metrics as well.
+// It returns an error whenever a metric can't be registered (e.g: another collector with the same name is registered)
+func New(registerer prometheus.Registerer) (httpc.Option, error) {
+	metrics, err := newMetrics(registerer)
+	if err != nil {
+		return nil, err
+	}
+	return httpc.OnAttempt(metrics.observe), nil
+}
+
+func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
+	var (
+		m   metrics
+		err error
+	)
+
+	if m.requests, err = register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "The number of responses by method, endpoint and status.",
+	}, []string{"method", "endpoint", "status"})); err != nil {
+		return nil, err
+	}
+	if m.duration, err = register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "The latency of the requests by method and endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "endpoint"})); err != nil {
+		return nil, err
+	}
+	if m.errors, err = register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "errors_total",
+		Help:      "The number of requests which failed without a response by method and endpoint.",
+	}, []string{"method", "endpoint"})); err != nil {
+		return nil, err
+	}
+	if m.ratelimit, err = register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "ratelimit_remaining",
+		Help:      "The remaining rate limit according to the last response.",
+	})); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// register registers collector, or returns the collector which has been registered already.
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	err := registerer.Register(collector)
+	if err == nil {
+		return collector, nil
+	}
+
+	var registered prometheus.AlreadyRegisteredError
+	if !errors.As(err, &registered) {
+		return collector, err
+	}
+
+	existing, ok := registered.ExistingCollector.(T)
+	if !ok {
+		return collector, fmt.Errorf("another collector is registered already: %T", registered.ExistingCollector)
+	}
+	return existing, nil
+}
+
+func (m *metrics) observe(request *http.Request, response *http.Response, latency time.Duration) {
+	var (
+		method   = request.Method
+		endpoint = httpc.Endpoint(request)
+	)
+
+	m.duration.WithLabelValues(method, endpoint).Observe(latency.Seconds())
+	if response == nil {
+		m.errors.WithLabelValues(method, endpoint).Inc()
+		return
+	}
+
+	m.requests.WithLabelValues(method, endpoint, strconv.Itoa(response.StatusCode)).Inc()
+	if ratelimit, err := strconv.ParseInt(response.Header.Get(headerRatelimit), 10, 64); err == nil {
+		m.ratelimit.Set(float64(ratelimit))
+	}
+}
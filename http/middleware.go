@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http"
+	"slices"
+)
+
+// Doer executes a single request, http.Client is a Doer.
+type Doer interface {
+	Do(request *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is a func which implements Doer.
+type DoerFunc func(request *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// Middleware wraps next, so it can inspect, change or replace every outgoing request and its response.
+type Middleware func(next Doer) Doer
+
+// Wrap every outgoing request with middleware (e.g: for auditing, custom retries, chaos testing or mirroring)
+// The first middleware is the outermost, it sees the request first and the response last.
+//
+// Middleware is called for every attempt after the request has been signed, so retries pass through it as well.
+// default: no middleware
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *httpClient) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// chain wraps the http.Client of c with its middleware.
+func (c *httpClient) chain() Doer {
+	var doer Doer = c.httpclient
+	for _, middleware := range slices.Backward(c.middleware) {
+		doer = middleware(doer)
+	}
+	return doer
+}
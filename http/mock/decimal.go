@@ -0,0 +1,221 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/typesdec"
+)
+
+// HttpClientDec is a fake http.HttpClientDec, every method returns the result of its Func field.
+//
+// Calling a method whose Func field is nil returns ErrNotConfigured.
+type HttpClientDec struct {
+	recorder
+
+	GetOrderBookFunc    func(ctx context.Context, market string, depth ...uint64) (typesdec.Book, error)
+	GetTradesFunc       func(ctx context.Context, market string, opt ...http.OptionalParams) ([]typesdec.Trade, error)
+	GetCandlesFunc      func(ctx context.Context, market string, interval types.Interval, opt ...http.OptionalParams) ([]typesdec.Candle, error)
+	GetTickerPricesFunc func(ctx context.Context) ([]typesdec.TickerPrice, error)
+	GetTickerPriceFunc  func(ctx context.Context, market string) (typesdec.TickerPrice, error)
+	GetTickerBooksFunc  func(ctx context.Context) ([]typesdec.TickerBook, error)
+	GetTickerBookFunc   func(ctx context.Context, market string) (typesdec.TickerBook, error)
+	GetTickers24hFunc   func(ctx context.Context) ([]typesdec.Ticker24h, error)
+	GetTicker24hFunc    func(ctx context.Context, market string) (typesdec.Ticker24h, error)
+}
+
+var _ http.HttpClientDec = (*HttpClientDec)(nil)
+
+func (m *HttpClientDec) GetOrderBook(market string, depth ...uint64) (typesdec.Book, error) {
+	return m.GetOrderBookWithContext(context.Background(), market, depth...)
+}
+
+func (m *HttpClientDec) GetOrderBookWithContext(ctx context.Context, market string, depth ...uint64) (typesdec.Book, error) {
+	if err := m.before("GetOrderBook", m.GetOrderBookFunc != nil, market, depth); err != nil {
+		return typesdec.Book{}, err
+	}
+	return m.GetOrderBookFunc(ctx, market, depth...)
+}
+
+func (m *HttpClientDec) GetTrades(market string, opt ...http.OptionalParams) ([]typesdec.Trade, error) {
+	return m.GetTradesWithContext(context.Background(), market, opt...)
+}
+
+func (m *HttpClientDec) GetTradesWithContext(ctx context.Context, market string, opt ...http.OptionalParams) ([]typesdec.Trade, error) {
+	if err := m.before("GetTrades", m.GetTradesFunc != nil, market, opt); err != nil {
+		return nil, err
+	}
+	return m.GetTradesFunc(ctx, market, opt...)
+}
+
+func (m *HttpClientDec) GetCandles(market string, interval types.Interval, opt ...http.OptionalParams) ([]typesdec.Candle, error) {
+	return m.GetCandlesWithContext(context.Background(), market, interval, opt...)
+}
+
+func (m *HttpClientDec) GetCandlesWithContext(ctx context.Context, market string, interval types.Interval, opt ...http.OptionalParams) ([]typesdec.Candle, error) {
+	if err := m.before("GetCandles", m.GetCandlesFunc != nil, market, interval, opt); err != nil {
+		return nil, err
+	}
+	return m.GetCandlesFunc(ctx, market, interval, opt...)
+}
+
+func (m *HttpClientDec) GetTickerPrices() ([]typesdec.TickerPrice, error) {
+	return m.GetTickerPricesWithContext(context.Background())
+}
+
+func (m *HttpClientDec) GetTickerPricesWithContext(ctx context.Context) ([]typesdec.TickerPrice, error) {
+	if err := m.before("GetTickerPrices", m.GetTickerPricesFunc != nil); err != nil {
+		return nil, err
+	}
+	return m.GetTickerPricesFunc(ctx)
+}
+
+func (m *HttpClientDec) GetTickerPrice(market string) (typesdec.TickerPrice, error) {
+	return m.GetTickerPriceWithContext(context.Background(), market)
+}
+
+func (m *HttpClientDec) GetTickerPriceWithContext(ctx context.Context, market string) (typesdec.TickerPrice, error) {
+	if err := m.before("GetTickerPrice", m.GetTickerPriceFunc != nil, market); err != nil {
+		return typesdec.TickerPrice{}, err
+	}
+	return m.GetTickerPriceFunc(ctx, market)
+}
+
+func (m *HttpClientDec) GetTickerBooks() ([]typesdec.TickerBook, error) {
+	return m.GetTickerBooksWithContext(context.Background())
+}
+
+func (m *HttpClientDec) GetTickerBooksWithContext(ctx context.Context) ([]typesdec.TickerBook, error) {
+	if err := m.before("GetTickerBooks", m.GetTickerBooksFunc != nil); err != nil {
+		return nil, err
+	}
+	return m.GetTickerBooksFunc(ctx)
+}
+
+func (m *HttpClientDec) GetTickerBook(market string) (typesdec.TickerBook, error) {
+	return m.GetTickerBookWithContext(context.Background(), market)
+}
+
+func (m *HttpClientDec) GetTickerBookWithContext(ctx context.Context, market string) (typesdec.TickerBook, error) {
+	if err := m.before("GetTickerBook", m.GetTickerBookFunc != nil, market); err != nil {
+		return typesdec.TickerBook{}, err
+	}
+	return m.GetTickerBookFunc(ctx, market)
+}
+
+func (m *HttpClientDec) GetTickers24h() ([]typesdec.Ticker24h, error) {
+	return m.GetTickers24hWithContext(context.Background())
+}
+
+func (m *HttpClientDec) GetTickers24hWithContext(ctx context.Context) ([]typesdec.Ticker24h, error) {
+	if err := m.before("GetTickers24h", m.GetTickers24hFunc != nil); err != nil {
+		return nil, err
+	}
+	return m.GetTickers24hFunc(ctx)
+}
+
+func (m *HttpClientDec) GetTicker24h(market string) (typesdec.Ticker24h, error) {
+	return m.GetTicker24hWithContext(context.Background(), market)
+}
+
+func (m *HttpClientDec) GetTicker24hWithContext(ctx context.Context, market string) (typesdec.Ticker24h, error) {
+	if err := m.before("GetTicker24h", m.GetTicker24hFunc != nil, market); err != nil {
+		return typesdec.Ticker24h{}, err
+	}
+	return m.GetTicker24hFunc(ctx, market)
+}
+
+// HttpClientAuthDec is a fake http.HttpClientAuthDec, every method returns the result of its Func field.
+//
+// Calling a method whose Func field is nil returns ErrNotConfigured.
+type HttpClientAuthDec struct {
+	recorder
+
+	GetBalanceFunc    func(ctx context.Context, symbol ...string) ([]typesdec.Balance, error)
+	GetTradesFunc     func(ctx context.Context, market string, opt ...http.OptionalParams) ([]typesdec.TradeHistoric, error)
+	GetOrdersFunc     func(ctx context.Context, market string, opt ...http.OptionalParams) ([]typesdec.Order, error)
+	GetOrdersOpenFunc func(ctx context.Context, market ...string) ([]typesdec.Order, error)
+	GetOrderFunc      func(ctx context.Context, market string, orderId string) (typesdec.Order, error)
+	NewOrderFunc      func(ctx context.Context, market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error)
+	UpdateOrderFunc   func(ctx context.Context, market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error)
+}
+
+var _ http.HttpClientAuthDec = (*HttpClientAuthDec)(nil)
+
+func (m *HttpClientAuthDec) GetBalance(symbol ...string) ([]typesdec.Balance, error) {
+	return m.GetBalanceWithContext(context.Background(), symbol...)
+}
+
+func (m *HttpClientAuthDec) GetBalanceWithContext(ctx context.Context, symbol ...string) ([]typesdec.Balance, error) {
+	if err := m.before("GetBalance", m.GetBalanceFunc != nil, symbol); err != nil {
+		return nil, err
+	}
+	return m.GetBalanceFunc(ctx, symbol...)
+}
+
+func (m *HttpClientAuthDec) GetTrades(market string, opt ...http.OptionalParams) ([]typesdec.TradeHistoric, error) {
+	return m.GetTradesWithContext(context.Background(), market, opt...)
+}
+
+func (m *HttpClientAuthDec) GetTradesWithContext(ctx context.Context, market string, opt ...http.OptionalParams) ([]typesdec.TradeHistoric, error) {
+	if err := m.before("GetTrades", m.GetTradesFunc != nil, market, opt); err != nil {
+		return nil, err
+	}
+	return m.GetTradesFunc(ctx, market, opt...)
+}
+
+func (m *HttpClientAuthDec) GetOrders(market string, opt ...http.OptionalParams) ([]typesdec.Order, error) {
+	return m.GetOrdersWithContext(context.Background(), market, opt...)
+}
+
+func (m *HttpClientAuthDec) GetOrdersWithContext(ctx context.Context, market string, opt ...http.OptionalParams) ([]typesdec.Order, error) {
+	if err := m.before("GetOrders", m.GetOrdersFunc != nil, market, opt); err != nil {
+		return nil, err
+	}
+	return m.GetOrdersFunc(ctx, market, opt...)
+}
+
+func (m *HttpClientAuthDec) GetOrdersOpen(market ...string) ([]typesdec.Order, error) {
+	return m.GetOrdersOpenWithContext(context.Background(), market...)
+}
+
+func (m *HttpClientAuthDec) GetOrdersOpenWithContext(ctx context.Context, market ...string) ([]typesdec.Order, error) {
+	if err := m.before("GetOrdersOpen", m.GetOrdersOpenFunc != nil, market); err != nil {
+		return nil, err
+	}
+	return m.GetOrdersOpenFunc(ctx, market...)
+}
+
+func (m *HttpClientAuthDec) GetOrder(market string, orderId string) (typesdec.Order, error) {
+	return m.GetOrderWithContext(context.Background(), market, orderId)
+}
+
+func (m *HttpClientAuthDec) GetOrderWithContext(ctx context.Context, market string, orderId string) (typesdec.Order, error) {
+	if err := m.before("GetOrder", m.GetOrderFunc != nil, market, orderId); err != nil {
+		return typesdec.Order{}, err
+	}
+	return m.GetOrderFunc(ctx, market, orderId)
+}
+
+func (m *HttpClientAuthDec) NewOrder(market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error) {
+	return m.NewOrderWithContext(context.Background(), market, side, orderType, order)
+}
+
+func (m *HttpClientAuthDec) NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error) {
+	if err := m.before("NewOrder", m.NewOrderFunc != nil, market, side, orderType, order); err != nil {
+		return typesdec.Order{}, err
+	}
+	return m.NewOrderFunc(ctx, market, side, orderType, order)
+}
+
+func (m *HttpClientAuthDec) UpdateOrder(market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error) {
+	return m.UpdateOrderWithContext(context.Background(), market, orderId, order)
+}
+
+func (m *HttpClientAuthDec) UpdateOrderWithContext(ctx context.Context, market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error) {
+	if err := m.before("UpdateOrder", m.UpdateOrderFunc != nil, market, orderId, order); err != nil {
+		return typesdec.Order{}, err
+	}
+	return m.UpdateOrderFunc(ctx, market, orderId, order)
+}
@@ -0,0 +1,378 @@
+package mock
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// HttpClient is a fake http.HttpClient, every method returns the result of its Func field.
+//
+// Calling a method whose Func field is nil returns ErrNotConfigured.
+type HttpClient struct {
+	recorder
+
+	// Auth is returned by ToAuthClient, it's created on first use if nil.
+	Auth *HttpClientAuth
+
+	// Decimal is returned by ToDecimalClient, it's created on first use if nil.
+	Decimal *HttpClientDec
+
+	GetRateLimitFunc           func() int64
+	GetRateLimitResetAtFunc    func() time.Time
+	RateLimitEstimateFunc      func() int64
+	GetTimeFunc                func(ctx context.Context) (int64, error)
+	GetMarketsFunc             func(ctx context.Context) ([]types.Market, error)
+	GetMarketFunc              func(ctx context.Context, market string) (types.Market, error)
+	GetMarketsWhereFunc        func(ctx context.Context, filter func(market types.Market) bool) ([]types.Market, error)
+	GetMarketsByQuoteFunc      func(ctx context.Context, quote string) ([]types.Market, error)
+	GetTradingMarketsFunc      func(ctx context.Context) ([]types.Market, error)
+	GetAssetsFunc              func(ctx context.Context) ([]types.Asset, error)
+	GetAssetFunc               func(ctx context.Context, symbol string) (types.Asset, error)
+	EstimateWithdrawalCostFunc func(ctx context.Context, symbol string, amount float64) (types.WithdrawalCost, error)
+	GetOrderBookFunc           func(ctx context.Context, market string, depth ...uint64) (types.Book, error)
+	GetTradesFunc              func(ctx context.Context, market string, params ...http.OptionalParams) ([]types.Trade, error)
+	GetTradesSeqFunc           func(ctx context.Context, market string, params ...http.OptionalParams) iter.Seq2[types.Trade, error]
+	GetCandlesFunc             func(ctx context.Context, market string, interval types.Interval, params ...http.OptionalParams) ([]types.Candle, error)
+	GetCandlesSeqFunc          func(ctx context.Context, market string, interval types.Interval, params ...http.OptionalParams) iter.Seq2[types.Candle, error]
+	GetCandlesRangeFunc        func(ctx context.Context, market string, interval types.Interval, start time.Time, end time.Time) ([]types.Candle, error)
+	GetTradesRangeFunc         func(ctx context.Context, market string, start time.Time, end time.Time) ([]types.Trade, error)
+	GetTickerPricesFunc        func(ctx context.Context) ([]types.TickerPrice, error)
+	GetTickerPriceFunc         func(ctx context.Context, market string) (types.TickerPrice, error)
+	GetTickerBooksFunc         func(ctx context.Context) ([]types.TickerBook, error)
+	GetTickerBookFunc          func(ctx context.Context, market string) (types.TickerBook, error)
+	GetTickers24hFunc          func(ctx context.Context) ([]types.Ticker24h, error)
+	GetTicker24hFunc           func(ctx context.Context, market string) (types.Ticker24h, error)
+	GetTickers24hForFunc       func(ctx context.Context, markets []string) (map[string]types.Ticker24h, error)
+	GetOrderBooksFunc          func(ctx context.Context, markets []string, depth ...uint64) (map[string]types.Book, error)
+}
+
+var _ http.HttpClient = (*HttpClient)(nil)
+
+func (m *HttpClient) GetRateLimit() int64 {
+	m.record("GetRateLimit")
+	if m.GetRateLimitFunc == nil {
+		return 0
+	}
+	return m.GetRateLimitFunc()
+}
+
+func (m *HttpClient) GetRateLimitResetAt() time.Time {
+	m.record("GetRateLimitResetAt")
+	if m.GetRateLimitResetAtFunc == nil {
+		return time.Time{}
+	}
+	return m.GetRateLimitResetAtFunc()
+}
+
+func (m *HttpClient) RateLimitEstimate() int64 {
+	m.record("RateLimitEstimate")
+	if m.RateLimitEstimateFunc == nil {
+		return 0
+	}
+	return m.RateLimitEstimateFunc()
+}
+
+func (m *HttpClient) ToAuthClient(apiKey string, apiSecret string, windowTimeMs ...uint64) http.HttpClientAuth {
+	m.record("ToAuthClient", apiKey, apiSecret, windowTimeMs)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Auth == nil {
+		m.Auth = &HttpClientAuth{}
+	}
+	return m.Auth
+}
+
+func (m *HttpClient) ToAuthClientWithCredentials(credentials http.CredentialsProvider, windowTimeMs ...uint64) http.HttpClientAuth {
+	m.record("ToAuthClientWithCredentials", credentials, windowTimeMs)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Auth == nil {
+		m.Auth = &HttpClientAuth{}
+	}
+	return m.Auth
+}
+
+func (m *HttpClient) ToDecimalClient() http.HttpClientDec {
+	m.record("ToDecimalClient")
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Decimal == nil {
+		m.Decimal = &HttpClientDec{}
+	}
+	return m.Decimal
+}
+
+func (m *HttpClient) GetTime() (int64, error) {
+	return m.GetTimeWithContext(context.Background())
+}
+
+func (m *HttpClient) GetTimeWithContext(ctx context.Context) (int64, error) {
+	if err := m.before("GetTime", m.GetTimeFunc != nil); err != nil {
+		return 0, err
+	}
+	return m.GetTimeFunc(ctx)
+}
+
+func (m *HttpClient) GetMarkets() ([]types.Market, error) {
+	return m.GetMarketsWithContext(context.Background())
+}
+
+func (m *HttpClient) GetMarketsWithContext(ctx context.Context) ([]types.Market, error) {
+	if err := m.before("GetMarkets", m.GetMarketsFunc != nil); err != nil {
+		return nil, err
+	}
+	return m.GetMarketsFunc(ctx)
+}
+
+func (m *HttpClient) GetMarket(market string) (types.Market, error) {
+	return m.GetMarketWithContext(context.Background(), market)
+}
+
+func (m *HttpClient) GetMarketWithContext(ctx context.Context, market string) (types.Market, error) {
+	if err := m.before("GetMarket", m.GetMarketFunc != nil, market); err != nil {
+		return types.Market{}, err
+	}
+	return m.GetMarketFunc(ctx, market)
+}
+
+func (m *HttpClient) GetMarketsWhere(filter func(market types.Market) bool) ([]types.Market, error) {
+	return m.GetMarketsWhereWithContext(context.Background(), filter)
+}
+
+func (m *HttpClient) GetMarketsWhereWithContext(ctx context.Context, filter func(market types.Market) bool) ([]types.Market, error) {
+	if err := m.before("GetMarketsWhere", m.GetMarketsWhereFunc != nil, filter); err != nil {
+		return nil, err
+	}
+	return m.GetMarketsWhereFunc(ctx, filter)
+}
+
+func (m *HttpClient) GetMarketsByQuote(quote string) ([]types.Market, error) {
+	return m.GetMarketsByQuoteWithContext(context.Background(), quote)
+}
+
+func (m *HttpClient) GetMarketsByQuoteWithContext(ctx context.Context, quote string) ([]types.Market, error) {
+	if err := m.before("GetMarketsByQuote", m.GetMarketsByQuoteFunc != nil, quote); err != nil {
+		return nil, err
+	}
+	return m.GetMarketsByQuoteFunc(ctx, quote)
+}
+
+func (m *HttpClient) GetTradingMarkets() ([]types.Market, error) {
+	return m.GetTradingMarketsWithContext(context.Background())
+}
+
+func (m *HttpClient) GetTradingMarketsWithContext(ctx context.Context) ([]types.Market, error) {
+	if err := m.before("GetTradingMarkets", m.GetTradingMarketsFunc != nil); err != nil {
+		return nil, err
+	}
+	return m.GetTradingMarketsFunc(ctx)
+}
+
+func (m *HttpClient) GetAssets() ([]types.Asset, error) {
+	return m.GetAssetsWithContext(context.Background())
+}
+
+func (m *HttpClient) GetAssetsWithContext(ctx context.Context) ([]types.Asset, error) {
+	if err := m.before("GetAssets", m.GetAssetsFunc != nil); err != nil {
+		return nil, err
+	}
+	return m.GetAssetsFunc(ctx)
+}
+
+func (m *HttpClient) GetAsset(symbol string) (types.Asset, error) {
+	return m.GetAssetWithContext(context.Background(), symbol)
+}
+
+func (m *HttpClient) GetAssetWithContext(ctx context.Context, symbol string) (types.Asset, error) {
+	if err := m.before("GetAsset", m.GetAssetFunc != nil, symbol); err != nil {
+		return types.Asset{}, err
+	}
+	return m.GetAssetFunc(ctx, symbol)
+}
+
+func (m *HttpClient) EstimateWithdrawalCost(symbol string, amount float64) (types.WithdrawalCost, error) {
+	return m.EstimateWithdrawalCostWithContext(context.Background(), symbol, amount)
+}
+
+func (m *HttpClient) EstimateWithdrawalCostWithContext(ctx context.Context, symbol string, amount float64) (types.WithdrawalCost, error) {
+	if err := m.before("EstimateWithdrawalCost", m.EstimateWithdrawalCostFunc != nil, symbol, amount); err != nil {
+		return types.WithdrawalCost{}, err
+	}
+	return m.EstimateWithdrawalCostFunc(ctx, symbol, amount)
+}
+
+func (m *HttpClient) GetOrderBook(market string, depth ...uint64) (types.Book, error) {
+	return m.GetOrderBookWithContext(context.Background(), market, depth...)
+}
+
+func (m *HttpClient) GetOrderBookWithContext(ctx context.Context, market string, depth ...uint64) (types.Book, error) {
+	if err := m.before("GetOrderBook", m.GetOrderBookFunc != nil, market, depth); err != nil {
+		return types.Book{}, err
+	}
+	return m.GetOrderBookFunc(ctx, market, depth...)
+}
+
+func (m *HttpClient) GetTrades(market string, params ...http.OptionalParams) ([]types.Trade, error) {
+	return m.GetTradesWithContext(context.Background(), market, params...)
+}
+
+func (m *HttpClient) GetTradesWithContext(ctx context.Context, market string, params ...http.OptionalParams) ([]types.Trade, error) {
+	if err := m.before("GetTrades", m.GetTradesFunc != nil, market, params); err != nil {
+		return nil, err
+	}
+	return m.GetTradesFunc(ctx, market, params...)
+}
+
+func (m *HttpClient) GetTradesSeq(market string, params ...http.OptionalParams) iter.Seq2[types.Trade, error] {
+	return m.GetTradesSeqWithContext(context.Background(), market, params...)
+}
+
+func (m *HttpClient) GetTradesSeqWithContext(ctx context.Context, market string, params ...http.OptionalParams) iter.Seq2[types.Trade, error] {
+	if err := m.before("GetTradesSeq", m.GetTradesSeqFunc != nil, market, params); err != nil {
+		return func(yield func(types.Trade, error) bool) {
+			yield(types.Trade{}, err)
+		}
+	}
+	return m.GetTradesSeqFunc(ctx, market, params...)
+}
+
+func (m *HttpClient) GetCandles(market string, interval types.Interval, params ...http.OptionalParams) ([]types.Candle, error) {
+	return m.GetCandlesWithContext(context.Background(), market, interval, params...)
+}
+
+func (m *HttpClient) GetCandlesWithContext(ctx context.Context, market string, interval types.Interval, params ...http.OptionalParams) ([]types.Candle, error) {
+	if err := m.before("GetCandles", m.GetCandlesFunc != nil, market, interval, params); err != nil {
+		return nil, err
+	}
+	return m.GetCandlesFunc(ctx, market, interval, params...)
+}
+
+func (m *HttpClient) GetCandlesSeq(market string, interval types.Interval, params ...http.OptionalParams) iter.Seq2[types.Candle, error] {
+	return m.GetCandlesSeqWithContext(context.Background(), market, interval, params...)
+}
+
+func (m *HttpClient) GetCandlesSeqWithContext(ctx context.Context, market string, interval types.Interval, params ...http.OptionalParams) iter.Seq2[types.Candle, error] {
+	if err := m.before("GetCandlesSeq", m.GetCandlesSeqFunc != nil, market, interval, params); err != nil {
+		return func(yield func(types.Candle, error) bool) {
+			yield(types.Candle{}, err)
+		}
+	}
+	return m.GetCandlesSeqFunc(ctx, market, interval, params...)
+}
+
+func (m *HttpClient) GetCandlesRange(market string, interval types.Interval, start time.Time, end time.Time) ([]types.Candle, error) {
+	return m.GetCandlesRangeWithContext(context.Background(), market, interval, start, end)
+}
+
+func (m *HttpClient) GetCandlesRangeWithContext(ctx context.Context, market string, interval types.Interval, start time.Time, end time.Time) ([]types.Candle, error) {
+	if err := m.before("GetCandlesRange", m.GetCandlesRangeFunc != nil, market, interval, start, end); err != nil {
+		return nil, err
+	}
+	return m.GetCandlesRangeFunc(ctx, market, interval, start, end)
+}
+
+func (m *HttpClient) GetTradesRange(market string, start time.Time, end time.Time) ([]types.Trade, error) {
+	return m.GetTradesRangeWithContext(context.Background(), market, start, end)
+}
+
+func (m *HttpClient) GetTradesRangeWithContext(ctx context.Context, market string, start time.Time, end time.Time) ([]types.Trade, error) {
+	if err := m.before("GetTradesRange", m.GetTradesRangeFunc != nil, market, start, end); err != nil {
+		return nil, err
+	}
+	return m.GetTradesRangeFunc(ctx, market, start, end)
+}
+
+func (m *HttpClient) GetTickerPrices() ([]types.TickerPrice, error) {
+	return m.GetTickerPricesWithContext(context.Background())
+}
+
+func (m *HttpClient) GetTickerPricesWithContext(ctx context.Context) ([]types.TickerPrice, error) {
+	if err := m.before("GetTickerPrices", m.GetTickerPricesFunc != nil); err != nil {
+		return nil, err
+	}
+	return m.GetTickerPricesFunc(ctx)
+}
+
+func (m *HttpClient) GetTickerPrice(market string) (types.TickerPrice, error) {
+	return m.GetTickerPriceWithContext(context.Background(), market)
+}
+
+func (m *HttpClient) GetTickerPriceWithContext(ctx context.Context, market string) (types.TickerPrice, error) {
+	if err := m.before("GetTickerPrice", m.GetTickerPriceFunc != nil, market); err != nil {
+		return types.TickerPrice{}, err
+	}
+	return m.GetTickerPriceFunc(ctx, market)
+}
+
+func (m *HttpClient) GetTickerBooks() ([]types.TickerBook, error) {
+	return m.GetTickerBooksWithContext(context.Background())
+}
+
+func (m *HttpClient) GetTickerBooksWithContext(ctx context.Context) ([]types.TickerBook, error) {
+	if err := m.before("GetTickerBooks", m.GetTickerBooksFunc != nil); err != nil {
+		return nil, err
+	}
+	return m.GetTickerBooksFunc(ctx)
+}
+
+func (m *HttpClient) GetTickerBook(market string) (types.TickerBook, error) {
+	return m.GetTickerBookWithContext(context.Background(), market)
+}
+
+func (m *HttpClient) GetTickerBookWithContext(ctx context.Context, market string) (types.TickerBook, error) {
+	if err := m.before("GetTickerBook", m.GetTickerBookFunc != nil, market); err != nil {
+		return types.TickerBook{}, err
+	}
+	return m.GetTickerBookFunc(ctx, market)
+}
+
+func (m *HttpClient) GetTickers24h() ([]types.Ticker24h, error) {
+	return m.GetTickers24hWithContext(context.Background())
+}
+
+func (m *HttpClient) GetTickers24hWithContext(ctx context.Context) ([]types.Ticker24h, error) {
+	if err := m.before("GetTickers24h", m.GetTickers24hFunc != nil); err != nil {
+		return nil, err
+	}
+	return m.GetTickers24hFunc(ctx)
+}
+
+func (m *HttpClient) GetTicker24h(market string) (types.Ticker24h, error) {
+	return m.GetTicker24hWithContext(context.Background(), market)
+}
+
+func (m *HttpClient) GetTicker24hWithContext(ctx context.Context, market string) (types.Ticker24h, error) {
+	if err := m.before("GetTicker24h", m.GetTicker24hFunc != nil, market); err != nil {
+		return types.Ticker24h{}, err
+	}
+	return m.GetTicker24hFunc(ctx, market)
+}
+
+func (m *HttpClient) GetTickers24hFor(markets []string) (map[string]types.Ticker24h, error) {
+	return m.GetTickers24hForWithContext(context.Background(), markets)
+}
+
+func (m *HttpClient) GetTickers24hForWithContext(ctx context.Context, markets []string) (map[string]types.Ticker24h, error) {
+	if err := m.before("GetTickers24hFor", m.GetTickers24hForFunc != nil, markets); err != nil {
+		return nil, err
+	}
+	return m.GetTickers24hForFunc(ctx, markets)
+}
+
+func (m *HttpClient) GetOrderBooks(markets []string, depth ...uint64) (map[string]types.Book, error) {
+	return m.GetOrderBooksWithContext(context.Background(), markets, depth...)
+}
+
+func (m *HttpClient) GetOrderBooksWithContext(ctx context.Context, markets []string, depth ...uint64) (map[string]types.Book, error) {
+	if err := m.before("GetOrderBooks", m.GetOrderBooksFunc != nil, markets, depth); err != nil {
+		return nil, err
+	}
+	return m.GetOrderBooksFunc(ctx, markets, depth...)
+}
@@ -0,0 +1,382 @@
+package mock
+
+import (
+	"context"
+	"iter"
+	"net/url"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// HttpClientAuth is a fake http.HttpClientAuth, every method returns the result of its Func field.
+//
+// Calling a method whose Func field is nil returns ErrNotConfigured.
+type HttpClientAuth struct {
+	recorder
+
+	// Decimal is returned by ToDecimalClient, it's created on first use if nil.
+	Decimal *HttpClientAuthDec
+
+	GetBalanceFunc            func(ctx context.Context, symbol ...string) ([]types.Balance, error)
+	GetAccountFunc            func(ctx context.Context) (types.Account, error)
+	GetFeesFunc               func(ctx context.Context, market string) (types.MarketFee, error)
+	EstimateFeeFunc           func(ctx context.Context, market string, side types.Side, amount float64, price float64, taker bool) (types.FeeEstimate, error)
+	GetPortfolioValueFunc     func(ctx context.Context, quote string) (types.Portfolio, error)
+	GetTradesFunc             func(ctx context.Context, market string, params ...http.OptionalParams) ([]types.TradeHistoric, error)
+	GetTradesSeqFunc          func(ctx context.Context, market string, params ...http.OptionalParams) iter.Seq2[types.TradeHistoric, error]
+	GetAllTradesFunc          func(ctx context.Context, market string) ([]types.TradeHistoric, error)
+	GetOrdersFunc             func(ctx context.Context, market string, params ...http.OptionalParams) ([]types.Order, error)
+	GetOrdersSeqFunc          func(ctx context.Context, market string, params ...http.OptionalParams) iter.Seq2[types.Order, error]
+	GetAllOrdersFunc          func(ctx context.Context, market string) ([]types.Order, error)
+	GetOrdersOpenFunc         func(ctx context.Context, market ...string) ([]types.Order, error)
+	GetOrderFunc              func(ctx context.Context, market string, orderId string) (types.Order, error)
+	GetOrderByClientIdFunc    func(ctx context.Context, market string, clientOrderId string) (types.Order, error)
+	CancelOrdersFunc          func(ctx context.Context, market ...string) ([]string, error)
+	CancelMarketOrdersFunc    func(ctx context.Context, markets []string) (map[string][]string, error)
+	CancelOrderFunc           func(ctx context.Context, market string, orderId string) (string, error)
+	CancelOrderByClientIdFunc func(ctx context.Context, market string, clientOrderId string) (string, error)
+	NewOrderFunc              func(ctx context.Context, market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error)
+	NewOrdersFunc             func(ctx context.Context, orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error)
+	UpdateOrderFunc           func(ctx context.Context, market string, orderId string, order types.OrderUpdate) (types.Order, error)
+	ReplaceOrderFunc          func(ctx context.Context, market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error)
+	GetDepositAssetFunc       func(ctx context.Context, symbol string) (types.DepositAsset, error)
+	GetDepositFiatFunc        func(ctx context.Context, symbol string) (types.DepositFiat, error)
+	GetDepositHistoryFunc     func(ctx context.Context, params ...http.OptionalParams) ([]types.DepositHistory, error)
+	GetWithdrawalHistoryFunc  func(ctx context.Context, params ...http.OptionalParams) ([]types.WithdrawalHistory, error)
+	GetTransactionHistoryFunc func(ctx context.Context, params ...http.OptionalParams) (types.TransactionHistory, error)
+	WithdrawFunc              func(ctx context.Context, symbol string, amount float64, address string, withdrawal types.Withdrawal) (types.WithDrawalResponse, error)
+	DoRawFunc                 func(ctx context.Context, method string, path string, params url.Values, payload []byte) ([]byte, error)
+}
+
+var _ http.HttpClientAuth = (*HttpClientAuth)(nil)
+
+func (m *HttpClientAuth) ToDecimalClient() http.HttpClientAuthDec {
+	m.record("ToDecimalClient")
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Decimal == nil {
+		m.Decimal = &HttpClientAuthDec{}
+	}
+	return m.Decimal
+}
+
+func (m *HttpClientAuth) GetBalance(symbol ...string) ([]types.Balance, error) {
+	return m.GetBalanceWithContext(context.Background(), symbol...)
+}
+
+func (m *HttpClientAuth) GetBalanceWithContext(ctx context.Context, symbol ...string) ([]types.Balance, error) {
+	if err := m.before("GetBalance", m.GetBalanceFunc != nil, symbol); err != nil {
+		return nil, err
+	}
+	return m.GetBalanceFunc(ctx, symbol...)
+}
+
+func (m *HttpClientAuth) GetAccount() (types.Account, error) {
+	return m.GetAccountWithContext(context.Background())
+}
+
+func (m *HttpClientAuth) GetAccountWithContext(ctx context.Context) (types.Account, error) {
+	if err := m.before("GetAccount", m.GetAccountFunc != nil); err != nil {
+		return types.Account{}, err
+	}
+	return m.GetAccountFunc(ctx)
+}
+
+func (m *HttpClientAuth) GetFees(market string) (types.MarketFee, error) {
+	return m.GetFeesWithContext(context.Background(), market)
+}
+
+func (m *HttpClientAuth) GetFeesWithContext(ctx context.Context, market string) (types.MarketFee, error) {
+	if err := m.before("GetFees", m.GetFeesFunc != nil, market); err != nil {
+		return types.MarketFee{}, err
+	}
+	return m.GetFeesFunc(ctx, market)
+}
+
+func (m *HttpClientAuth) EstimateFee(market string, side types.Side, amount float64, price float64, taker bool) (types.FeeEstimate, error) {
+	return m.EstimateFeeWithContext(context.Background(), market, side, amount, price, taker)
+}
+
+func (m *HttpClientAuth) EstimateFeeWithContext(ctx context.Context, market string, side types.Side, amount float64, price float64, taker bool) (types.FeeEstimate, error) {
+	if err := m.before("EstimateFee", m.EstimateFeeFunc != nil, market, side, amount, price, taker); err != nil {
+		return types.FeeEstimate{}, err
+	}
+	return m.EstimateFeeFunc(ctx, market, side, amount, price, taker)
+}
+
+func (m *HttpClientAuth) GetPortfolioValue(quote string) (types.Portfolio, error) {
+	return m.GetPortfolioValueWithContext(context.Background(), quote)
+}
+
+func (m *HttpClientAuth) GetPortfolioValueWithContext(ctx context.Context, quote string) (types.Portfolio, error) {
+	if err := m.before("GetPortfolioValue", m.GetPortfolioValueFunc != nil, quote); err != nil {
+		return types.Portfolio{}, err
+	}
+	return m.GetPortfolioValueFunc(ctx, quote)
+}
+
+func (m *HttpClientAuth) GetTrades(market string, params ...http.OptionalParams) ([]types.TradeHistoric, error) {
+	return m.GetTradesWithContext(context.Background(), market, params...)
+}
+
+func (m *HttpClientAuth) GetTradesWithContext(ctx context.Context, market string, params ...http.OptionalParams) ([]types.TradeHistoric, error) {
+	if err := m.before("GetTrades", m.GetTradesFunc != nil, market, params); err != nil {
+		return nil, err
+	}
+	return m.GetTradesFunc(ctx, market, params...)
+}
+
+func (m *HttpClientAuth) GetTradesSeq(market string, params ...http.OptionalParams) iter.Seq2[types.TradeHistoric, error] {
+	return m.GetTradesSeqWithContext(context.Background(), market, params...)
+}
+
+func (m *HttpClientAuth) GetTradesSeqWithContext(ctx context.Context, market string, params ...http.OptionalParams) iter.Seq2[types.TradeHistoric, error] {
+	if err := m.before("GetTradesSeq", m.GetTradesSeqFunc != nil, market, params); err != nil {
+		return func(yield func(types.TradeHistoric, error) bool) {
+			yield(types.TradeHistoric{}, err)
+		}
+	}
+	return m.GetTradesSeqFunc(ctx, market, params...)
+}
+
+func (m *HttpClientAuth) GetAllTrades(market string) ([]types.TradeHistoric, error) {
+	return m.GetAllTradesWithContext(context.Background(), market)
+}
+
+func (m *HttpClientAuth) GetAllTradesWithContext(ctx context.Context, market string) ([]types.TradeHistoric, error) {
+	if err := m.before("GetAllTrades", m.GetAllTradesFunc != nil, market); err != nil {
+		return nil, err
+	}
+	return m.GetAllTradesFunc(ctx, market)
+}
+
+func (m *HttpClientAuth) GetOrders(market string, params ...http.OptionalParams) ([]types.Order, error) {
+	return m.GetOrdersWithContext(context.Background(), market, params...)
+}
+
+func (m *HttpClientAuth) GetOrdersWithContext(ctx context.Context, market string, params ...http.OptionalParams) ([]types.Order, error) {
+	if err := m.before("GetOrders", m.GetOrdersFunc != nil, market, params); err != nil {
+		return nil, err
+	}
+	return m.GetOrdersFunc(ctx, market, params...)
+}
+
+func (m *HttpClientAuth) GetOrdersSeq(market string, params ...http.OptionalParams) iter.Seq2[types.Order, error] {
+	return m.GetOrdersSeqWithContext(context.Background(), market, params...)
+}
+
+func (m *HttpClientAuth) GetOrdersSeqWithContext(ctx context.Context, market string, params ...http.OptionalParams) iter.Seq2[types.Order, error] {
+	if err := m.before("GetOrdersSeq", m.GetOrdersSeqFunc != nil, market, params); err != nil {
+		return func(yield func(types.Order, error) bool) {
+			yield(types.Order{}, err)
+		}
+	}
+	return m.GetOrdersSeqFunc(ctx, market, params...)
+}
+
+func (m *HttpClientAuth) GetAllOrders(market string) ([]types.Order, error) {
+	return m.GetAllOrdersWithContext(context.Background(), market)
+}
+
+func (m *HttpClientAuth) GetAllOrdersWithContext(ctx context.Context, market string) ([]types.Order, error) {
+	if err := m.before("GetAllOrders", m.GetAllOrdersFunc != nil, market); err != nil {
+		return nil, err
+	}
+	return m.GetAllOrdersFunc(ctx, market)
+}
+
+func (m *HttpClientAuth) GetOrdersOpen(market ...string) ([]types.Order, error) {
+	return m.GetOrdersOpenWithContext(context.Background(), market...)
+}
+
+func (m *HttpClientAuth) GetOrdersOpenWithContext(ctx context.Context, market ...string) ([]types.Order, error) {
+	if err := m.before("GetOrdersOpen", m.GetOrdersOpenFunc != nil, market); err != nil {
+		return nil, err
+	}
+	return m.GetOrdersOpenFunc(ctx, market...)
+}
+
+func (m *HttpClientAuth) GetOrder(market string, orderId string) (types.Order, error) {
+	return m.GetOrderWithContext(context.Background(), market, orderId)
+}
+
+func (m *HttpClientAuth) GetOrderWithContext(ctx context.Context, market string, orderId string) (types.Order, error) {
+	if err := m.before("GetOrder", m.GetOrderFunc != nil, market, orderId); err != nil {
+		return types.Order{}, err
+	}
+	return m.GetOrderFunc(ctx, market, orderId)
+}
+
+func (m *HttpClientAuth) GetOrderByClientId(market string, clientOrderId string) (types.Order, error) {
+	return m.GetOrderByClientIdWithContext(context.Background(), market, clientOrderId)
+}
+
+func (m *HttpClientAuth) GetOrderByClientIdWithContext(ctx context.Context, market string, clientOrderId string) (types.Order, error) {
+	if err := m.before("GetOrderByClientId", m.GetOrderByClientIdFunc != nil, market, clientOrderId); err != nil {
+		return types.Order{}, err
+	}
+	return m.GetOrderByClientIdFunc(ctx, market, clientOrderId)
+}
+
+func (m *HttpClientAuth) CancelOrders(market ...string) ([]string, error) {
+	return m.CancelOrdersWithContext(context.Background(), market...)
+}
+
+func (m *HttpClientAuth) CancelOrdersWithContext(ctx context.Context, market ...string) ([]string, error) {
+	if err := m.before("CancelOrders", m.CancelOrdersFunc != nil, market); err != nil {
+		return nil, err
+	}
+	return m.CancelOrdersFunc(ctx, market...)
+}
+
+func (m *HttpClientAuth) CancelMarketOrders(markets []string) (map[string][]string, error) {
+	return m.CancelMarketOrdersWithContext(context.Background(), markets)
+}
+
+func (m *HttpClientAuth) CancelMarketOrdersWithContext(ctx context.Context, markets []string) (map[string][]string, error) {
+	if err := m.before("CancelMarketOrders", m.CancelMarketOrdersFunc != nil, markets); err != nil {
+		return nil, err
+	}
+	return m.CancelMarketOrdersFunc(ctx, markets)
+}
+
+func (m *HttpClientAuth) CancelOrder(market string, orderId string) (string, error) {
+	return m.CancelOrderWithContext(context.Background(), market, orderId)
+}
+
+func (m *HttpClientAuth) CancelOrderWithContext(ctx context.Context, market string, orderId string) (string, error) {
+	if err := m.before("CancelOrder", m.CancelOrderFunc != nil, market, orderId); err != nil {
+		return "", err
+	}
+	return m.CancelOrderFunc(ctx, market, orderId)
+}
+
+func (m *HttpClientAuth) CancelOrderByClientId(market string, clientOrderId string) (string, error) {
+	return m.CancelOrderByClientIdWithContext(context.Background(), market, clientOrderId)
+}
+
+func (m *HttpClientAuth) CancelOrderByClientIdWithContext(ctx context.Context, market string, clientOrderId string) (string, error) {
+	if err := m.before("CancelOrderByClientId", m.CancelOrderByClientIdFunc != nil, market, clientOrderId); err != nil {
+		return "", err
+	}
+	return m.CancelOrderByClientIdFunc(ctx, market, clientOrderId)
+}
+
+func (m *HttpClientAuth) NewOrder(market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	return m.NewOrderWithContext(context.Background(), market, side, orderType, order)
+}
+
+func (m *HttpClientAuth) NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	if err := m.before("NewOrder", m.NewOrderFunc != nil, market, side, orderType, order); err != nil {
+		return types.Order{}, err
+	}
+	return m.NewOrderFunc(ctx, market, side, orderType, order)
+}
+
+func (m *HttpClientAuth) NewOrders(orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error) {
+	return m.NewOrdersWithContext(context.Background(), orders, failFast...)
+}
+
+func (m *HttpClientAuth) NewOrdersWithContext(ctx context.Context, orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error) {
+	if err := m.before("NewOrders", m.NewOrdersFunc != nil, orders, failFast); err != nil {
+		return nil, err
+	}
+	return m.NewOrdersFunc(ctx, orders, failFast...)
+}
+
+func (m *HttpClientAuth) UpdateOrder(market string, orderId string, order types.OrderUpdate) (types.Order, error) {
+	return m.UpdateOrderWithContext(context.Background(), market, orderId, order)
+}
+
+func (m *HttpClientAuth) UpdateOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderUpdate) (types.Order, error) {
+	if err := m.before("UpdateOrder", m.UpdateOrderFunc != nil, market, orderId, order); err != nil {
+		return types.Order{}, err
+	}
+	return m.UpdateOrderFunc(ctx, market, orderId, order)
+}
+
+func (m *HttpClientAuth) ReplaceOrder(market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	return m.ReplaceOrderWithContext(context.Background(), market, orderId, order, reduceByFilled...)
+}
+
+func (m *HttpClientAuth) ReplaceOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	if err := m.before("ReplaceOrder", m.ReplaceOrderFunc != nil, market, orderId, order, reduceByFilled); err != nil {
+		return http.ReplaceResult{}, err
+	}
+	return m.ReplaceOrderFunc(ctx, market, orderId, order, reduceByFilled...)
+}
+
+func (m *HttpClientAuth) GetDepositAsset(symbol string) (types.DepositAsset, error) {
+	return m.GetDepositAssetWithContext(context.Background(), symbol)
+}
+
+func (m *HttpClientAuth) GetDepositAssetWithContext(ctx context.Context, symbol string) (types.DepositAsset, error) {
+	if err := m.before("GetDepositAsset", m.GetDepositAssetFunc != nil, symbol); err != nil {
+		return types.DepositAsset{}, err
+	}
+	return m.GetDepositAssetFunc(ctx, symbol)
+}
+
+func (m *HttpClientAuth) GetDepositFiat(symbol string) (types.DepositFiat, error) {
+	return m.GetDepositFiatWithContext(context.Background(), symbol)
+}
+
+func (m *HttpClientAuth) GetDepositFiatWithContext(ctx context.Context, symbol string) (types.DepositFiat, error) {
+	if err := m.before("GetDepositFiat", m.GetDepositFiatFunc != nil, symbol); err != nil {
+		return types.DepositFiat{}, err
+	}
+	return m.GetDepositFiatFunc(ctx, symbol)
+}
+
+func (m *HttpClientAuth) GetDepositHistory(params ...http.OptionalParams) ([]types.DepositHistory, error) {
+	return m.GetDepositHistoryWithContext(context.Background(), params...)
+}
+
+func (m *HttpClientAuth) GetDepositHistoryWithContext(ctx context.Context, params ...http.OptionalParams) ([]types.DepositHistory, error) {
+	if err := m.before("GetDepositHistory", m.GetDepositHistoryFunc != nil, params); err != nil {
+		return nil, err
+	}
+	return m.GetDepositHistoryFunc(ctx, params...)
+}
+
+func (m *HttpClientAuth) GetWithdrawalHistory(params ...http.OptionalParams) ([]types.WithdrawalHistory, error) {
+	return m.GetWithdrawalHistoryWithContext(context.Background(), params...)
+}
+
+func (m *HttpClientAuth) GetWithdrawalHistoryWithContext(ctx context.Context, params ...http.OptionalParams) ([]types.WithdrawalHistory, error) {
+	if err := m.before("GetWithdrawalHistory", m.GetWithdrawalHistoryFunc != nil, params); err != nil {
+		return nil, err
+	}
+	return m.GetWithdrawalHistoryFunc(ctx, params...)
+}
+
+func (m *HttpClientAuth) GetTransactionHistory(params ...http.OptionalParams) (types.TransactionHistory, error) {
+	return m.GetTransactionHistoryWithContext(context.Background(), params...)
+}
+
+func (m *HttpClientAuth) GetTransactionHistoryWithContext(ctx context.Context, params ...http.OptionalParams) (types.TransactionHistory, error) {
+	if err := m.before("GetTransactionHistory", m.GetTransactionHistoryFunc != nil, params); err != nil {
+		return types.TransactionHistory{}, err
+	}
+	return m.GetTransactionHistoryFunc(ctx, params...)
+}
+
+func (m *HttpClientAuth) Withdraw(symbol string, amount float64, address string, withdrawal types.Withdrawal) (types.WithDrawalResponse, error) {
+	return m.WithdrawWithContext(context.Background(), symbol, amount, address, withdrawal)
+}
+
+func (m *HttpClientAuth) WithdrawWithContext(ctx context.Context, symbol string, amount float64, address string, withdrawal types.Withdrawal) (types.WithDrawalResponse, error) {
+	if err := m.before("Withdraw", m.WithdrawFunc != nil, symbol, amount, address, withdrawal); err != nil {
+		return types.WithDrawalResponse{}, err
+	}
+	return m.WithdrawFunc(ctx, symbol, amount, address, withdrawal)
+}
+
+func (m *HttpClientAuth) DoRaw(ctx context.Context, method string, path string, params url.Values, payload []byte) ([]byte, error) {
+	if err := m.before("DoRaw", m.DoRawFunc != nil, method, path, params, payload); err != nil {
+		return nil, err
+	}
+	return m.DoRawFunc(ctx, method, path, params, payload)
+}
@@ -0,0 +1,114 @@
+// Package mock provides configurable fake implementations of the http clients, so you can unit test
+// code which depends on http.HttpClient or http.HttpClientAuth without calling Bitvavo.
+//
+//	client := &mock.HttpClient{
+//		GetMarketsFunc: func(ctx context.Context) ([]types.Market, error) {
+//			return []types.Market{{Market: "ETH-EUR", Status: "trading"}}, nil
+//		},
+//	}
+//	client.FailNext("GetMarkets", errors.New("connection reset"))
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNotConfigured is returned by a method whose Func field is nil.
+var ErrNotConfigured = errors.New("method is not configured")
+
+var errNotConfigured = func(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotConfigured, method)
+}
+
+// Call is a recorded call of a method with its arguments (without context)
+type Call struct {
+	Method string
+	Args   []any
+}
+
+type recorder struct {
+	mu       sync.Mutex
+	calls    []Call
+	failures map[string]error
+	next     map[string][]error
+}
+
+// Calls returns all recorded calls in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// CallCount returns the number of calls of method (e.g: GetMarkets)
+func (r *recorder) CallCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, call := range r.calls {
+		if call.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+// Fail makes every call of method (e.g: GetMarkets) return err, pass a nil err to stop failing.
+func (r *recorder) Fail(method string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failures == nil {
+		r.failures = make(map[string]error)
+	}
+	if err == nil {
+		delete(r.failures, method)
+	} else {
+		r.failures[method] = err
+	}
+}
+
+// FailNext makes the next calls of method (e.g: NewOrder) return errs, one error per call in order.
+// Scripted errors take precedence over Fail.
+func (r *recorder) FailNext(method string, errs ...error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.next == nil {
+		r.next = make(map[string][]error)
+	}
+	r.next[method] = append(r.next[method], errs...)
+}
+
+func (r *recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// before records the call of method and returns the error it should fail with, if any.
+func (r *recorder) before(method string, configured bool, args ...any) error {
+	r.record(method, args...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if errs := r.next[method]; len(errs) > 0 {
+		r.next[method] = errs[1:]
+		return errs[0]
+	}
+	if err, found := r.failures[method]; found {
+		return err
+	}
+	if !configured {
+		return errNotConfigured(method)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+const (
+	// The max number of orders or trades Bitvavo returns per request.
+	maxPageLimit = 1000
+
+	// The rate limit which pagination leaves for other requests, it waits for the reset otherwise.
+	paginateReserve = 50
+)
+
+func (c *httpClientAuth) GetAllOrders(market string) ([]types.Order, error) {
+	return c.GetAllOrdersWithContext(context.Background(), market)
+}
+
+func (c *httpClientAuth) GetAllOrdersWithContext(ctx context.Context, market string) ([]types.Order, error) {
+	params := &types.OrderParams{Limit: maxPageLimit}
+
+	return paginate(func() ([]types.Order, error) {
+		if err := c.client.waitForBudget(ctx, endpointWeights["GET /orders"], paginateReserve, false); err != nil {
+			return nil, err
+		}
+		return c.GetOrdersWithContext(ctx, market, params)
+	}, func(order types.Order) string {
+		return order.OrderId
+	}, func(orderId string) {
+		params.OrderIdTo = orderId
+	})
+}
+
+func (c *httpClientAuth) GetAllTrades(market string) ([]types.TradeHistoric, error) {
+	return c.GetAllTradesWithContext(context.Background(), market)
+}
+
+func (c *httpClientAuth) GetAllTradesWithContext(ctx context.Context, market string) ([]types.TradeHistoric, error) {
+	params := &types.TradeParams{Limit: maxPageLimit}
+
+	return paginate(func() ([]types.TradeHistoric, error) {
+		if err := c.client.waitForBudget(ctx, endpointWeights["GET /trades"], paginateReserve, false); err != nil {
+			return nil, err
+		}
+		return c.GetTradesWithContext(ctx, market, params)
+	}, func(trade types.TradeHistoric) string {
+		return trade.FillId
+	}, func(tradeId string) {
+		params.TradeIdTo = tradeId
+	})
+}
+
+// paginate fetches pages (newest first) until exhaustion, setOldest is called with the id of the
+// oldest item of the previous page, which is skipped whenever it's part of the next page.
+func paginate[T any](
+	fetch func() ([]T, error),
+	getId func(item T) string,
+	setOldest func(id string),
+) ([]T, error) {
+	var (
+		items  = make([]T, 0)
+		oldest string
+	)
+
+	for {
+		page, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		size := len(page)
+
+		if oldest != "" && size > 0 && getId(page[0]) == oldest {
+			page = page[1:]
+		}
+		items = append(items, page...)
+
+		if size < maxPageLimit || len(page) == 0 {
+			return items, nil
+		}
+
+		oldest = getId(page[len(page)-1])
+		setOldest(oldest)
+	}
+}
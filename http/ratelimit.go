@@ -0,0 +1,272 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// The rate limit (weight points per minute) Bitvavo applies to each api key or ip.
+	defaultRateLimit       = 1000
+	defaultRateLimitWindow = time.Minute
+	defaultWeight          = 1
+	requestWeightCandles   = 1
+	requestWeightTrades    = 5
+)
+
+// The weight of each endpoint, keyed by method and path. Markets in a path are replaced by {market}
+var endpointWeights = map[string]int64{
+	"GET /time":              1,
+	"GET /markets":           1,
+	"GET /assets":            1,
+	"GET /{market}/book":     1,
+	"GET /{market}/trades":   requestWeightTrades,
+	"GET /{market}/candles":  requestWeightCandles,
+	"GET /ticker/price":      1,
+	"GET /ticker/book":       1,
+	"GET /ticker/24h":        1,
+	"POST /order":            1,
+	"PUT /order":             1,
+	"GET /order":             1,
+	"DELETE /order":          1,
+	"GET /orders":            5,
+	"DELETE /orders":         1,
+	"GET /ordersOpen":        1,
+	"GET /trades":            5,
+	"GET /account":           1,
+	"GET /account/history":   1,
+	"GET /balance":           5,
+	"GET /deposit":           1,
+	"GET /depositHistory":    5,
+	"POST /withdrawal":       1,
+	"GET /withdrawalHistory": 5,
+}
+
+// The weight of endpoints which are requested without a market, keyed by method and path.
+var endpointWeightsAllMarkets = map[string]int64{
+	"GET /ticker/24h": 25,
+	"GET /ordersOpen": 25,
+}
+
+// ErrRateLimitGuard is returned in fail fast mode whenever a request would drop the remaining rate limit below the threshold.
+var ErrRateLimitGuard = errors.New("remaining rate limit would drop below the threshold")
+
+type rateLimitGuard struct {
+	threshold int64
+	failFast  bool
+}
+
+// Guard the rate limit of your api key, so Bitvavo doesn't ban it.
+// Whenever a request would drop the remaining rate limit below threshold, the request
+// blocks until the rate limit resets.
+//
+// Set failFast to return ErrRateLimitGuard immediately instead of blocking.
+// default: no guard
+func WithRateLimitGuard(threshold uint64, failFast ...bool) Option {
+	return func(c *httpClient) {
+		c.ratelimitGuard = &rateLimitGuard{
+			threshold: int64(threshold),
+			failFast:  len(failFast) > 0 && failFast[0],
+		}
+	}
+}
+
+// RateLimiter is the rate limit budget of an api key, which is shared by every client it's injected into (see: WithRateLimiter)
+// so the remaining rate limit and the time it resets are coordinated process-wide. It's safe for concurrent use.
+//
+// The weight of each request is subtracted from the estimate before it is sent and the estimate
+// is corrected by the remaining rate limit of every response.
+type RateLimiter struct {
+	mu              sync.Mutex
+	remaining       int64
+	resetAt         time.Time
+	estimate        int64
+	estimateResetAt time.Time
+}
+
+// NewRateLimiter creates the budget of a single api key.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		remaining: -1,
+		estimate:  defaultRateLimit,
+	}
+}
+
+// Remaining returns the remaining rate limit according to the last response, -1 if there was no response yet.
+func (r *RateLimiter) Remaining() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.remaining
+}
+
+// ResetAt returns the time (local time) when the rate limit resets according to the last response.
+func (r *RateLimiter) ResetAt() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.resetAt
+}
+
+// Estimate returns the estimated remaining rate limit.
+func (r *RateLimiter) Estimate() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.resetEstimateIfExpired()
+	return r.estimate
+}
+
+// Reserve subtracts weight from the estimated remaining rate limit, call it before sending a request
+// which isn't sent by an injected client (e.g: an order placed over the websocket)
+func (r *RateLimiter) Reserve(weight int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.resetEstimateIfExpired()
+	r.estimate -= weight
+}
+
+// Update sets the remaining rate limit and the time it resets as reported by Bitvavo.
+func (r *RateLimiter) Update(remaining int64, resetAt time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.remaining, r.estimate = remaining, remaining
+	r.resetAt, r.estimateResetAt = resetAt, resetAt
+}
+
+// Wait blocks until the estimated remaining rate limit minus weight is at least threshold or the rate limit resets.
+func (r *RateLimiter) Wait(ctx context.Context, weight int64, threshold int64) error {
+	return r.wait(ctx, weight, threshold, false)
+}
+
+func (r *RateLimiter) wait(ctx context.Context, weight int64, threshold int64, failFast bool) error {
+	for {
+		r.mu.Lock()
+		r.resetEstimateIfExpired()
+		remaining, resetAt := r.estimate, r.estimateResetAt
+		r.mu.Unlock()
+
+		if remaining-weight >= threshold {
+			return nil
+		}
+
+		wait := time.Until(resetAt)
+		if wait <= 0 {
+			return nil
+		}
+
+		if failFast {
+			return fmt.Errorf("%w, remaining: %d, resets at: %s", ErrRateLimitGuard, remaining, resetAt)
+		}
+
+		log.Debug().Int64("remaining", remaining).Dur("wait", wait).Msg("rate limit guard, waiting for reset")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (r *RateLimiter) updateRemaining(remaining int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remaining = remaining
+	r.estimate = remaining
+}
+
+func (r *RateLimiter) updateResetAt(resetAt time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resetAt = resetAt
+	r.estimateResetAt = resetAt
+}
+
+// resetEstimateIfExpired restores the full budget whenever the rate limit has been reset, r.mu must be held.
+func (r *RateLimiter) resetEstimateIfExpired() {
+	now := time.Now()
+	if now.Before(r.estimateResetAt) {
+		return
+	}
+	r.estimate = defaultRateLimit
+	r.estimateResetAt = now.Add(defaultRateLimitWindow)
+}
+
+// Share the rate limit budget with other clients which use the same api key, so they don't exceed it together.
+// default: a budget per client
+func WithRateLimiter(ratelimiter *RateLimiter) Option {
+	return func(c *httpClient) {
+		c.ratelimiter = ratelimiter
+	}
+}
+
+func (c *httpClient) RateLimitEstimate() int64 {
+	return c.ratelimiter.Estimate()
+}
+
+// reserveRateLimit waits for the rate limit guard and subtracts weight from the budget.
+func (c *httpClient) reserveRateLimit(ctx context.Context, weight int64) error {
+	if err := c.waitForRateLimit(ctx, weight); err != nil {
+		return err
+	}
+
+	c.ratelimiter.Reserve(weight)
+	return nil
+}
+
+// waitForRateLimit blocks until the request with weight fits in the estimated rate limit, according to the guard.
+func (c *httpClient) waitForRateLimit(ctx context.Context, weight int64) error {
+	guard := c.ratelimitGuard
+	if guard == nil {
+		return nil
+	}
+
+	return c.waitForBudget(ctx, weight, guard.threshold, guard.failFast)
+}
+
+// waitForBudget blocks until the estimated rate limit minus weight is at least threshold or the rate limit resets.
+func (c *httpClient) waitForBudget(ctx context.Context, weight int64, threshold int64, failFast bool) error {
+	return c.ratelimiter.wait(ctx, weight, threshold, failFast)
+}
+
+// RequestWeight returns the weight of the endpoint of request, which counts against the rate limit.
+func RequestWeight(request *http.Request) int64 {
+	key := fmt.Sprintf("%s %s", request.Method, Endpoint(request))
+	if !request.URL.Query().Has("market") {
+		if weight, found := endpointWeightsAllMarkets[key]; found {
+			return weight
+		}
+	}
+	if weight, found := endpointWeights[key]; found {
+		return weight
+	}
+	return defaultWeight
+}
+
+// Endpoint returns the path of request without the version, markets in the path are replaced by {market}
+// (e.g: /{market}/candles), so it can be used as a label which doesn't grow with the number of markets.
+func Endpoint(request *http.Request) string {
+	if _, resource, found := marketPath(request); found {
+		return fmt.Sprintf("/{market}/%s", resource)
+	}
+	return strings.TrimPrefix(request.URL.Path, bitvavoPath)
+}
+
+// marketPath returns the market and the resource of a path which starts with a market (e.g: /v2/ETH-EUR/book)
+func marketPath(request *http.Request) (string, string, bool) {
+	path := strings.TrimPrefix(request.URL.Path, bitvavoPath)
+	if parts := strings.Split(strings.Trim(path, "/"), "/"); len(parts) == 2 && strings.Contains(parts[0], "-") {
+		return parts[0], parts[1], true
+	}
+	return "", "", false
+}
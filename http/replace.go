@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// The interval in which ReplaceOrder polls the canceled order until the cancel is confirmed.
+const replacePollInterval = 250 * time.Millisecond
+
+// ErrOrderFilled is returned by ReplaceOrder whenever the order has been filled before it could be canceled,
+// so there is nothing left to replace.
+var ErrOrderFilled = errors.New("order has been filled before it was canceled")
+
+// ReplaceResult is the outcome of ReplaceOrder.
+type ReplaceResult struct {
+	// The final state of the replaced order, its filled amount contains the fills which happened before the cancel.
+	Previous types.Order
+
+	// The replacement order, empty if it hasn't been placed.
+	Replacement types.Order
+
+	// Whether the replacement order has been placed.
+	Placed bool
+}
+
+func (c *httpClientAuth) ReplaceOrder(market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (ReplaceResult, error) {
+	return c.ReplaceOrderWithContext(context.Background(), market, orderId, order, reduceByFilled...)
+}
+
+func (c *httpClientAuth) ReplaceOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (ReplaceResult, error) {
+	return ReplaceOrderWith(ctx, c, market, orderId, order, len(reduceByFilled) > 0 && reduceByFilled[0])
+}
+
+// ReplaceOrderWith cancels the order of market with orderId using client, waits until the cancel is confirmed
+// and then places order with client (see: HttpClientAuth.ReplaceOrder)
+func ReplaceOrderWith(
+	ctx context.Context,
+	client HttpClientAuth,
+	market string,
+	orderId string,
+	order types.OrderNew,
+	reduceByFilled bool,
+) (ReplaceResult, error) {
+	var result ReplaceResult
+
+	if _, err := client.CancelOrderWithContext(ctx, market, orderId); err != nil && !types.IsOrderNotFound(err) {
+		return result, fmt.Errorf("failed to cancel order %s: %w", orderId, err)
+	}
+
+	previous, err := waitForFinalOrder(ctx, client, market, orderId)
+	if err != nil {
+		return result, fmt.Errorf("failed to confirm cancel of order %s: %w", orderId, err)
+	}
+	result.Previous = previous
+
+	if previous.Status == types.StatusFilled {
+		return result, ErrOrderFilled
+	}
+
+	if reduceByFilled {
+		if order.AmountQuote > 0 {
+			order.AmountQuote -= previous.FilledAmountQuote
+		} else {
+			order.Amount -= previous.FilledAmount
+		}
+		if order.Amount <= 0 && order.AmountQuote <= 0 {
+			return result, ErrOrderFilled
+		}
+	}
+
+	order.Market = market
+	replacement, err := client.NewOrderWithContext(ctx, market, order.Side, order.OrderType, order)
+	if err != nil {
+		return result, fmt.Errorf("failed to place replacement of order %s: %w", orderId, err)
+	}
+	result.Replacement = replacement
+	result.Placed = true
+	return result, nil
+}
+
+// waitForFinalOrder polls the order until it has a final status (e.g: canceled or filled)
+func waitForFinalOrder(ctx context.Context, client HttpClientAuth, market string, orderId string) (types.Order, error) {
+	for {
+		order, err := client.GetOrderWithContext(ctx, market, orderId)
+		if err != nil {
+			return order, err
+		}
+		if order.Status.IsFinal() {
+			return order, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return order, ctx.Err()
+		case <-time.After(replacePollInterval):
+		}
+	}
+}
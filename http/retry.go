@@ -0,0 +1,242 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultRetryMinBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff = 5 * time.Second
+
+	// The max wait for the rate limit to reset (see: WithRateLimitWait), which is the wait as well
+	// whenever the response has no valid reset time. Bitvavo resets the rate limit every minute.
+	maxRateLimitWait = time.Minute
+)
+
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy retries requests which failed with a network error or a retryable status code.
+//
+// Only idempotent requests (GET, DELETE) are retried by default,
+// so an order is never placed twice unless you explicitly enable RetryNonIdempotent.
+type RetryPolicy struct {
+	// The max number of retries after the first attempt.
+	MaxRetries uint64
+
+	// The backoff before the first retry, the backoff doubles after each retry.
+	//
+	// Default value: 100ms
+	MinBackoff time.Duration
+
+	// The backoff never exceeds MaxBackoff.
+	//
+	// Default value: 5s
+	MaxBackoff time.Duration
+
+	// The response status codes which are retried.
+	//
+	// Default value: 429, 500, 502, 503, 504
+	RetryableStatusCodes []int
+
+	// Retry POST and PUT requests as well (e.g: order creation)
+	RetryNonIdempotent bool
+}
+
+// Retry transient failures with exponential backoff according to policy.
+// default: no retries
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *httpClient) {
+		if policy.MinBackoff == 0 {
+			policy.MinBackoff = defaultRetryMinBackoff
+		}
+		if policy.MaxBackoff == 0 {
+			policy.MaxBackoff = defaultRetryMaxBackoff
+		}
+		if policy.RetryableStatusCodes == nil {
+			policy.RetryableStatusCodes = defaultRetryableStatusCodes
+		}
+		c.retryPolicy = &policy
+	}
+}
+
+func (p *RetryPolicy) canRetry(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodDelete:
+		return true
+	default:
+		return p.RetryNonIdempotent
+	}
+}
+
+func (p *RetryPolicy) isRetryable(ctx context.Context, response *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return slices.Contains(p.RetryableStatusCodes, response.StatusCode)
+}
+
+// Whenever a request is rejected because the rate limit has been exceeded (429), wait until the rate limit resets
+// and retry the request once, instead of returning the error. The wait is bounded by the context of the request.
+// default: false
+func WithRateLimitWait(wait bool) Option {
+	return func(c *httpClient) {
+		c.rateLimitWait = wait
+	}
+}
+
+// do executes the request with retries, whenever the rate limit has been exceeded
+// it waits for the rate limit to reset and executes the request once more (see: WithRateLimitWait)
+func (c *httpClient) do(request *http.Request, body []byte, config *authConfig) (*http.Response, error) {
+	response, err := c.doWithRetries(request, body, config)
+	if err != nil || !c.rateLimitWait || response.StatusCode != http.StatusTooManyRequests {
+		return response, err
+	}
+
+	wait := maxRateLimitWait
+	if err := c.updateRateLimits(response); err != nil {
+		log.Warn().Err(err).Str("url", request.URL.String()).Dur("wait", wait).Msg("failed to update the rate limit, waiting for the max wait")
+	} else if resetAt, ok := rateLimitResetAt(response); ok {
+		wait = min(time.Until(resetAt), maxRateLimitWait)
+	}
+	response.Body.Close()
+
+	log.Debug().
+		Str("method", request.Method).
+		Str("url", request.URL.String()).
+		Dur("wait", wait).
+		Msg("rate limit exceeded, waiting for reset")
+
+	ctx := request.Context()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(max(wait, 0)):
+	}
+
+	if err := resetBody(request); err != nil {
+		return nil, err
+	}
+	return c.doWithRetries(request, body, config)
+}
+
+// rateLimitResetAt returns the time at which the rate limit resets according to response, false if the header is missing or invalid.
+func rateLimitResetAt(response *http.Response) (time.Time, bool) {
+	resetAt, err := strconv.ParseInt(response.Header.Get(headerRatelimitResetAt), 10, 64)
+	if err != nil || resetAt <= 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(resetAt), true
+}
+
+// doWithRetries executes the request and retries it according to the retry policy of the client,
+// every attempt reserves the weight of the request from the rate limit.
+func (c *httpClient) doWithRetries(request *http.Request, body []byte, config *authConfig) (*http.Response, error) {
+	ctx := request.Context()
+	weight := RequestWeight(request)
+
+	policy := c.retryPolicy
+	if policy == nil || !policy.canRetry(request) {
+		if err := c.reserveRateLimit(ctx, weight); err != nil {
+			return nil, err
+		}
+		if err := c.applyHeaders(request, body, config); err != nil {
+			return nil, err
+		}
+		return c.send(request, 0)
+	}
+
+	backoff := policy.MinBackoff
+	for attempt := uint64(0); ; attempt++ {
+		if err := c.reserveRateLimit(ctx, weight); err != nil {
+			return nil, err
+		}
+		if err := c.applyHeaders(request, body, config); err != nil {
+			return nil, err
+		}
+
+		response, err := c.send(request, attempt)
+		if attempt >= policy.MaxRetries || !policy.isRetryable(ctx, response, err) {
+			return response, err
+		}
+
+		if response != nil {
+			if err := c.updateRateLimits(response); err != nil {
+				log.Warn().Err(err).Str("url", request.URL.String()).Msg("failed to update the rate limit before retrying")
+			}
+			response.Body.Close()
+		}
+
+		log.Debug().
+			Str("method", request.Method).
+			Str("url", request.URL.String()).
+			Uint64("attempt", attempt+1).
+			Dur("backoff", backoff).
+			Msg("retrying request")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, policy.MaxBackoff)
+
+		if err := resetBody(request); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// resetBody rewinds the body of request, so it can be sent again.
+func resetBody(request *http.Request) error {
+	if request.GetBody == nil {
+		return nil
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return err
+	}
+	request.Body = body
+
+	return nil
+}
+
+// send executes a single attempt of the request.
+func (c *httpClient) send(request *http.Request, attempt uint64) (*http.Response, error) {
+	for _, hook := range c.onRequest {
+		hook(request)
+	}
+
+	start := time.Now()
+	response, err := c.doer.Do(request)
+	latency := time.Since(start)
+
+	if response != nil {
+		for _, hook := range c.onResponse {
+			hook(response, latency)
+		}
+	}
+
+	for _, hook := range c.onAttempt {
+		hook(request, response, latency)
+	}
+
+	if metadata, ok := request.Context().Value(metadataKey{}).(*ResponseMetadata); ok {
+		metadata.capture(request, response, latency, attempt+1)
+	}
+
+	return response, err
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/crypto"
+)
+
+// SignRequest adds the Bitvavo-Access-* headers to request, signed with apiSecret at timestamp (unix milliseconds).
+// The body must be the exact body of the request, nil if it has none.
+//
+// WindowTimeMs is the window that allows execution of your request (see: ToAuthClient)
+func SignRequest(request *http.Request, body []byte, apiKey string, apiSecret string, timestamp int64, windowTimeMs uint64) {
+	path := strings.TrimPrefix(request.URL.RequestURI(), bitvavoPath)
+
+	request.Header.Set(headerAccessKey, apiKey)
+	request.Header.Set(headerAccessSignature, crypto.CreateSignature(request.Method, path, body, timestamp, apiSecret))
+	request.Header.Set(headerAccessTimestamp, fmt.Sprint(timestamp))
+	request.Header.Set(headerAccessWindow, fmt.Sprint(windowTimeMs))
+}
+
+// SigningTransport is an http.RoundTripper which signs every request with the credentials,
+// so you can integrate the signature of Bitvavo into your own HTTP stack.
+type SigningTransport struct {
+	// The credentials which sign every request.
+	Credentials CredentialsProvider
+
+	// The window that allows execution of your request.
+	//
+	// Default value: 10000
+	WindowTimeMs uint64
+
+	// The transport which executes the signed requests.
+	//
+	// Default value: http.DefaultTransport
+	Base http.RoundTripper
+}
+
+func (t *SigningTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	apiKey, apiSecret, err := t.Credentials.Credentials(request.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	signed := request.Clone(request.Context())
+
+	var body []byte
+	if request.Body != nil && request.Body != http.NoBody {
+		reader := request.Body
+		if request.GetBody != nil {
+			if reader, err = request.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		body, err = io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+		signed.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	windowTimeMs := t.WindowTimeMs
+	if windowTimeMs == 0 {
+		windowTimeMs = defaultWindowTimeMs
+	}
+	SignRequest(signed, body, apiKey, apiSecret, time.Now().UnixMilli(), min(windowTimeMs, maxWindowTimeMs))
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(signed)
+}
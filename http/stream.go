@@ -0,0 +1,149 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/goccy/go-json"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// httpStream executes a GET request whose response is a JSON array and yields each item as it's decoded,
+// so the body is never buffered entirely. A failure is yielded as the last (empty) item with the error.
+func httpStream[T any](
+	ctx context.Context,
+	url string,
+	params url.Values,
+	client *httpClient,
+	config *authConfig,
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		req, _ := http.NewRequestWithContext(ctx, "GET", createRequestUrl(url, params), nil)
+		err := httpExecute(client, req, emptyBody, config, func(response *http.Response) error {
+			return decodeStream(response.Body, yield)
+		})
+		if err != nil {
+			var empty T
+			yield(empty, err)
+		}
+	}
+}
+
+// decodeStream decodes the JSON array of reader item by item, until yield returns false.
+func decodeStream[T any](reader io.Reader, yield func(T, error) bool) error {
+	decoder := json.NewDecoder(reader)
+	if err := expectDelim(decoder, '['); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		var item T
+		if err := decoder.Decode(&item); err != nil {
+			return err
+		}
+		if !yield(item, nil) {
+			return nil
+		}
+	}
+
+	return expectDelim(decoder, ']')
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected: %s in JSON array, got: %v", delim, token)
+	}
+	return nil
+}
+
+func (c *httpClient) GetTradesSeq(market string, opt ...OptionalParams) iter.Seq2[types.Trade, error] {
+	return c.GetTradesSeqWithContext(context.Background(), market, opt...)
+}
+
+func (c *httpClient) GetTradesSeqWithContext(ctx context.Context, market string, opt ...OptionalParams) iter.Seq2[types.Trade, error] {
+	params := make(url.Values)
+	if len(opt) > 0 {
+		params = opt[0].Params()
+	}
+	return httpStream[types.Trade](
+		ctx,
+		fmt.Sprintf("%s/%s/trades", bitvavoURL, market),
+		params,
+		c,
+		nil,
+	)
+}
+
+func (c *httpClient) GetCandlesSeq(market string, interval types.Interval, opt ...OptionalParams) iter.Seq2[types.Candle, error] {
+	return c.GetCandlesSeqWithContext(context.Background(), market, interval, opt...)
+}
+
+func (c *httpClient) GetCandlesSeqWithContext(ctx context.Context, market string, interval types.Interval, opt ...OptionalParams) iter.Seq2[types.Candle, error] {
+	if err := interval.Validate(); err != nil {
+		return func(yield func(types.Candle, error) bool) {
+			yield(types.Candle{}, err)
+		}
+	}
+
+	params := make(url.Values)
+	if len(opt) > 0 {
+		params = opt[0].Params()
+	}
+	params.Add("interval", interval.String())
+
+	return httpStream[types.Candle](
+		ctx,
+		fmt.Sprintf("%s/%s/candles", bitvavoURL, market),
+		params,
+		c,
+		nil,
+	)
+}
+
+func (c *httpClientAuth) GetOrdersSeq(market string, opt ...OptionalParams) iter.Seq2[types.Order, error] {
+	return c.GetOrdersSeqWithContext(context.Background(), market, opt...)
+}
+
+func (c *httpClientAuth) GetOrdersSeqWithContext(ctx context.Context, market string, opt ...OptionalParams) iter.Seq2[types.Order, error] {
+	params := make(url.Values)
+	if len(opt) > 0 {
+		params = opt[0].Params()
+	}
+	params.Add("market", market)
+
+	return httpStream[types.Order](
+		ctx,
+		fmt.Sprintf("%s/orders", bitvavoURL),
+		params,
+		c.client,
+		c.config,
+	)
+}
+
+func (c *httpClientAuth) GetTradesSeq(market string, opt ...OptionalParams) iter.Seq2[types.TradeHistoric, error] {
+	return c.GetTradesSeqWithContext(context.Background(), market, opt...)
+}
+
+func (c *httpClientAuth) GetTradesSeqWithContext(ctx context.Context, market string, opt ...OptionalParams) iter.Seq2[types.TradeHistoric, error] {
+	params := make(url.Values)
+	if len(opt) > 0 {
+		params = opt[0].Params()
+	}
+	params.Add("market", market)
+
+	return httpStream[types.TradeHistoric](
+		ctx,
+		fmt.Sprintf("%s/trades", bitvavoURL),
+		params,
+		c.client,
+		c.config,
+	)
+}
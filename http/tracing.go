@@ -0,0 +1,37 @@
+package http
+
+import (
+	"net/http"
+)
+
+// Tracer is called for every request (e.g: to create a span), see the tracing package for OpenTelemetry.
+type Tracer interface {
+	// Start is called before request is executed, the returned request is executed instead (e.g: it carries the context of a span).
+	// end is called once the request has finished (including retries and rate limit waits) with the response, which is nil
+	// if there is none, and the error of the request.
+	Start(request *http.Request) (traced *http.Request, end func(response *http.Response, err error))
+}
+
+// The Tracer which is called for every request.
+// default: no tracer
+func WithTracer(tracer Tracer) Option {
+	return func(c *httpClient) {
+		c.tracer = tracer
+	}
+}
+
+// startTrace starts tracing request with the tracer of c (if any), the returned request must be executed instead.
+func (c *httpClient) startTrace(request *http.Request) (*http.Request, func(response *http.Response, err error)) {
+	if c.tracer == nil {
+		return request, func(*http.Response, error) {}
+	}
+	return c.tracer.Start(request)
+}
+
+// RequestMarket returns the market of request, either from the path or the query.
+func RequestMarket(request *http.Request) string {
+	if market, _, found := marketPath(request); found {
+		return market
+	}
+	return request.URL.Query().Get("market")
+}
@@ -0,0 +1,74 @@
+// Package tracing creates an OpenTelemetry span for every request of the http client.
+//
+// It's a separate module (github.com/larscom/go-bitvavo/v2/http/tracing), so the client itself doesn't depend on OpenTelemetry.
+// Pass the option of New to http.NewHttpClient.
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	httpc "github.com/larscom/go-bitvavo/v2/http"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName      = "github.com/larscom/go-bitvavo/v2/http"
+	headerRatelimit = "Bitvavo-Ratelimit-Remaining"
+)
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// New returns an option for the http client which creates a span with provider for every request,
+// retries and rate limit waits are part of the span.
+//
+// Pass a nil provider to use the global TracerProvider (otel.GetTracerProvider)
+func New(provider trace.TracerProvider) httpc.Option {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return httpc.WithTracer(&tracer{tracer: provider.Tracer(tracerName)})
+}
+
+// Start starts a span for request, the returned request carries the context of the span.
+func (t *tracer) Start(request *http.Request) (*http.Request, func(response *http.Response, err error)) {
+	endpoint := httpc.Endpoint(request)
+
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", request.Method),
+		attribute.String("url.full", request.URL.String()),
+		attribute.String("bitvavo.endpoint", endpoint),
+		attribute.Int64("bitvavo.weight", httpc.RequestWeight(request)),
+	}
+	if market := httpc.RequestMarket(request); market != "" {
+		attributes = append(attributes, attribute.String("bitvavo.market", market))
+	}
+
+	ctx, span := t.tracer.Start(
+		request.Context(),
+		fmt.Sprintf("%s %s", request.Method, endpoint),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+
+	return request.WithContext(ctx), func(response *http.Response, err error) {
+		defer span.End()
+
+		if response != nil {
+			span.SetAttributes(attribute.Int("http.response.status_code", response.StatusCode))
+			if ratelimit, err := strconv.ParseInt(response.Header.Get(headerRatelimit), 10, 64); err == nil {
+				span.SetAttributes(attribute.Int64("bitvavo.ratelimit.remaining", ratelimit))
+			}
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+}
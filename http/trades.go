@@ -0,0 +1,55 @@
+package http
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+const (
+	// The max number of public trades Bitvavo returns per request.
+	maxTradesLimit = 1000
+
+	// The rate limit which GetTradesRange leaves for other requests, it waits for the reset otherwise.
+	tradesRangeReserve = 50
+)
+
+func (c *httpClient) GetTradesRange(market string, start time.Time, end time.Time) ([]types.Trade, error) {
+	return c.GetTradesRangeWithContext(context.Background(), market, start, end)
+}
+
+func (c *httpClient) GetTradesRangeWithContext(ctx context.Context, market string, start time.Time, end time.Time) ([]types.Trade, error) {
+	var (
+		trades    = make([]types.Trade, 0)
+		tradeIdTo string
+	)
+
+	// the trades are returned newest first, so every next page ends before the oldest trade of the previous page
+	for {
+		if err := c.waitForBudget(ctx, requestWeightTrades, tradesRangeReserve, false); err != nil {
+			return nil, err
+		}
+
+		page, err := c.GetTradesWithContext(ctx, market, &types.TradeParams{
+			Limit:     maxTradesLimit,
+			Start:     start,
+			End:       end,
+			TradeIdTo: tradeIdTo,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		trades = append(trades, page...)
+		if len(page) < maxTradesLimit {
+			break
+		}
+		tradeIdTo = page[len(page)-1].Id
+	}
+
+	slices.Reverse(trades)
+
+	return trades, nil
+}
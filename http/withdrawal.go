@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// ErrWithdrawalBlocked is returned by Withdraw whenever the withdrawal guard blocks the withdrawal.
+var ErrWithdrawalBlocked = errors.New("withdrawal blocked by guard")
+
+// WithdrawalGuard is invoked before every withdrawal, the withdrawal is blocked whenever it returns an error.
+type WithdrawalGuard interface {
+	CheckWithdrawal(ctx context.Context, withdrawal types.Withdrawal) error
+}
+
+// WithdrawalGuardFunc is an adapter to use an ordinary function as WithdrawalGuard (e.g: a confirmation callback)
+type WithdrawalGuardFunc func(ctx context.Context, withdrawal types.Withdrawal) error
+
+func (f WithdrawalGuardFunc) CheckWithdrawal(ctx context.Context, withdrawal types.Withdrawal) error {
+	return f(ctx, withdrawal)
+}
+
+// Check every withdrawal with guard before it's executed, so mistakes or compromised code can't withdraw your funds.
+// Multiple guards are checked in order, the withdrawal is blocked by the first guard which returns an error.
+// default: no guard
+func WithWithdrawalGuard(guard ...WithdrawalGuard) Option {
+	return func(c *httpClient) {
+		c.withdrawalGuards = append(c.withdrawalGuards, guard...)
+	}
+}
+
+// AllowedAddresses returns a WithdrawalGuard which only allows withdrawals to the addresses (or IBAN's) of each symbol (e.g: BTC)
+func AllowedAddresses(addresses map[string][]string) WithdrawalGuard {
+	return WithdrawalGuardFunc(func(ctx context.Context, withdrawal types.Withdrawal) error {
+		if !slices.Contains(addresses[withdrawal.Symbol], withdrawal.Address) {
+			return fmt.Errorf("address: %s is not allowed for symbol: %s", withdrawal.Address, withdrawal.Symbol)
+		}
+		return nil
+	})
+}
+
+// MaxWithdrawalAmounts returns a WithdrawalGuard which only allows withdrawals up to the max amount of each symbol (e.g: BTC),
+// withdrawals of symbols without a max amount are blocked.
+func MaxWithdrawalAmounts(amounts map[string]float64) WithdrawalGuard {
+	return WithdrawalGuardFunc(func(ctx context.Context, withdrawal types.Withdrawal) error {
+		maxAmount, found := amounts[withdrawal.Symbol]
+		if !found {
+			return fmt.Errorf("no max amount for symbol: %s", withdrawal.Symbol)
+		}
+		if withdrawal.Amount > maxAmount {
+			return fmt.Errorf("amount: %f exceeds the max amount: %f for symbol: %s", withdrawal.Amount, maxAmount, withdrawal.Symbol)
+		}
+		return nil
+	})
+}
+
+// checkWithdrawal returns ErrWithdrawalBlocked whenever a withdrawal guard of the client blocks withdrawal.
+func (c *httpClient) checkWithdrawal(ctx context.Context, withdrawal types.Withdrawal) error {
+	for _, guard := range c.withdrawalGuards {
+		if err := guard.CheckWithdrawal(ctx, withdrawal); err != nil {
+			return fmt.Errorf("%w: %w", ErrWithdrawalBlocked, err)
+		}
+	}
+	return nil
+}
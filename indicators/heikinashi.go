@@ -0,0 +1,116 @@
+package indicators
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/util"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+// HeikinAshi transforms candles into Heikin-Ashi candles for every market and interval, which smooth out the noise
+// of regular candles. It's safe for concurrent use.
+//
+// The open of a Heikin-Ashi candle depends on the previous candle, which is final once a newer candle has been received.
+type HeikinAshi struct {
+	mu     sync.Mutex
+	series map[string]*heikinAshiSeries
+}
+
+type heikinAshiSeries struct {
+	timestamp int64
+	started   bool
+
+	// the open and close of the previous Heikin-Ashi candle, the current one is still being updated
+	previousOpen  float64
+	previousClose float64
+	hasPrevious   bool
+
+	open  float64
+	close float64
+}
+
+// NewHeikinAshi creates a transformer without candles.
+func NewHeikinAshi() *HeikinAshi {
+	return &HeikinAshi{series: make(map[string]*heikinAshiSeries)}
+}
+
+// Run transforms every event of candlechn and sends the Heikin-Ashi candles to the returned channel,
+// which is closed whenever candlechn is closed. You can set the buffSize for this channel.
+//
+// Default buffSize: 50
+func (h *HeikinAshi) Run(candlechn <-chan ws.CandlesEvent, buffSize ...uint64) <-chan ws.CandlesEvent {
+	var (
+		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
+		outchn = make(chan ws.CandlesEvent, size)
+	)
+
+	go func() {
+		defer close(outchn)
+		for event := range candlechn {
+			if transformed, ok := h.Add(event); ok {
+				outchn <- transformed
+			}
+		}
+	}()
+
+	return outchn
+}
+
+// Add transforms the candle of event into a Heikin-Ashi candle, the market and interval are preserved.
+// It returns false if event is older than the last candle of its market and interval.
+func (h *HeikinAshi) Add(event ws.CandlesEvent) (ws.CandlesEvent, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := event.Market + "_" + event.Interval.String()
+	s, found := h.series[key]
+	if !found {
+		s = new(heikinAshiSeries)
+		h.series[key] = s
+	}
+
+	candle := event.Candle
+	if s.started && candle.Timestamp < s.timestamp {
+		return ws.CandlesEvent{}, false
+	}
+	// the previous candle is final
+	if s.started && candle.Timestamp > s.timestamp {
+		s.previousOpen = s.open
+		s.previousClose = s.close
+		s.hasPrevious = true
+	}
+	s.started = true
+	s.timestamp = candle.Timestamp
+
+	s.close = (candle.Open + candle.High + candle.Low + candle.Close) / 4
+	if s.hasPrevious {
+		s.open = (s.previousOpen + s.previousClose) / 2
+	} else {
+		s.open = (candle.Open + candle.Close) / 2
+	}
+
+	var (
+		high = max(candle.High, s.open, s.close)
+		low  = min(candle.Low, s.open, s.close)
+	)
+	event.Candle = types.Candle{
+		Timestamp: candle.Timestamp,
+		Open:      s.open,
+		OpenStr:   formatFloat(s.open),
+		High:      high,
+		HighStr:   formatFloat(high),
+		Low:       low,
+		LowStr:    formatFloat(low),
+		Close:     s.close,
+		CloseStr:  formatFloat(s.close),
+		Volume:    candle.Volume,
+		VolumeStr: candle.VolumeStr,
+	}
+	return event, true
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,250 @@
+// Package indicators computes technical indicators (e.g: SMA, RSI or MACD) and candle transforms (e.g: Heikin-Ashi) on a stream of candles,
+// so bots don't need an external library with incompatible types.
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Indicator computes a value on a series of prices, it isn't safe for concurrent use.
+type Indicator interface {
+	// Name returns the name of the indicator and its parameters (e.g: sma(20))
+	Name() string
+
+	// Add adds the next price and returns the value of the indicator, false while it's warming up.
+	Add(price float64) (Value, bool)
+
+	// Clone returns a copy of the indicator with the same state.
+	Clone() Indicator
+}
+
+// Value is the value of an indicator, indicators with a single line only set Value.
+type Value struct {
+	// The value of SMA, EMA and RSI, the MACD line or the middle band of Bollinger.
+	Value float64 `json:"value"`
+
+	// The signal line of MACD.
+	Signal float64 `json:"signal,omitempty"`
+
+	// The histogram of MACD (MACD line - signal line)
+	Histogram float64 `json:"histogram,omitempty"`
+
+	// The upper band of Bollinger.
+	Upper float64 `json:"upper,omitempty"`
+
+	// The lower band of Bollinger.
+	Lower float64 `json:"lower,omitempty"`
+}
+
+// SMA is the simple moving average of the last period prices.
+type SMA struct {
+	period int
+	prices []float64
+	sum    float64
+}
+
+// NewSMA creates an SMA of the last period (e.g: 20) prices, a period lower than 1 is 1.
+func NewSMA(period int) *SMA {
+	return &SMA{period: max(period, 1)}
+}
+
+func (s *SMA) Name() string {
+	return fmt.Sprintf("sma(%d)", s.period)
+}
+
+func (s *SMA) Add(price float64) (Value, bool) {
+	s.prices = append(s.prices, price)
+	s.sum += price
+	if len(s.prices) > s.period {
+		s.sum -= s.prices[0]
+		s.prices = s.prices[1:]
+	}
+
+	if len(s.prices) < s.period {
+		return Value{}, false
+	}
+	return Value{Value: s.sum / float64(s.period)}, true
+}
+
+func (s *SMA) Clone() Indicator {
+	clone := *s
+	clone.prices = slices.Clone(s.prices)
+	return &clone
+}
+
+// EMA is the exponential moving average over period prices, it starts with the SMA of the first period prices.
+type EMA struct {
+	period int
+	count  int
+	sum    float64
+	value  float64
+}
+
+// NewEMA creates an EMA over period (e.g: 12) prices, a period lower than 1 is 1.
+func NewEMA(period int) *EMA {
+	return &EMA{period: max(period, 1)}
+}
+
+func (e *EMA) Name() string {
+	return fmt.Sprintf("ema(%d)", e.period)
+}
+
+func (e *EMA) Add(price float64) (Value, bool) {
+	value, ok := e.add(price)
+	return Value{Value: value}, ok
+}
+
+func (e *EMA) add(price float64) (float64, bool) {
+	e.count++
+	if e.count < e.period {
+		e.sum += price
+		return 0, false
+	}
+	if e.count == e.period {
+		e.value = (e.sum + price) / float64(e.period)
+		return e.value, true
+	}
+
+	alpha := 2 / float64(e.period+1)
+	e.value = alpha*price + (1-alpha)*e.value
+	return e.value, true
+}
+
+func (e *EMA) Clone() Indicator {
+	clone := *e
+	return &clone
+}
+
+// RSI is the relative strength index over period prices with the smoothing of Wilder, from 0 to 100.
+type RSI struct {
+	period  int
+	count   int
+	last    float64
+	avgGain float64
+	avgLoss float64
+}
+
+// NewRSI creates an RSI over period (e.g: 14) price changes, a period lower than 1 is 1.
+func NewRSI(period int) *RSI {
+	return &RSI{period: max(period, 1)}
+}
+
+func (r *RSI) Name() string {
+	return fmt.Sprintf("rsi(%d)", r.period)
+}
+
+func (r *RSI) Add(price float64) (Value, bool) {
+	r.count++
+	last := r.last
+	r.last = price
+	if r.count == 1 {
+		return Value{}, false
+	}
+
+	var (
+		change = price - last
+		gain   = max(change, 0)
+		loss   = max(-change, 0)
+		n      = float64(r.period)
+	)
+	// the first averages are the mean of the first period changes
+	if r.count <= r.period+1 {
+		r.avgGain += gain / n
+		r.avgLoss += loss / n
+	} else {
+		r.avgGain = (r.avgGain*(n-1) + gain) / n
+		r.avgLoss = (r.avgLoss*(n-1) + loss) / n
+	}
+
+	if r.count <= r.period {
+		return Value{}, false
+	}
+	if r.avgLoss == 0 {
+		return Value{Value: 100}, true
+	}
+	return Value{Value: 100 - 100/(1+r.avgGain/r.avgLoss)}, true
+}
+
+func (r *RSI) Clone() Indicator {
+	clone := *r
+	return &clone
+}
+
+// MACD is the difference between a fast and a slow EMA (the MACD line) and an EMA of that difference (the signal line)
+type MACD struct {
+	fast   *EMA
+	slow   *EMA
+	signal *EMA
+}
+
+// NewMACD creates a MACD with a fast (e.g: 12), slow (e.g: 26) and signal (e.g: 9) period.
+func NewMACD(fast int, slow int, signal int) *MACD {
+	return &MACD{fast: NewEMA(fast), slow: NewEMA(slow), signal: NewEMA(signal)}
+}
+
+func (m *MACD) Name() string {
+	return fmt.Sprintf("macd(%d,%d,%d)", m.fast.period, m.slow.period, m.signal.period)
+}
+
+func (m *MACD) Add(price float64) (Value, bool) {
+	fast, fastOk := m.fast.add(price)
+	slow, slowOk := m.slow.add(price)
+	if !fastOk || !slowOk {
+		return Value{}, false
+	}
+
+	line := fast - slow
+	signal, ok := m.signal.add(line)
+	if !ok {
+		return Value{}, false
+	}
+	return Value{Value: line, Signal: signal, Histogram: line - signal}, true
+}
+
+func (m *MACD) Clone() Indicator {
+	return &MACD{
+		fast:   m.fast.Clone().(*EMA),
+		slow:   m.slow.Clone().(*EMA),
+		signal: m.signal.Clone().(*EMA),
+	}
+}
+
+// Bollinger are the bands at a number of standard deviations above and below the SMA of the last period prices.
+type Bollinger struct {
+	sma *SMA
+	k   float64
+}
+
+// NewBollinger creates Bollinger bands of the last period (e.g: 20) prices at k (e.g: 2) standard deviations.
+func NewBollinger(period int, k float64) *Bollinger {
+	return &Bollinger{sma: NewSMA(period), k: k}
+}
+
+func (b *Bollinger) Name() string {
+	return fmt.Sprintf("bollinger(%d,%g)", b.sma.period, b.k)
+}
+
+func (b *Bollinger) Add(price float64) (Value, bool) {
+	middle, ok := b.sma.Add(price)
+	if !ok {
+		return Value{}, false
+	}
+
+	var variance float64
+	for _, price := range b.sma.prices {
+		variance += (price - middle.Value) * (price - middle.Value)
+	}
+	deviation := math.Sqrt(variance / float64(len(b.sma.prices)))
+
+	return Value{
+		Value: middle.Value,
+		Upper: middle.Value + b.k*deviation,
+		Lower: middle.Value - b.k*deviation,
+	}, true
+}
+
+func (b *Bollinger) Clone() Indicator {
+	return &Bollinger{sma: b.sma.Clone().(*SMA), k: b.k}
+}
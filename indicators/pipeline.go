@@ -0,0 +1,106 @@
+package indicators
+
+import (
+	"sync"
+
+	"github.com/larscom/go-bitvavo/v2/util"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+const defaultBuffSize = 50
+
+// Event is a candle event enriched with the values of the indicators.
+type Event struct {
+	ws.CandlesEvent
+
+	// The values by the name of the indicator (e.g: sma(20)), an indicator which is warming up is missing.
+	Values map[string]Value `json:"values"`
+}
+
+// Pipeline computes indicators on the close of the candles of every market and interval. It's safe for concurrent use.
+//
+// The websocket sends the candle of the current period on every trade, the close of a candle is only added
+// to the indicators once a newer candle has been received, so updates of the same candle don't distort them.
+type Pipeline struct {
+	indicators []Indicator
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	indicators []Indicator
+	timestamp  int64
+	close      float64
+	started    bool
+}
+
+// NewPipeline creates a pipeline which computes indicators, every market and interval gets its own copy of them.
+func NewPipeline(indicators ...Indicator) *Pipeline {
+	return &Pipeline{
+		indicators: indicators,
+		series:     make(map[string]*series),
+	}
+}
+
+// Run computes the indicators on every event of candlechn (e.g: a subscription or the candles of an aggregator)
+// and sends the enriched events to the returned channel, which is closed whenever candlechn is closed.
+// You can set the buffSize for this channel.
+//
+// Default buffSize: 50
+func (p *Pipeline) Run(candlechn <-chan ws.CandlesEvent, buffSize ...uint64) <-chan Event {
+	var (
+		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
+		outchn = make(chan Event, size)
+	)
+
+	go func() {
+		defer close(outchn)
+		for event := range candlechn {
+			if enriched, ok := p.Add(event); ok {
+				outchn <- enriched
+			}
+		}
+	}()
+
+	return outchn
+}
+
+// Add computes the indicators on event, false if event is older than the last candle of its market and interval.
+func (p *Pipeline) Add(event ws.CandlesEvent) (Event, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := event.Market + "_" + event.Interval.String()
+	s, found := p.series[key]
+	if !found {
+		s = &series{indicators: make([]Indicator, len(p.indicators))}
+		for i, indicator := range p.indicators {
+			s.indicators[i] = indicator.Clone()
+		}
+		p.series[key] = s
+	}
+
+	timestamp := event.Candle.Timestamp
+	if s.started && timestamp < s.timestamp {
+		return Event{}, false
+	}
+	// the previous candle is final
+	if s.started && timestamp > s.timestamp {
+		for _, indicator := range s.indicators {
+			indicator.Add(s.close)
+		}
+	}
+	s.started = true
+	s.timestamp = timestamp
+	s.close = event.Candle.Close
+
+	values := make(map[string]Value, len(s.indicators))
+	for _, indicator := range s.indicators {
+		if value, ok := indicator.Clone().Add(s.close); ok {
+			values[indicator.Name()] = value
+		}
+	}
+
+	return Event{CandlesEvent: event, Values: values}, true
+}
@@ -0,0 +1,139 @@
+// Package marketdata keeps the latest market data of the websocket in memory,
+// so request/response services can read it synchronously instead of consuming channels.
+package marketdata
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+// Cache subscribes to the ticker and ticker24h of markets and keeps the latest value of every market.
+// It's safe for concurrent use.
+type Cache struct {
+	client  ws.WsClient
+	markets []string
+
+	mu        sync.RWMutex
+	tickers   map[string]types.Ticker
+	tickers24 map[string]types.Ticker24h
+
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// New creates a cache for markets (e.g: ETH-EUR) which subscribes to the ticker and ticker24h channels of client.
+//
+// The getters return false for a market until its first event has been received.
+func New(client ws.WsClient, markets []string) (*Cache, error) {
+	cache := &Cache{
+		client:    client,
+		markets:   markets,
+		tickers:   make(map[string]types.Ticker),
+		tickers24: make(map[string]types.Ticker24h),
+	}
+
+	tickerchn, err := client.Ticker().Subscribe(markets)
+	if err != nil {
+		return nil, err
+	}
+
+	ticker24hchn, err := client.Ticker24h().Subscribe(markets)
+	if err != nil {
+		return nil, errors.Join(err, client.Ticker().Unsubscribe(markets))
+	}
+
+	cache.wg.Add(2)
+	go cache.consumeTickers(tickerchn)
+	go cache.consumeTickers24h(ticker24hchn)
+
+	return cache, nil
+}
+
+// LastPrice returns the price of the last trade of market.
+func (c *Cache) LastPrice(market string) (float64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ticker, found := c.tickers[market]
+	if !found || ticker.LastPriceStr == "" {
+		return 0, false
+	}
+	return ticker.LastPrice, true
+}
+
+// BestBidAsk returns the best (highest) bid and the best (lowest) ask of market.
+func (c *Cache) BestBidAsk(market string) (bid types.Page, ask types.Page, found bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ticker, found := c.tickers[market]
+	if !found || ticker.BestBidStr == "" || ticker.BestAskStr == "" {
+		return types.Page{}, types.Page{}, false
+	}
+	return types.Page{Price: ticker.BestBid, Size: ticker.BestBidSize}, types.Page{Price: ticker.BestAsk, Size: ticker.BestAskSize}, true
+}
+
+// Stats24h returns the ticker of market over the previous 24 hours.
+func (c *Cache) Stats24h(market string) (types.Ticker24h, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ticker24h, found := c.tickers24[market]
+	return ticker24h, found
+}
+
+// Close unsubscribes the markets and waits until the cache has stopped.
+func (c *Cache) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		err = errors.Join(
+			c.client.Ticker().Unsubscribe(c.markets),
+			c.client.Ticker24h().Unsubscribe(c.markets),
+		)
+		c.wg.Wait()
+	})
+	return err
+}
+
+func (c *Cache) consumeTickers(tickerchn <-chan ws.TickerEvent) {
+	defer c.wg.Done()
+
+	for event := range tickerchn {
+		c.mu.Lock()
+		c.tickers[event.Market] = mergeTicker(c.tickers[event.Market], event.Ticker)
+		c.mu.Unlock()
+	}
+}
+
+func (c *Cache) consumeTickers24h(ticker24hchn <-chan ws.Ticker24hEvent) {
+	defer c.wg.Done()
+
+	for event := range ticker24hchn {
+		c.mu.Lock()
+		c.tickers24[event.Market] = event.Ticker24h
+		c.mu.Unlock()
+	}
+}
+
+// mergeTicker applies the fields of update to ticker, a ticker event only contains the fields which have changed.
+func mergeTicker(ticker types.Ticker, update types.Ticker) types.Ticker {
+	if update.BestBidStr != "" {
+		ticker.BestBid, ticker.BestBidStr = update.BestBid, update.BestBidStr
+	}
+	if update.BestBidSizeStr != "" {
+		ticker.BestBidSize, ticker.BestBidSizeStr = update.BestBidSize, update.BestBidSizeStr
+	}
+	if update.BestAskStr != "" {
+		ticker.BestAsk, ticker.BestAskStr = update.BestAsk, update.BestAskStr
+	}
+	if update.BestAskSizeStr != "" {
+		ticker.BestAskSize, ticker.BestAskSizeStr = update.BestAskSize, update.BestAskSizeStr
+	}
+	if update.LastPriceStr != "" {
+		ticker.LastPrice, ticker.LastPriceStr = update.LastPrice, update.LastPriceStr
+	}
+	return ticker
+}
@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const telegramURL = "https://api.telegram.org"
+
+// Slack sends messages to an incoming webhook of Slack.
+type Slack struct {
+	config     *config
+	webhookURL string
+}
+
+var _ Notifier = (*Slack)(nil)
+
+// NewSlack creates a notifier which posts to webhookURL (e.g: https://hooks.slack.com/services/...)
+func NewSlack(webhookURL string, options ...Option) *Slack {
+	return &Slack{config: newConfig(options...), webhookURL: webhookURL}
+}
+
+func (s *Slack) Notify(ctx context.Context, message Message) error {
+	return postJSON(ctx, s.config.httpclient, s.webhookURL, map[string]string{
+		"text": fmt.Sprintf("%s *%s*\n%s", slackEmoji(message.Level), message.Title, message.Text),
+	})
+}
+
+func slackEmoji(level Level) string {
+	switch level {
+	case LevelError:
+		return ":red_circle:"
+	case LevelWarning:
+		return ":warning:"
+	default:
+		return ":information_source:"
+	}
+}
+
+// Telegram sends messages to a chat with a Telegram bot.
+type Telegram struct {
+	config *config
+	token  string
+	chatId string
+}
+
+var _ Notifier = (*Telegram)(nil)
+
+// NewTelegram creates a notifier which sends messages with the bot of token to the chat with chatId.
+func NewTelegram(token string, chatId string, options ...Option) *Telegram {
+	return &Telegram{config: newConfig(options...), token: token, chatId: chatId}
+}
+
+func (t *Telegram) Notify(ctx context.Context, message Message) error {
+	return postJSON(ctx, t.config.httpclient, fmt.Sprintf("%s/bot%s/sendMessage", telegramURL, url.PathEscape(t.token)), map[string]string{
+		"chat_id": t.chatId,
+		"text":    message.String(),
+	})
+}
@@ -0,0 +1,189 @@
+// Package notify sends human readable notifications of account events and websocket errors to chat services
+// (e.g: Slack or Telegram), so unattended bots can alert you.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+type Level string
+
+const (
+	LevelInfo    Level = "info"
+	LevelWarning Level = "warning"
+	LevelError   Level = "error"
+)
+
+type Message struct {
+	// The severity of the message.
+	Level Level
+
+	// A short summary (e.g: Order filled)
+	Title string
+
+	// The details of the message.
+	Text string
+
+	// The time of the event.
+	Time time.Time
+}
+
+// String returns the message as plain text.
+func (m Message) String() string {
+	return fmt.Sprintf("[%s] %s\n%s", strings.ToUpper(string(m.Level)), m.Title, m.Text)
+}
+
+// Notifier sends messages to a chat service.
+type Notifier interface {
+	// Notify sends message.
+	Notify(ctx context.Context, message Message) error
+}
+
+// NotifierFunc is a function which implements Notifier.
+type NotifierFunc func(ctx context.Context, message Message) error
+
+func (f NotifierFunc) Notify(ctx context.Context, message Message) error {
+	return f(ctx, message)
+}
+
+type config struct {
+	httpclient *http.Client
+}
+
+type Option func(*config)
+
+// The http.Client which sends the requests.
+// default: http.DefaultClient
+func WithHTTPClient(httpclient *http.Client) Option {
+	return func(c *config) {
+		c.httpclient = httpclient
+	}
+}
+
+func newConfig(options ...Option) *config {
+	config := &config{httpclient: http.DefaultClient}
+	for _, opt := range options {
+		opt(config)
+	}
+	return config
+}
+
+// Account sends a message for every fill and for every order which reached a final status (e.g: filled or canceled)
+// of the account stream, until both channels are closed or ctx is done.
+func Account(ctx context.Context, notifier Notifier, orderchn <-chan ws.OrderEvent, fillchn <-chan ws.FillEvent) {
+	for orderchn != nil || fillchn != nil {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-orderchn:
+			if !ok {
+				orderchn = nil
+				continue
+			}
+			if order := event.Order; order.Status.IsFinal() {
+				send(ctx, notifier, Message{
+					Level: LevelInfo,
+					Title: fmt.Sprintf("Order %s %s", event.Market, order.Status),
+					Text: fmt.Sprintf("%s %s order %s, filled %s of %s (%s quote)",
+						order.Side, order.OrderType, order.OrderId, order.FilledAmountStr, order.AmountStr, order.FilledAmountQuoteStr),
+					Time: order.UpdatedTime(),
+				})
+			}
+		case event, ok := <-fillchn:
+			if !ok {
+				fillchn = nil
+				continue
+			}
+			fill := event.Fill
+			send(ctx, notifier, Message{
+				Level: LevelInfo,
+				Title: fmt.Sprintf("Fill %s", event.Market),
+				Text: fmt.Sprintf("%s %s at %s, fee %s %s (order %s)",
+					fill.Side, fill.AmountStr, fill.PriceStr, fill.FeeStr, fill.FeeCurrency, fill.OrderId),
+				Time: fill.Time(),
+			})
+		}
+	}
+}
+
+// Errors sends a message for every error of errchn (see: ws.WithErrorChannel) until it's closed or ctx is done.
+func Errors(ctx context.Context, notifier Notifier, errchn <-chan error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-errchn:
+			if !ok {
+				return
+			}
+			send(ctx, notifier, Message{Level: LevelError, Title: "Websocket error", Text: err.Error(), Time: time.Now()})
+		}
+	}
+}
+
+// Reauth sends a message for every failed re-authentication and every recovery of reauthchn (see: ws.WithReauthChannel)
+// until it's closed or ctx is done.
+func Reauth(ctx context.Context, notifier Notifier, reauthchn <-chan ws.ReauthEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-reauthchn:
+			if !ok {
+				return
+			}
+			message := Message{Level: LevelInfo, Title: "Account re-authenticated", Time: time.Now()}
+			if !event.Authenticated {
+				message.Level = LevelError
+				message.Title = "Account authentication failed"
+			}
+			message.Text = fmt.Sprintf("attempt %d", event.Attempt)
+			if event.Err != nil {
+				message.Text = fmt.Sprintf("attempt %d: %s", event.Attempt, event.Err)
+			}
+			send(ctx, notifier, message)
+		}
+	}
+}
+
+func send(ctx context.Context, notifier Notifier, message Message) {
+	if err := notifier.Notify(ctx, message); err != nil {
+		log.Err(err).Str("title", message.Title).Msg("Failed to send notification")
+	}
+}
+
+// postJSON posts payload as JSON to url.
+func postJSON(ctx context.Context, httpclient *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := httpclient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		bytes, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("did not get OK response, code=%d, body=%s", response.StatusCode, string(bytes))
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package orderbook
+
+import (
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// Depth is the cumulative size of the bids and asks within a distance of the mid price.
+type Depth struct {
+	// The size of the bids in base currency.
+	Bids float64
+
+	// The size of the asks in base currency.
+	Asks float64
+
+	// The size of the bids in quote currency (size * price)
+	BidsQuote float64
+
+	// The size of the asks in quote currency (size * price)
+	AsksQuote float64
+}
+
+// Mid returns the price halfway the best bid and the best ask, false if either side is empty.
+func (b *Book) Mid() (float64, bool) {
+	bid, found := b.BestBid()
+	if !found {
+		return 0, false
+	}
+	ask, found := b.BestAsk()
+	if !found {
+		return 0, false
+	}
+	return (bid.Price + ask.Price) / 2, true
+}
+
+// Depth returns the cumulative size of the bids and asks with a price within percent (e.g: 0.01 for 1%) of the mid price.
+func (b *Book) Depth(percent float64) (Depth, bool) {
+	mid, found := b.Mid()
+	if !found {
+		return Depth{}, false
+	}
+
+	var depth Depth
+	for _, bid := range b.Bids() {
+		if bid.Price < mid*(1-percent) {
+			break
+		}
+		depth.Bids += bid.Size
+		depth.BidsQuote += bid.Size * bid.Price
+	}
+	for _, ask := range b.Asks() {
+		if ask.Price > mid*(1+percent) {
+			break
+		}
+		depth.Asks += ask.Size
+		depth.AsksQuote += ask.Size * ask.Price
+	}
+	return depth, true
+}
+
+// Imbalance returns (bids - asks) / (bids + asks) of the size of the best levels on each side (0 for every level),
+// from -1 (only asks) to 1 (only bids). It returns 0 if the book is empty.
+func (b *Book) Imbalance(levels int) float64 {
+	var (
+		bids = sumSize(b.Bids(), levels)
+		asks = sumSize(b.Asks(), levels)
+	)
+	if bids+asks == 0 {
+		return 0
+	}
+	return (bids - asks) / (bids + asks)
+}
+
+// VWAP returns the volume weighted average price of a market order with side for amount in base currency,
+// which takes the asks for a buy and the bids for a sell. It returns the amount which can be filled as well,
+// which is lower than amount if the book isn't deep enough.
+func (b *Book) VWAP(side types.Side, amount float64) (price float64, filled float64) {
+	pages := b.Asks()
+	if side == types.SideSell {
+		pages = b.Bids()
+	}
+
+	var quote float64
+	for _, page := range pages {
+		if filled >= amount {
+			break
+		}
+		size := min(page.Size, amount-filled)
+		filled += size
+		quote += size * page.Price
+	}
+
+	if filled == 0 {
+		return 0, 0
+	}
+	return quote / filled, filled
+}
+
+// PriceImpact returns how far the VWAP of a market order with side for amount moves away from the mid price,
+// relative to the mid price (e.g: 0.002 for 0.2%). It returns false if the book isn't deep enough to fill amount.
+func (b *Book) PriceImpact(side types.Side, amount float64) (float64, bool) {
+	mid, found := b.Mid()
+	if !found {
+		return 0, false
+	}
+
+	price, filled := b.VWAP(side, amount)
+	if filled < amount {
+		return 0, false
+	}
+
+	if side == types.SideSell {
+		return (mid - price) / mid, true
+	}
+	return (price - mid) / mid, true
+}
+
+// sumSize returns the total size of the first levels of pages, or of every page if levels is 0.
+func sumSize(pages []types.Page, levels int) float64 {
+	if levels > 0 && levels < len(pages) {
+		pages = pages[:levels]
+	}
+
+	var size float64
+	for _, page := range pages {
+		size += page.Size
+	}
+	return size
+}
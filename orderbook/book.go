@@ -0,0 +1,174 @@
+// Package orderbook maintains a local order book from a book snapshot and the book updates of the websocket.
+package orderbook
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+var (
+	ErrNotSynced = errors.New("book is not synced, apply a snapshot first")
+	ErrOutOfSync = errors.New("book is out of sync, missed one or more updates")
+)
+
+// Book is a local order book for a single market which is safe for concurrent use.
+type Book struct {
+	mu     sync.RWMutex
+	market string
+	nonce  int64
+	synced bool
+	bids   map[float64]float64
+	asks   map[float64]float64
+}
+
+// New creates an empty book for market (e.g: ETH-EUR), call Snapshot to sync the book.
+func New(market string) *Book {
+	return &Book{
+		market: market,
+		bids:   make(map[float64]float64),
+		asks:   make(map[float64]float64),
+	}
+}
+
+// Market returns the market of this book.
+func (b *Book) Market() string {
+	return b.market
+}
+
+// Nonce returns the nonce of the last applied snapshot or update.
+func (b *Book) Nonce() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.nonce
+}
+
+// Synced returns true if a snapshot has been applied and no updates were missed since.
+func (b *Book) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// Snapshot replaces the whole book with snapshot (e.g: from GetOrderBook)
+func (b *Book) Snapshot(snapshot types.Book) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bids = make(map[float64]float64, len(snapshot.Bids))
+	b.asks = make(map[float64]float64, len(snapshot.Asks))
+	applyPages(b.bids, snapshot.Bids)
+	applyPages(b.asks, snapshot.Asks)
+	b.nonce = snapshot.Nonce
+	b.synced = true
+}
+
+// Update applies a book update received from the websocket.
+//
+// Updates which are already part of the book are ignored.
+// It returns ErrNotSynced if no snapshot has been applied yet and ErrOutOfSync if an update has been missed,
+// in both cases a new snapshot must be applied.
+func (b *Book) Update(update types.Book) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.synced {
+		return ErrNotSynced
+	}
+	if update.Nonce <= b.nonce {
+		return nil
+	}
+	if update.Nonce != b.nonce+1 {
+		b.synced = false
+		return ErrOutOfSync
+	}
+
+	applyPages(b.bids, update.Bids)
+	applyPages(b.asks, update.Asks)
+	b.nonce = update.Nonce
+
+	return nil
+}
+
+// BestBid returns the highest bid, false if there are no bids.
+func (b *Book) BestBid() (types.Page, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var (
+		best  types.Page
+		found bool
+	)
+	for price, size := range b.bids {
+		if !found || price > best.Price {
+			best, found = types.Page{Price: price, Size: size}, true
+		}
+	}
+	return best, found
+}
+
+// BestAsk returns the lowest ask, false if there are no asks.
+func (b *Book) BestAsk() (types.Page, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var (
+		best  types.Page
+		found bool
+	)
+	for price, size := range b.asks {
+		if !found || price < best.Price {
+			best, found = types.Page{Price: price, Size: size}, true
+		}
+	}
+	return best, found
+}
+
+// Bids returns all bids sorted by price, highest first.
+func (b *Book) Bids() []types.Page {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	bids := toPages(b.bids)
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	return bids
+}
+
+// Asks returns all asks sorted by price, lowest first.
+func (b *Book) Asks() []types.Page {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	asks := toPages(b.asks)
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+	return asks
+}
+
+// Book returns a copy of the book with sorted bids and asks.
+func (b *Book) Book() types.Book {
+	return types.Book{
+		Nonce: b.Nonce(),
+		Bids:  b.Bids(),
+		Asks:  b.Asks(),
+	}
+}
+
+func applyPages(levels map[float64]float64, pages []types.Page) {
+	for _, page := range pages {
+		if page.Size == 0 {
+			delete(levels, page.Price)
+		} else {
+			levels[page.Price] = page.Size
+		}
+	}
+}
+
+func toPages(levels map[float64]float64) []types.Page {
+	pages := make([]types.Page, 0, len(levels))
+	for price, size := range levels {
+		pages = append(pages, types.Page{Price: price, Size: size})
+	}
+	return pages
+}
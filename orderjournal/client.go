@@ -0,0 +1,181 @@
+package orderjournal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// Report is the outcome of Reconcile.
+type Report struct {
+	// Orders which were submitted without an acknowledgement, but have been found on the exchange.
+	Recovered []types.Order
+
+	// Orders which were submitted without an acknowledgement and never reached the exchange,
+	// or accepted orders which the exchange doesn't know anymore.
+	Lost []State
+
+	// Orders which were open according to the journal, but have been closed in the meantime (e.g: filled)
+	Closed []types.Order
+
+	// Open orders on the exchange which aren't in the journal (e.g: placed by another application)
+	Unknown []types.Order
+}
+
+// Client journals every new order before it's submitted with the wrapped client and the response of the exchange
+// afterwards, orders without a clientOrderId get a random one. Every other method is passed through.
+type Client struct {
+	http.HttpClientAuth
+
+	journal *Journal
+}
+
+// NewClient creates a client which submits orders with client and journals them in journal.
+func NewClient(client http.HttpClientAuth, journal *Journal) *Client {
+	return &Client{HttpClientAuth: client, journal: journal}
+}
+
+func (c *Client) NewOrder(market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	return c.NewOrderWithContext(context.Background(), market, side, orderType, order)
+}
+
+func (c *Client) NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	order.Market = market
+	order.Side = side
+	order.OrderType = orderType
+	if order.ClientOrderId == "" {
+		order.ClientOrderId = uuid.NewString()
+	}
+
+	if err := c.journal.Append(Entry{Type: EntryTypeIntent, ClientOrderId: order.ClientOrderId, Order: &order}); err != nil {
+		return types.Order{}, fmt.Errorf("failed to journal order: %w", err)
+	}
+
+	placed, err := c.HttpClientAuth.NewOrderWithContext(ctx, market, side, orderType, order)
+	if err != nil {
+		// the outcome of an ambiguous failure stays pending, so Reconcile can find out what happened
+		if !types.IsAmbiguous(err) {
+			if journalErr := c.journal.Append(Entry{Type: EntryTypeReject, ClientOrderId: order.ClientOrderId, Error: err.Error()}); journalErr != nil {
+				return types.Order{}, fmt.Errorf("%w (failed to journal reject: %w)", err, journalErr)
+			}
+		}
+		return types.Order{}, err
+	}
+
+	ack := Entry{Type: EntryTypeAck, ClientOrderId: order.ClientOrderId, OrderId: placed.OrderId, Status: placed.Status}
+	if err := c.journal.Append(ack); err != nil {
+		return placed, fmt.Errorf("order %s has been placed, but failed to journal it: %w", placed.OrderId, err)
+	}
+	return placed, nil
+}
+
+func (c *Client) NewOrders(orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error) {
+	return c.NewOrdersWithContext(context.Background(), orders, failFast...)
+}
+
+func (c *Client) NewOrdersWithContext(ctx context.Context, orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error) {
+	return http.NewOrdersWith(ctx, orders, len(failFast) > 0 && failFast[0], func(ctx context.Context, order types.OrderNew) (types.Order, error) {
+		return c.NewOrderWithContext(ctx, order.Market, order.Side, order.OrderType, order)
+	})
+}
+
+func (c *Client) ReplaceOrder(market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	return c.ReplaceOrderWithContext(context.Background(), market, orderId, order, reduceByFilled...)
+}
+
+func (c *Client) ReplaceOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	return http.ReplaceOrderWith(ctx, c, market, orderId, order, len(reduceByFilled) > 0 && reduceByFilled[0])
+}
+
+// Reconcile compares the journal with the orders on the exchange using client, call it on startup before placing new orders.
+//
+// Pending orders are looked up by their clientOrderId, open orders which aren't open on the exchange anymore are fetched,
+// every finding is written to the journal. Open orders on the exchange which aren't in the journal are reported as unknown.
+func (j *Journal) Reconcile(ctx context.Context, client http.HttpClientAuth) (Report, error) {
+	var report Report
+
+	for _, state := range j.States() {
+		if !state.Pending() {
+			continue
+		}
+
+		order, err := client.GetOrderByClientIdWithContext(ctx, state.Order.Market, state.Order.ClientOrderId)
+		if types.IsOrderNotFound(err) {
+			state.Failed = true
+			state.Error = "order never reached the exchange"
+			if err := j.Append(Entry{Type: EntryTypeLost, ClientOrderId: state.Order.ClientOrderId, Error: state.Error}); err != nil {
+				return report, err
+			}
+			report.Lost = append(report.Lost, state)
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("failed to get order %s: %w", state.Order.ClientOrderId, err)
+		}
+
+		if err := j.Append(Entry{Type: EntryTypeAck, ClientOrderId: state.Order.ClientOrderId, OrderId: order.OrderId, Status: order.Status}); err != nil {
+			return report, err
+		}
+		report.Recovered = append(report.Recovered, order)
+	}
+
+	orders, err := client.GetOrdersOpenWithContext(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to get open orders: %w", err)
+	}
+
+	var (
+		open  = make(map[string]types.Order, len(orders))
+		known = make(map[string]bool)
+	)
+	for _, order := range orders {
+		open[order.OrderId] = order
+	}
+
+	for _, state := range j.States() {
+		if state.OrderId == "" {
+			continue
+		}
+		known[state.OrderId] = true
+		if !state.Open() {
+			continue
+		}
+
+		status := state.Status
+		order, found := open[state.OrderId]
+		if !found {
+			order, err = client.GetOrderWithContext(ctx, state.Order.Market, state.OrderId)
+			if types.IsOrderNotFound(err) {
+				state.Failed = true
+				state.Error = "order not found on the exchange"
+				if err := j.Append(Entry{Type: EntryTypeLost, ClientOrderId: state.Order.ClientOrderId, Error: state.Error}); err != nil {
+					return report, err
+				}
+				report.Lost = append(report.Lost, state)
+				continue
+			}
+			if err != nil {
+				return report, fmt.Errorf("failed to get order %s: %w", state.OrderId, err)
+			}
+			report.Closed = append(report.Closed, order)
+		}
+
+		if order.Status != status {
+			entry := Entry{Type: EntryTypeStatus, ClientOrderId: state.Order.ClientOrderId, OrderId: order.OrderId, Status: order.Status}
+			if err := j.Append(entry); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	for _, order := range orders {
+		if !known[order.OrderId] {
+			report.Unknown = append(report.Unknown, order)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,204 @@
+// Package orderjournal writes every order intent and the acknowledgement of the exchange to an append-only file
+// before and after the order is submitted, so lost or unknown orders can be detected after a crash or restart.
+package orderjournal
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+type EntryType string
+
+const (
+	// The order is about to be submitted.
+	EntryTypeIntent EntryType = "intent"
+
+	// The exchange accepted the order.
+	EntryTypeAck EntryType = "ack"
+
+	// The exchange rejected the order.
+	EntryTypeReject EntryType = "reject"
+
+	// The order never reached the exchange (detected by Reconcile)
+	EntryTypeLost EntryType = "lost"
+
+	// The status of an accepted order changed (detected by Reconcile)
+	EntryTypeStatus EntryType = "status"
+)
+
+// Entry is a single line of the journal.
+type Entry struct {
+	Type EntryType `json:"type"`
+
+	// The time the entry was written.
+	Time time.Time `json:"time"`
+
+	// The key of the order in the journal.
+	ClientOrderId string `json:"clientOrderId"`
+
+	// The order which is submitted, only set for EntryTypeIntent.
+	Order *types.OrderNew `json:"order,omitempty"`
+
+	// The id of the order, set once the exchange accepted it.
+	OrderId string `json:"orderId,omitempty"`
+
+	// The status of the order, set once the exchange accepted it.
+	Status types.OrderStatus `json:"status,omitempty"`
+
+	// The reason why the order was rejected or couldn't be submitted.
+	Error string `json:"error,omitempty"`
+}
+
+// State is the last known state of an order in the journal.
+type State struct {
+	// The order which has been submitted.
+	Order types.OrderNew
+
+	// The time the order was submitted.
+	SubmittedAt time.Time
+
+	// The id of the order, empty if the exchange hasn't acknowledged it.
+	OrderId string
+
+	// The last known status of the order, empty if the exchange hasn't acknowledged it.
+	Status types.OrderStatus
+
+	// The reason why the order was rejected or couldn't be submitted.
+	Error string
+
+	// Whether the order has been rejected by the exchange or never reached it.
+	Failed bool
+}
+
+// Pending returns true if it's unknown whether the order reached the exchange.
+func (s State) Pending() bool {
+	return s.OrderId == "" && !s.Failed
+}
+
+// Open returns true if the order has been accepted and hasn't reached a final status.
+func (s State) Open() bool {
+	return s.OrderId != "" && !s.Status.IsFinal()
+}
+
+// Journal is an append-only file of order entries. It's safe for concurrent use.
+type Journal struct {
+	mu     sync.RWMutex
+	file   *os.File
+	states map[string]*State
+	order  []string
+}
+
+// Open opens the journal at path and replays its entries, the file is created if it doesn't exist.
+func Open(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	journal := &Journal{file: file, states: make(map[string]*State)}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// a line which is half written by a crash is skipped
+			continue
+		}
+		journal.apply(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+
+	return journal, nil
+}
+
+// Append writes entry to the journal and syncs it to disk.
+func (j *Journal) Append(entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Write(append(bytes, '\n')); err != nil {
+		return err
+	}
+	if err := j.file.Sync(); err != nil {
+		return err
+	}
+	j.apply(entry)
+	return nil
+}
+
+// State returns the last known state of the order with clientOrderId, false if it isn't in the journal.
+func (j *Journal) State(clientOrderId string) (State, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	state, found := j.states[clientOrderId]
+	if !found {
+		return State{}, false
+	}
+	return *state, true
+}
+
+// States returns the last known state of every order in the journal, in the order they were submitted.
+func (j *Journal) States() []State {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	states := make([]State, 0, len(j.order))
+	for _, clientOrderId := range j.order {
+		states = append(states, *j.states[clientOrderId])
+	}
+	return states
+}
+
+// Close closes the file of the journal.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.file.Close()
+}
+
+func (j *Journal) apply(entry Entry) {
+	state, found := j.states[entry.ClientOrderId]
+	if !found {
+		if entry.Type != EntryTypeIntent || entry.Order == nil {
+			return
+		}
+		state = &State{}
+		j.states[entry.ClientOrderId] = state
+		j.order = append(j.order, entry.ClientOrderId)
+	}
+
+	switch entry.Type {
+	case EntryTypeIntent:
+		if entry.Order != nil {
+			state.Order = *entry.Order
+		}
+		state.SubmittedAt = entry.Time
+	case EntryTypeAck, EntryTypeStatus:
+		state.OrderId = entry.OrderId
+		state.Status = entry.Status
+		state.Failed = false
+	case EntryTypeReject, EntryTypeLost:
+		state.Error = entry.Error
+		state.Failed = true
+	}
+}
@@ -0,0 +1,351 @@
+// Package papertrade provides a simulated http.HttpClientAuth which never places real orders.
+//
+// Orders are matched against the live order book of the public endpoints (best bid and best ask)
+// and the balances and fills are kept in memory, so a strategy can be dry-run before trading with real money.
+package papertrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+const (
+	defaultTakerFee = 0.0025
+	defaultMakerFee = 0.0015
+)
+
+// ErrNotSupported is returned by the methods which can't be simulated (e.g: deposits and withdrawals)
+var ErrNotSupported = errors.New("not supported by the paper trading client")
+
+var (
+	errInsufficientBalance = func() error {
+		return &types.BitvavoErr{Code: types.ErrorCodeInsufficientBalance, Message: "You do not have sufficient balance to complete this operation."}
+	}
+	errOrderNotFound = func() error {
+		return &types.BitvavoErr{Code: types.ErrorCodeOrderNotFound, Message: "No order found. Please be aware that simultaneously updating the same order may return this error."}
+	}
+	errInvalidOrder = func(message string) error {
+		return &types.BitvavoErr{Code: types.ErrorCodeInvalidParameter, Message: message}
+	}
+)
+
+type Client struct {
+	mu     sync.Mutex
+	public http.HttpClient
+
+	takerFee float64
+	makerFee float64
+
+	balances map[string]*types.Balance
+	orders   []*types.Order
+	fills    map[string][]types.Fill
+}
+
+var _ http.HttpClientAuth = (*Client)(nil)
+
+type Option func(*Client)
+
+// The taker and maker fee (e.g: 0.0025 for 0.25%) which is paid in quote currency for every fill.
+// default: taker 0.0025, maker 0.0015
+func WithFees(taker float64, maker float64) Option {
+	return func(c *Client) {
+		c.takerFee = taker
+		c.makerFee = maker
+	}
+}
+
+// NewClient creates a paper trading client which starts with balances (e.g: {"EUR": 1000})
+// and uses public to get the order book of a market.
+func NewClient(public http.HttpClient, balances map[string]float64, options ...Option) *Client {
+	client := &Client{
+		public:   public,
+		takerFee: defaultTakerFee,
+		makerFee: defaultMakerFee,
+		balances: make(map[string]*types.Balance),
+		fills:    make(map[string][]types.Fill),
+	}
+	for symbol, available := range balances {
+		client.balances[symbol] = &types.Balance{Symbol: symbol, Available: available}
+	}
+	for _, opt := range options {
+		opt(client)
+	}
+
+	return client
+}
+
+// Run matches the open orders every interval until ctx is done.
+func (c *Client) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := c.Match(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Match matches the open orders of every market against the current order book.
+func (c *Client) Match(ctx context.Context) error {
+	for _, market := range c.openMarkets() {
+		book, err := c.public.GetTickerBookWithContext(ctx, market)
+		if err != nil {
+			return err
+		}
+
+		c.mu.Lock()
+		for _, order := range c.orders {
+			if order.Market == market && isOpen(order) {
+				c.match(order, book, false)
+			}
+		}
+		c.mu.Unlock()
+	}
+
+	return nil
+}
+
+func (c *Client) ToDecimalClient() http.HttpClientAuthDec {
+	return decimalClient{}
+}
+
+func (c *Client) GetBalance(symbol ...string) ([]types.Balance, error) {
+	return c.GetBalanceWithContext(context.Background(), symbol...)
+}
+
+func (c *Client) GetBalanceWithContext(ctx context.Context, symbol ...string) ([]types.Balance, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	balances := make([]types.Balance, 0, len(c.balances))
+	for _, balance := range c.balances {
+		if len(symbol) > 0 && balance.Symbol != symbol[0] {
+			continue
+		}
+		balances = append(balances, *balance)
+	}
+	slices.SortFunc(balances, func(a, b types.Balance) int { return strings.Compare(a.Symbol, b.Symbol) })
+
+	return balances, nil
+}
+
+func (c *Client) GetPortfolioValue(quote string) (types.Portfolio, error) {
+	return c.GetPortfolioValueWithContext(context.Background(), quote)
+}
+
+func (c *Client) GetPortfolioValueWithContext(ctx context.Context, quote string) (types.Portfolio, error) {
+	balances, err := c.GetBalanceWithContext(ctx)
+	if err != nil {
+		return types.Portfolio{}, err
+	}
+
+	prices, err := c.public.GetTickerPricesWithContext(ctx)
+	if err != nil {
+		return types.Portfolio{}, err
+	}
+
+	return types.NewPortfolio(quote, balances, prices), nil
+}
+
+func (c *Client) GetAccount() (types.Account, error) {
+	return c.GetAccountWithContext(context.Background())
+}
+
+func (c *Client) GetAccountWithContext(ctx context.Context) (types.Account, error) {
+	return types.Account{
+		Fees: types.Fee{
+			Taker: c.takerFee,
+			Maker: c.makerFee,
+		},
+	}, nil
+}
+
+func (c *Client) GetFees(market string) (types.MarketFee, error) {
+	return c.GetFeesWithContext(context.Background(), market)
+}
+
+func (c *Client) GetFeesWithContext(ctx context.Context, market string) (types.MarketFee, error) {
+	account, err := c.GetAccountWithContext(ctx)
+	if err != nil {
+		return types.MarketFee{}, err
+	}
+	return types.MarketFee{Fee: account.Fees}, nil
+}
+
+func (c *Client) EstimateFee(market string, side types.Side, amount float64, price float64, taker bool) (types.FeeEstimate, error) {
+	return c.EstimateFeeWithContext(context.Background(), market, side, amount, price, taker)
+}
+
+func (c *Client) EstimateFeeWithContext(ctx context.Context, market string, side types.Side, amount float64, price float64, taker bool) (types.FeeEstimate, error) {
+	fees, err := c.GetFeesWithContext(ctx, market)
+	if err != nil {
+		return types.FeeEstimate{}, err
+	}
+	return fees.Estimate(side, amount, price, taker), nil
+}
+
+func (c *Client) GetTrades(market string, params ...http.OptionalParams) ([]types.TradeHistoric, error) {
+	return c.GetTradesWithContext(context.Background(), market, params...)
+}
+
+func (c *Client) GetTradesWithContext(ctx context.Context, market string, params ...http.OptionalParams) ([]types.TradeHistoric, error) {
+	trades, err := c.GetAllTradesWithContext(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+	return limit(trades, params...), nil
+}
+
+func (c *Client) GetTradesSeq(market string, params ...http.OptionalParams) iter.Seq2[types.TradeHistoric, error] {
+	return c.GetTradesSeqWithContext(context.Background(), market, params...)
+}
+
+func (c *Client) GetTradesSeqWithContext(ctx context.Context, market string, params ...http.OptionalParams) iter.Seq2[types.TradeHistoric, error] {
+	return seq(c.GetTradesWithContext(ctx, market, params...))
+}
+
+func (c *Client) GetAllTrades(market string) ([]types.TradeHistoric, error) {
+	return c.GetAllTradesWithContext(context.Background(), market)
+}
+
+func (c *Client) GetAllTradesWithContext(ctx context.Context, market string) ([]types.TradeHistoric, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fills := c.fills[market]
+	trades := make([]types.TradeHistoric, len(fills))
+	for i, fill := range fills {
+		// newest first
+		trades[len(fills)-1-i] = types.TradeHistoric(fill)
+	}
+
+	return trades, nil
+}
+
+func (c *Client) GetDepositAsset(symbol string) (types.DepositAsset, error) {
+	return c.GetDepositAssetWithContext(context.Background(), symbol)
+}
+
+func (c *Client) GetDepositAssetWithContext(ctx context.Context, symbol string) (types.DepositAsset, error) {
+	return types.DepositAsset{}, ErrNotSupported
+}
+
+func (c *Client) GetDepositFiat(symbol string) (types.DepositFiat, error) {
+	return c.GetDepositFiatWithContext(context.Background(), symbol)
+}
+
+func (c *Client) GetDepositFiatWithContext(ctx context.Context, symbol string) (types.DepositFiat, error) {
+	return types.DepositFiat{}, ErrNotSupported
+}
+
+func (c *Client) GetDepositHistory(params ...http.OptionalParams) ([]types.DepositHistory, error) {
+	return c.GetDepositHistoryWithContext(context.Background(), params...)
+}
+
+func (c *Client) GetDepositHistoryWithContext(ctx context.Context, params ...http.OptionalParams) ([]types.DepositHistory, error) {
+	return nil, ErrNotSupported
+}
+
+func (c *Client) GetWithdrawalHistory(params ...http.OptionalParams) ([]types.WithdrawalHistory, error) {
+	return c.GetWithdrawalHistoryWithContext(context.Background(), params...)
+}
+
+func (c *Client) GetWithdrawalHistoryWithContext(ctx context.Context, params ...http.OptionalParams) ([]types.WithdrawalHistory, error) {
+	return nil, ErrNotSupported
+}
+
+func (c *Client) GetTransactionHistory(params ...http.OptionalParams) (types.TransactionHistory, error) {
+	return c.GetTransactionHistoryWithContext(context.Background(), params...)
+}
+
+func (c *Client) GetTransactionHistoryWithContext(ctx context.Context, params ...http.OptionalParams) (types.TransactionHistory, error) {
+	return types.TransactionHistory{}, ErrNotSupported
+}
+
+func (c *Client) Withdraw(symbol string, amount float64, address string, withdrawal types.Withdrawal) (types.WithDrawalResponse, error) {
+	return c.WithdrawWithContext(context.Background(), symbol, amount, address, withdrawal)
+}
+
+func (c *Client) WithdrawWithContext(ctx context.Context, symbol string, amount float64, address string, withdrawal types.Withdrawal) (types.WithDrawalResponse, error) {
+	return types.WithDrawalResponse{}, ErrNotSupported
+}
+
+// DoRaw isn't supported, since a request to an arbitrary endpoint can't be simulated.
+func (c *Client) DoRaw(ctx context.Context, method string, path string, params url.Values, payload []byte) ([]byte, error) {
+	return nil, ErrNotSupported
+}
+
+// openMarkets returns the markets which have open orders.
+func (c *Client) openMarkets() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	markets := make([]string, 0)
+	for _, order := range c.orders {
+		if isOpen(order) && !slices.Contains(markets, order.Market) {
+			markets = append(markets, order.Market)
+		}
+	}
+	return markets
+}
+
+// balance returns the balance of symbol, c.mu must be held.
+func (c *Client) balance(symbol string) *types.Balance {
+	balance, found := c.balances[symbol]
+	if !found {
+		balance = &types.Balance{Symbol: symbol}
+		c.balances[symbol] = balance
+	}
+	return balance
+}
+
+// limit returns the first items according to the limit param, if any.
+func limit[T any](items []T, params ...http.OptionalParams) []T {
+	if len(params) == 0 {
+		return items
+	}
+
+	var n int
+	if _, err := fmt.Sscan(params[0].Params().Get("limit"), &n); err != nil || n <= 0 || n >= len(items) {
+		return items
+	}
+	return items[:n]
+}
+
+// seq returns an iterator over items, or over the error only if err isn't nil.
+func seq[T any](items []T, err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if err != nil {
+			var empty T
+			yield(empty, err)
+			return
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// splitMarket returns the base and quote currency of market (e.g: ETH-EUR)
+func splitMarket(market string) (string, string) {
+	base, quote, _ := strings.Cut(market, "-")
+	return base, quote
+}
@@ -0,0 +1,68 @@
+package papertrade
+
+import (
+	"context"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/typesdec"
+)
+
+// decimalClient is returned by ToDecimalClient, decimals aren't supported by the paper trading client.
+type decimalClient struct{}
+
+func (decimalClient) GetBalance(symbol ...string) ([]typesdec.Balance, error) {
+	return nil, ErrNotSupported
+}
+
+func (decimalClient) GetBalanceWithContext(ctx context.Context, symbol ...string) ([]typesdec.Balance, error) {
+	return nil, ErrNotSupported
+}
+
+func (decimalClient) GetTrades(market string, opt ...http.OptionalParams) ([]typesdec.TradeHistoric, error) {
+	return nil, ErrNotSupported
+}
+
+func (decimalClient) GetTradesWithContext(ctx context.Context, market string, opt ...http.OptionalParams) ([]typesdec.TradeHistoric, error) {
+	return nil, ErrNotSupported
+}
+
+func (decimalClient) GetOrders(market string, opt ...http.OptionalParams) ([]typesdec.Order, error) {
+	return nil, ErrNotSupported
+}
+
+func (decimalClient) GetOrdersWithContext(ctx context.Context, market string, opt ...http.OptionalParams) ([]typesdec.Order, error) {
+	return nil, ErrNotSupported
+}
+
+func (decimalClient) GetOrdersOpen(market ...string) ([]typesdec.Order, error) {
+	return nil, ErrNotSupported
+}
+
+func (decimalClient) GetOrdersOpenWithContext(ctx context.Context, market ...string) ([]typesdec.Order, error) {
+	return nil, ErrNotSupported
+}
+
+func (decimalClient) GetOrder(market string, orderId string) (typesdec.Order, error) {
+	return typesdec.Order{}, ErrNotSupported
+}
+
+func (decimalClient) GetOrderWithContext(ctx context.Context, market string, orderId string) (typesdec.Order, error) {
+	return typesdec.Order{}, ErrNotSupported
+}
+
+func (decimalClient) NewOrder(market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error) {
+	return typesdec.Order{}, ErrNotSupported
+}
+
+func (decimalClient) NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error) {
+	return typesdec.Order{}, ErrNotSupported
+}
+
+func (decimalClient) UpdateOrder(market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error) {
+	return typesdec.Order{}, ErrNotSupported
+}
+
+func (decimalClient) UpdateOrderWithContext(ctx context.Context, market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error) {
+	return typesdec.Order{}, ErrNotSupported
+}
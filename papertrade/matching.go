@@ -0,0 +1,198 @@
+package papertrade
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// match executes order against the best bid and ask of book, c.mu must be held.
+//
+// The liquidity at the best bid and ask is assumed to be unlimited, so an order which executes is filled entirely.
+// Whenever placing is true, the order is new (or updated) and takes liquidity, otherwise it's a resting order
+// which fills as maker at its own price.
+func (c *Client) match(order *types.Order, book types.TickerBook, placing bool) {
+	if order.Status == types.StatusAwaitingTrigger {
+		if !isTriggered(order, book) {
+			return
+		}
+		order.Status = types.StatusNew
+		order.TriggerPrice = order.TriggerAmount
+		order.Updated = time.Now().UnixMilli()
+		placing = true
+	}
+
+	price := marketPrice(order.Side, book)
+	if !isLimitOrder(order.OrderType) {
+		if price > 0 {
+			c.fill(order, price, true)
+		}
+		return
+	}
+
+	crosses := price > 0 && (order.Side == types.SideBuy && order.Price >= price || order.Side == types.SideSell && order.Price <= price)
+	switch {
+	case crosses && placing && order.PostOnly:
+		c.cancel(order, types.StatusCanceledPostOnly)
+	case crosses && placing:
+		c.fill(order, price, true)
+	case crosses:
+		c.fill(order, order.Price, false)
+	case placing && order.TimeInForce == types.TimeInForceIOC:
+		c.cancel(order, types.StatusCanceledIOC)
+	case placing && order.TimeInForce == types.TimeInForceFOK:
+		c.cancel(order, types.StatusCanceledFOK)
+	}
+}
+
+// fill fills the remaining amount of order at price and settles the balances, c.mu must be held.
+func (c *Client) fill(order *types.Order, price float64, taker bool) {
+	var (
+		base, quote = splitMarket(order.Market)
+		amount      = order.AmountRemaining
+		amountQuote = amount * price
+		feeRate     = c.makerFee
+	)
+	if taker {
+		feeRate = c.takerFee
+	}
+	fee := amountQuote * feeRate
+
+	c.release(order)
+	if order.Side == types.SideBuy {
+		c.balance(quote).Available -= amountQuote + fee
+		c.balance(base).Available += amount
+	} else {
+		c.balance(base).Available -= amount
+		c.balance(quote).Available += amountQuote - fee
+	}
+
+	now := time.Now().UnixMilli()
+	fill := types.Fill{
+		FillId:      uuid.NewString(),
+		OrderId:     order.OrderId,
+		Timestamp:   now,
+		Amount:      amount,
+		Side:        order.Side,
+		Price:       price,
+		Taker:       taker,
+		Fee:         fee,
+		FeeCurrency: quote,
+		Settled:     true,
+	}
+	c.fills[order.Market] = append(c.fills[order.Market], fill)
+
+	order.Fills = append(order.Fills, fill)
+	order.AmountRemaining = 0
+	order.FilledAmount += amount
+	order.FilledAmountQuote += amountQuote
+	order.FeePaid += fee
+	order.FeeCurrency = quote
+	order.Status = types.StatusFilled
+	order.Updated = now
+}
+
+// hold places the funds which are needed for order on hold, c.mu must be held.
+func (c *Client) hold(order *types.Order, book types.TickerBook) error {
+	base, quote := splitMarket(order.Market)
+
+	currency, amount := base, order.AmountRemaining
+	if order.Side == types.SideBuy {
+		price := order.Price
+		switch order.OrderType {
+		case types.OrderTypeMarket:
+			price = marketPrice(order.Side, book)
+		case types.OrderTypeStopLoss, types.OrderTypeTakeProfit:
+			price = order.TriggerAmount
+		}
+		currency, amount = quote, order.AmountRemaining*price*(1+c.takerFee)
+	}
+
+	balance := c.balance(currency)
+	if balance.Available < amount {
+		return errInsufficientBalance()
+	}
+	balance.Available -= amount
+	balance.InOrder += amount
+
+	order.OnHold = amount
+	order.OnHoldCurrency = currency
+
+	return nil
+}
+
+// release returns the funds on hold for order, c.mu must be held.
+func (c *Client) release(order *types.Order) {
+	if order.OnHold == 0 {
+		return
+	}
+
+	balance := c.balance(order.OnHoldCurrency)
+	balance.Available += order.OnHold
+	balance.InOrder -= order.OnHold
+
+	order.OnHold = 0
+}
+
+// cancel cancels order with status, c.mu must be held.
+func (c *Client) cancel(order *types.Order, status types.OrderStatus) {
+	c.release(order)
+	order.Status = status
+	order.Updated = time.Now().UnixMilli()
+}
+
+// isTriggered returns true if the trigger amount of the stop order has been reached.
+func isTriggered(order *types.Order, book types.TickerBook) bool {
+	price := marketPrice(order.Side, book)
+	if price == 0 {
+		return false
+	}
+
+	// a stop loss protects against the price moving against you, a take profit locks in the price moving your way
+	var (
+		buy        = order.Side == types.SideBuy
+		stopLoss   = order.OrderType == types.OrderTypeStopLoss || order.OrderType == types.OrderTypeStopLossLimit
+		priceAbove = price >= order.TriggerAmount
+		priceBelow = price <= order.TriggerAmount
+	)
+	if stopLoss {
+		return buy && priceAbove || !buy && priceBelow
+	}
+	return buy && priceBelow || !buy && priceAbove
+}
+
+// marketPrice returns the price for which side executes immediately, 0 if there is no liquidity.
+func marketPrice(side types.Side, book types.TickerBook) float64 {
+	if side == types.SideBuy {
+		return book.Ask
+	}
+	return book.Bid
+}
+
+func isOpen(order *types.Order) bool {
+	switch order.Status {
+	case types.StatusNew, types.StatusAwaitingTrigger, types.StatusPartiallyFilled:
+		return true
+	default:
+		return false
+	}
+}
+
+func isStopOrder(orderType types.OrderType) bool {
+	switch orderType {
+	case types.OrderTypeStopLoss, types.OrderTypeStopLossLimit, types.OrderTypeTakeProfit, types.OrderTypeTakeProfitLimit:
+		return true
+	default:
+		return false
+	}
+}
+
+func isLimitOrder(orderType types.OrderType) bool {
+	switch orderType {
+	case types.OrderTypeLimit, types.OrderTypeStopLossLimit, types.OrderTypeTakeProfitLimit:
+		return true
+	default:
+		return false
+	}
+}
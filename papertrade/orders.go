@@ -0,0 +1,316 @@
+package papertrade
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/util"
+)
+
+func (c *Client) GetOrders(market string, params ...http.OptionalParams) ([]types.Order, error) {
+	return c.GetOrdersWithContext(context.Background(), market, params...)
+}
+
+func (c *Client) GetOrdersWithContext(ctx context.Context, market string, params ...http.OptionalParams) ([]types.Order, error) {
+	orders, err := c.GetAllOrdersWithContext(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+	return limit(orders, params...), nil
+}
+
+func (c *Client) GetOrdersSeq(market string, params ...http.OptionalParams) iter.Seq2[types.Order, error] {
+	return c.GetOrdersSeqWithContext(context.Background(), market, params...)
+}
+
+func (c *Client) GetOrdersSeqWithContext(ctx context.Context, market string, params ...http.OptionalParams) iter.Seq2[types.Order, error] {
+	return seq(c.GetOrdersWithContext(ctx, market, params...))
+}
+
+func (c *Client) GetAllOrders(market string) ([]types.Order, error) {
+	return c.GetAllOrdersWithContext(context.Background(), market)
+}
+
+func (c *Client) GetAllOrdersWithContext(ctx context.Context, market string) ([]types.Order, error) {
+	return c.findOrders(func(order *types.Order) bool { return order.Market == market }), nil
+}
+
+func (c *Client) GetOrdersOpen(market ...string) ([]types.Order, error) {
+	return c.GetOrdersOpenWithContext(context.Background(), market...)
+}
+
+func (c *Client) GetOrdersOpenWithContext(ctx context.Context, market ...string) ([]types.Order, error) {
+	return c.findOrders(func(order *types.Order) bool {
+		return isOpen(order) && (len(market) == 0 || order.Market == market[0])
+	}), nil
+}
+
+func (c *Client) GetOrder(market string, orderId string) (types.Order, error) {
+	return c.GetOrderWithContext(context.Background(), market, orderId)
+}
+
+func (c *Client) GetOrderWithContext(ctx context.Context, market string, orderId string) (types.Order, error) {
+	return c.findOrder(func(order *types.Order) bool { return order.Market == market && order.OrderId == orderId })
+}
+
+func (c *Client) GetOrderByClientId(market string, clientOrderId string) (types.Order, error) {
+	return c.GetOrderByClientIdWithContext(context.Background(), market, clientOrderId)
+}
+
+func (c *Client) GetOrderByClientIdWithContext(ctx context.Context, market string, clientOrderId string) (types.Order, error) {
+	return c.findOrder(func(order *types.Order) bool {
+		return order.Market == market && order.ClientOrderId == clientOrderId
+	})
+}
+
+func (c *Client) CancelOrders(market ...string) ([]string, error) {
+	return c.CancelOrdersWithContext(context.Background(), market...)
+}
+
+func (c *Client) CancelOrdersWithContext(ctx context.Context, market ...string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	orderIds := make([]string, 0)
+	for _, order := range c.orders {
+		if isOpen(order) && (len(market) == 0 || order.Market == market[0]) {
+			c.cancel(order, types.StatusCanceled)
+			orderIds = append(orderIds, order.OrderId)
+		}
+	}
+
+	return orderIds, nil
+}
+
+func (c *Client) CancelMarketOrders(markets []string) (map[string][]string, error) {
+	return c.CancelMarketOrdersWithContext(context.Background(), markets)
+}
+
+func (c *Client) CancelMarketOrdersWithContext(ctx context.Context, markets []string) (map[string][]string, error) {
+	canceled := make(map[string][]string, len(markets))
+	for _, market := range markets {
+		if _, found := canceled[market]; found {
+			continue
+		}
+
+		orderIds, err := c.CancelOrdersWithContext(ctx, market)
+		if err != nil {
+			return canceled, err
+		}
+		canceled[market] = orderIds
+	}
+
+	return canceled, nil
+}
+
+func (c *Client) CancelOrder(market string, orderId string) (string, error) {
+	return c.CancelOrderWithContext(context.Background(), market, orderId)
+}
+
+func (c *Client) CancelOrderWithContext(ctx context.Context, market string, orderId string) (string, error) {
+	return c.cancelOrder(func(order *types.Order) bool { return order.Market == market && order.OrderId == orderId })
+}
+
+func (c *Client) CancelOrderByClientId(market string, clientOrderId string) (string, error) {
+	return c.CancelOrderByClientIdWithContext(context.Background(), market, clientOrderId)
+}
+
+func (c *Client) CancelOrderByClientIdWithContext(ctx context.Context, market string, clientOrderId string) (string, error) {
+	return c.cancelOrder(func(order *types.Order) bool {
+		return order.Market == market && order.ClientOrderId == clientOrderId
+	})
+}
+
+func (c *Client) NewOrder(market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	return c.NewOrderWithContext(context.Background(), market, side, orderType, order)
+}
+
+func (c *Client) NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	if !side.IsValid() || !orderType.IsValid() {
+		return types.Order{}, errInvalidOrder("side and orderType should be valid.")
+	}
+
+	book, err := c.public.GetTickerBookWithContext(ctx, market)
+	if err != nil {
+		return types.Order{}, err
+	}
+
+	now := time.Now().UnixMilli()
+	newOrder := &types.Order{
+		OrderId:             uuid.NewString(),
+		ClientOrderId:       order.ClientOrderId,
+		OperatorId:          order.OperatorId,
+		Market:              market,
+		Created:             now,
+		Updated:             now,
+		Status:              types.StatusNew,
+		Side:                side,
+		OrderType:           orderType,
+		Amount:              order.Amount,
+		AmountRemaining:     order.Amount,
+		Price:               order.Price,
+		TriggerAmount:       order.TriggerAmount,
+		TriggerType:         order.TriggerType,
+		TriggerReference:    order.TriggerReference,
+		TimeInForce:         order.TimeInForce,
+		PostOnly:            order.PostOnly,
+		SelfTradePrevention: order.SelfTradePrevention,
+		Visible:             orderType == types.OrderTypeLimit,
+		Fills:               make([]types.Fill, 0),
+	}
+	if isStopOrder(orderType) {
+		newOrder.Status = types.StatusAwaitingTrigger
+	}
+	if newOrder.TimeInForce == "" && isLimitOrder(orderType) {
+		newOrder.TimeInForce = types.TimeInForceGTC
+	}
+	if orderType == types.OrderTypeMarket && order.Amount == 0 && order.AmountQuote > 0 {
+		price := marketPrice(side, book)
+		if price == 0 {
+			return types.Order{}, errInvalidOrder("There is no liquidity in this market.")
+		}
+		amount := util.IfOrElse(side == types.SideBuy, func() float64 { return order.AmountQuote / (price * (1 + c.takerFee)) }, order.AmountQuote/price)
+		newOrder.Amount = amount
+		newOrder.AmountRemaining = amount
+	}
+	if newOrder.Amount <= 0 {
+		return types.Order{}, errInvalidOrder("amount should be greater than 0.")
+	}
+	if isLimitOrder(orderType) && newOrder.Price <= 0 {
+		return types.Order{}, errInvalidOrder("price should be greater than 0.")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.hold(newOrder, book); err != nil {
+		return types.Order{}, err
+	}
+	c.orders = append(c.orders, newOrder)
+	c.match(newOrder, book, true)
+
+	return copyOrder(newOrder), nil
+}
+
+func (c *Client) NewOrders(orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error) {
+	return c.NewOrdersWithContext(context.Background(), orders, failFast...)
+}
+
+func (c *Client) NewOrdersWithContext(ctx context.Context, orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error) {
+	return http.NewOrdersWith(ctx, orders, len(failFast) > 0 && failFast[0], func(ctx context.Context, order types.OrderNew) (types.Order, error) {
+		return c.NewOrderWithContext(ctx, order.Market, order.Side, order.OrderType, order)
+	})
+}
+
+func (c *Client) ReplaceOrder(market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	return c.ReplaceOrderWithContext(context.Background(), market, orderId, order, reduceByFilled...)
+}
+
+func (c *Client) ReplaceOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	return http.ReplaceOrderWith(ctx, c, market, orderId, order, len(reduceByFilled) > 0 && reduceByFilled[0])
+}
+
+func (c *Client) UpdateOrder(market string, orderId string, order types.OrderUpdate) (types.Order, error) {
+	return c.UpdateOrderWithContext(context.Background(), market, orderId, order)
+}
+
+func (c *Client) UpdateOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderUpdate) (types.Order, error) {
+	book, err := c.public.GetTickerBookWithContext(ctx, market)
+	if err != nil {
+		return types.Order{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := slices.IndexFunc(c.orders, func(o *types.Order) bool {
+		return o.Market == market && isOpen(o) &&
+			((orderId != "" && o.OrderId == orderId) || (orderId == "" && o.ClientOrderId == order.ClientOrderId))
+	})
+	if index < 0 {
+		return types.Order{}, errOrderNotFound()
+	}
+
+	existing := c.orders[index]
+	updated := copyOrder(existing)
+	if order.Amount > 0 {
+		updated.Amount = order.Amount
+		updated.AmountRemaining = order.Amount - updated.FilledAmount
+	}
+	if order.AmountRemaining > 0 {
+		updated.AmountRemaining = order.AmountRemaining
+		updated.Amount = updated.FilledAmount + order.AmountRemaining
+	}
+	if order.Price > 0 {
+		updated.Price = order.Price
+	}
+	if order.TriggerAmount > 0 {
+		updated.TriggerAmount = order.TriggerAmount
+	}
+	if order.TimeInForce != "" {
+		updated.TimeInForce = order.TimeInForce
+	}
+	if order.SelfTradePrevention != "" {
+		updated.SelfTradePrevention = order.SelfTradePrevention
+	}
+	updated.PostOnly = order.PostOnly
+	updated.Updated = time.Now().UnixMilli()
+
+	c.release(existing)
+	if err := c.hold(&updated, book); err != nil {
+		// restore the hold of the existing order
+		_ = c.hold(existing, book)
+		return types.Order{}, err
+	}
+	*existing = updated
+	c.match(existing, book, true)
+
+	return copyOrder(existing), nil
+}
+
+// findOrders returns a copy of the orders which match, newest first.
+func (c *Client) findOrders(matches func(order *types.Order) bool) []types.Order {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	orders := make([]types.Order, 0)
+	for _, order := range slices.Backward(c.orders) {
+		if matches(order) {
+			orders = append(orders, copyOrder(order))
+		}
+	}
+	return orders
+}
+
+func (c *Client) findOrder(matches func(order *types.Order) bool) (types.Order, error) {
+	orders := c.findOrders(matches)
+	if len(orders) == 0 {
+		return types.Order{}, errOrderNotFound()
+	}
+	return orders[0], nil
+}
+
+func (c *Client) cancelOrder(matches func(order *types.Order) bool) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := slices.IndexFunc(c.orders, func(order *types.Order) bool { return isOpen(order) && matches(order) })
+	if index < 0 {
+		return "", errOrderNotFound()
+	}
+
+	order := c.orders[index]
+	c.cancel(order, types.StatusCanceled)
+	return order.OrderId, nil
+}
+
+func copyOrder(order *types.Order) types.Order {
+	copied := *order
+	copied.Fills = slices.Clone(order.Fills)
+	return copied
+}
@@ -0,0 +1,107 @@
+package pgstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// migrationLock is the key of the advisory lock which prevents concurrent migrations by multiple processes.
+const migrationLock = 7_241_563_902
+
+// migrations are applied in order, a migration may never change once it has been released.
+var migrations = [][]string{
+	{
+		`CREATE TABLE bitvavo_orders (
+			order_id            TEXT PRIMARY KEY,
+			client_order_id     TEXT NOT NULL DEFAULT '',
+			market              TEXT NOT NULL,
+			created             TIMESTAMPTZ NOT NULL,
+			updated             TIMESTAMPTZ NOT NULL,
+			status              TEXT NOT NULL,
+			side                TEXT NOT NULL,
+			order_type          TEXT NOT NULL,
+			amount              NUMERIC,
+			amount_remaining    NUMERIC,
+			price               NUMERIC,
+			trigger_price       NUMERIC,
+			time_in_force       TEXT NOT NULL DEFAULT '',
+			post_only           BOOLEAN NOT NULL DEFAULT FALSE,
+			filled_amount       NUMERIC,
+			filled_amount_quote NUMERIC,
+			fee_paid            NUMERIC,
+			fee_currency        TEXT NOT NULL DEFAULT ''
+		)`,
+		`CREATE INDEX bitvavo_orders_market_created ON bitvavo_orders (market, created)`,
+		`CREATE TABLE bitvavo_fills (
+			fill_id      TEXT PRIMARY KEY,
+			order_id     TEXT NOT NULL,
+			market       TEXT NOT NULL,
+			executed_at  TIMESTAMPTZ NOT NULL,
+			side         TEXT NOT NULL,
+			amount       NUMERIC NOT NULL,
+			price        NUMERIC NOT NULL,
+			taker        BOOLEAN NOT NULL,
+			fee          NUMERIC NOT NULL,
+			fee_currency TEXT NOT NULL DEFAULT '',
+			settled      BOOLEAN NOT NULL
+		)`,
+		`CREATE INDEX bitvavo_fills_market_executed_at ON bitvavo_fills (market, executed_at)`,
+		`CREATE INDEX bitvavo_fills_order_id ON bitvavo_fills (order_id)`,
+		`CREATE TABLE bitvavo_balances (
+			taken_at  TIMESTAMPTZ NOT NULL,
+			symbol    TEXT NOT NULL,
+			available NUMERIC NOT NULL,
+			in_order  NUMERIC NOT NULL,
+			PRIMARY KEY (taken_at, symbol)
+		)`,
+		`CREATE TABLE bitvavo_candles (
+			market          TEXT NOT NULL,
+			candle_interval TEXT NOT NULL,
+			open_time       TIMESTAMPTZ NOT NULL,
+			open            NUMERIC NOT NULL,
+			high            NUMERIC NOT NULL,
+			low             NUMERIC NOT NULL,
+			close           NUMERIC NOT NULL,
+			volume          NUMERIC NOT NULL,
+			PRIMARY KEY (market, candle_interval, open_time)
+		)`,
+	},
+}
+
+// Migrate creates or updates the tables of the store, it's safe to call on every start of your application.
+func (s *Store) Migrate(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLock); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS bitvavo_schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`); err != nil {
+		return err
+	}
+
+	var version sql.NullInt64
+	if err := tx.QueryRowContext(ctx, "SELECT max(version) FROM bitvavo_schema_migrations").Scan(&version); err != nil {
+		return err
+	}
+
+	for i := int(version.Int64); i < len(migrations); i++ {
+		for _, statement := range migrations[i] {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("migration %d: %w", i+1, err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO bitvavo_schema_migrations (version) VALUES ($1)", i+1); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,252 @@
+// Package pgstore persists orders, fills, balance snapshots and candles into Postgres with upsert semantics,
+// fed by the account stream of the websocket and backfilled with the REST api.
+//
+// The store works with any Postgres driver for database/sql (e.g: github.com/jackc/pgx/v5/stdlib), which is registered by your application.
+package pgstore
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	upsertOrder = `INSERT INTO bitvavo_orders (
+		order_id, client_order_id, market, created, updated, status, side, order_type, amount, amount_remaining,
+		price, trigger_price, time_in_force, post_only, filled_amount, filled_amount_quote, fee_paid, fee_currency
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	ON CONFLICT (order_id) DO UPDATE SET
+		client_order_id = EXCLUDED.client_order_id,
+		updated = EXCLUDED.updated,
+		status = EXCLUDED.status,
+		amount = EXCLUDED.amount,
+		amount_remaining = EXCLUDED.amount_remaining,
+		price = EXCLUDED.price,
+		trigger_price = EXCLUDED.trigger_price,
+		time_in_force = EXCLUDED.time_in_force,
+		post_only = EXCLUDED.post_only,
+		filled_amount = EXCLUDED.filled_amount,
+		filled_amount_quote = EXCLUDED.filled_amount_quote,
+		fee_paid = EXCLUDED.fee_paid,
+		fee_currency = EXCLUDED.fee_currency
+	WHERE bitvavo_orders.updated <= EXCLUDED.updated`
+
+	upsertFill = `INSERT INTO bitvavo_fills (
+		fill_id, order_id, market, executed_at, side, amount, price, taker, fee, fee_currency, settled
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT (fill_id) DO UPDATE SET
+		fee = EXCLUDED.fee,
+		fee_currency = EXCLUDED.fee_currency,
+		settled = EXCLUDED.settled`
+
+	upsertBalance = `INSERT INTO bitvavo_balances (taken_at, symbol, available, in_order) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (taken_at, symbol) DO UPDATE SET
+		available = EXCLUDED.available,
+		in_order = EXCLUDED.in_order`
+
+	upsertCandle = `INSERT INTO bitvavo_candles (
+		market, candle_interval, open_time, open, high, low, close, volume
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (market, candle_interval, open_time) DO UPDATE SET
+		open = EXCLUDED.open,
+		high = EXCLUDED.high,
+		low = EXCLUDED.low,
+		close = EXCLUDED.close,
+		volume = EXCLUDED.volume`
+)
+
+// Store writes to the tables which are created by Migrate. It's safe for concurrent use.
+type Store struct {
+	db *sql.DB
+}
+
+// New creates a store which writes to db, call Migrate before using it.
+func New(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// SaveOrder inserts order or updates it, unless the stored order has been updated more recently.
+func (s *Store) SaveOrder(ctx context.Context, order types.Order) error {
+	_, err := s.db.ExecContext(ctx, upsertOrder,
+		order.OrderId,
+		order.ClientOrderId,
+		order.Market,
+		order.CreatedTime(),
+		order.UpdatedTime(),
+		string(order.Status),
+		string(order.Side),
+		string(order.OrderType),
+		optionalNumeric(order.AmountStr, order.Amount),
+		optionalNumeric(order.AmountRemainingStr, order.AmountRemaining),
+		optionalNumeric(order.PriceStr, order.Price),
+		optionalNumeric(order.TriggerPriceStr, order.TriggerPrice),
+		string(order.TimeInForce),
+		order.PostOnly,
+		optionalNumeric(order.FilledAmountStr, order.FilledAmount),
+		optionalNumeric(order.FilledAmountQuoteStr, order.FilledAmountQuote),
+		optionalNumeric(order.FeePaidStr, order.FeePaid),
+		order.FeeCurrency,
+	)
+	return err
+}
+
+// SaveFill inserts fill of market or updates its fee and settlement.
+func (s *Store) SaveFill(ctx context.Context, market string, fill types.Fill) error {
+	_, err := s.db.ExecContext(ctx, upsertFill,
+		fill.FillId,
+		fill.OrderId,
+		market,
+		fill.Time(),
+		string(fill.Side),
+		numeric(fill.AmountStr, fill.Amount),
+		numeric(fill.PriceStr, fill.Price),
+		fill.Taker,
+		numeric(fill.FeeStr, fill.Fee),
+		fill.FeeCurrency,
+		fill.Settled,
+	)
+	return err
+}
+
+// SaveBalances stores balances as a snapshot taken at takenAt.
+func (s *Store) SaveBalances(ctx context.Context, takenAt time.Time, balances []types.Balance) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, balance := range balances {
+			if _, err := tx.ExecContext(ctx, upsertBalance,
+				takenAt,
+				balance.Symbol,
+				numeric("", balance.Available),
+				numeric("", balance.InOrder),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveCandles inserts the candles of market with interval (e.g: 5m) or updates them.
+func (s *Store) SaveCandles(ctx context.Context, market string, interval types.Interval, candles []types.Candle) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, candle := range candles {
+			if _, err := tx.ExecContext(ctx, upsertCandle,
+				market,
+				interval.String(),
+				candle.Time(),
+				numeric(candle.OpenStr, candle.Open),
+				numeric(candle.HighStr, candle.High),
+				numeric(candle.LowStr, candle.Low),
+				numeric(candle.CloseStr, candle.Close),
+				numeric(candle.VolumeStr, candle.Volume),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Consume saves every order and fill of the account stream until both channels are closed or ctx is done,
+// failures are logged so the stream keeps being consumed.
+func (s *Store) Consume(ctx context.Context, orderchn <-chan ws.OrderEvent, fillchn <-chan ws.FillEvent) {
+	for orderchn != nil || fillchn != nil {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-orderchn:
+			if !ok {
+				orderchn = nil
+				continue
+			}
+			if err := s.SaveOrder(ctx, event.Order); err != nil {
+				log.Err(err).Str("orderId", event.Order.OrderId).Msg("Failed to save order")
+			}
+		case event, ok := <-fillchn:
+			if !ok {
+				fillchn = nil
+				continue
+			}
+			if err := s.SaveFill(ctx, event.Market, event.Fill); err != nil {
+				log.Err(err).Str("fillId", event.Fill.FillId).Msg("Failed to save fill")
+			}
+		}
+	}
+}
+
+// Backfill saves every order and fill of markets (e.g: ETH-EUR) and a snapshot of the balances using client.
+//
+// To not miss an update, start consuming the account stream before backfilling, the upserts make it safe to store both.
+func (s *Store) Backfill(ctx context.Context, client http.HttpClientAuth, markets ...string) error {
+	for _, market := range markets {
+		orders, err := client.GetAllOrdersWithContext(ctx, market)
+		if err != nil {
+			return err
+		}
+		for _, order := range orders {
+			if err := s.SaveOrder(ctx, order); err != nil {
+				return err
+			}
+		}
+
+		trades, err := client.GetAllTradesWithContext(ctx, market)
+		if err != nil {
+			return err
+		}
+		for _, trade := range trades {
+			if err := s.SaveFill(ctx, market, types.Fill(trade)); err != nil {
+				return err
+			}
+		}
+	}
+
+	takenAt := time.Now()
+	balances, err := client.GetBalanceWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.SaveBalances(ctx, takenAt, balances)
+}
+
+// BackfillCandles saves the candles of market with interval between start and end using client.
+func (s *Store) BackfillCandles(ctx context.Context, client http.HttpClient, market string, interval types.Interval, start time.Time, end time.Time) error {
+	candles, err := client.GetCandlesRangeWithContext(ctx, market, interval, start, end)
+	if err != nil {
+		return err
+	}
+	return s.SaveCandles(ctx, market, interval, candles)
+}
+
+func (s *Store) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// numeric returns the original string value of a number if any, so it's stored without rounding errors.
+func numeric(original string, value float64) string {
+	if original != "" {
+		return original
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// optionalNumeric is the same as numeric, but returns NULL whenever the number is missing.
+func optionalNumeric(original string, value float64) sql.NullString {
+	if original == "" && value == 0 {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: numeric(original, value), Valid: true}
+}
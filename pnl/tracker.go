@@ -0,0 +1,290 @@
+// Package pnl computes the realized and unrealized profit and loss per market from the fills of your account.
+package pnl
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+// Method determines the cost of the amount which is sold.
+type Method int
+
+const (
+	// FIFO (first in, first out) sells the oldest bought amount first.
+	FIFO Method = iota
+
+	// AverageCost sells at the average price of everything that has been bought.
+	AverageCost
+)
+
+type MarketPnL struct {
+	// The market (e.g: ETH-EUR)
+	Market string `json:"market"`
+
+	// The amount in base currency which is held according to the fills.
+	Position float64 `json:"position"`
+
+	// The average price in quote currency of the position.
+	AverageCost float64 `json:"averageCost"`
+
+	// The profit (or loss if negative) in quote currency of everything that has been sold, excluding fees.
+	Realized float64 `json:"realized"`
+
+	// The profit (or loss if negative) in quote currency of the position at the current price, excluding fees.
+	Unrealized float64 `json:"unrealized"`
+
+	// The fees paid in quote currency.
+	Fees float64 `json:"fees"`
+}
+
+// Net returns the realized and unrealized profit minus the fees.
+func (m MarketPnL) Net() float64 {
+	return m.Realized + m.Unrealized - m.Fees
+}
+
+// lot is an amount which has been bought at price.
+type lot struct {
+	amount float64
+	price  float64
+}
+
+type position struct {
+	lots     []lot
+	realized float64
+	fees     float64
+}
+
+func (p *position) amount() float64 {
+	var amount float64
+	for _, lot := range p.lots {
+		amount += lot.amount
+	}
+	return amount
+}
+
+func (p *position) cost() float64 {
+	var cost float64
+	for _, lot := range p.lots {
+		cost += lot.amount * lot.price
+	}
+	return cost
+}
+
+// Tracker keeps the position of every market from the fills which are added. It's safe for concurrent use.
+//
+// Sells are matched with the bought amount according to the method. Whenever more is sold than has been bought according to
+// the fills (e.g: the asset has been deposited), nothing is realized for the excess because its cost is unknown.
+//
+// Fees paid in the base currency are converted to the quote currency at the price of the fill, fees paid in any other
+// currency are left out of the fees because there is no price to convert them with.
+type Tracker struct {
+	method Method
+
+	mu        sync.RWMutex
+	positions map[string]*position
+	seen      map[string]bool
+
+	// the number of running replays, fills which are added meanwhile are buffered in pending
+	replaying int
+	pending   []pendingFill
+}
+
+type pendingFill struct {
+	market string
+	fill   types.Fill
+}
+
+// New creates an empty tracker which matches sells with the bought amount according to method.
+func New(method Method) *Tracker {
+	return &Tracker{
+		method:    method,
+		positions: make(map[string]*position),
+		seen:      make(map[string]bool),
+	}
+}
+
+// Add applies fill of market, fills which have already been added are ignored.
+// Fills must be added in the order they happened, fills which are added during a Replay are applied after it has finished.
+func (t *Tracker) Add(market string, fill types.Fill) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.replaying > 0 {
+		t.pending = append(t.pending, pendingFill{market: market, fill: fill})
+		return
+	}
+	t.add(market, fill)
+}
+
+func (t *Tracker) add(market string, fill types.Fill) {
+	if fill.Amount <= 0 {
+		return
+	}
+
+	if fill.FillId != "" {
+		if t.seen[fill.FillId] {
+			return
+		}
+		t.seen[fill.FillId] = true
+	}
+
+	pos, found := t.positions[market]
+	if !found {
+		pos = new(position)
+		t.positions[market] = pos
+	}
+
+	switch base, quote := splitMarket(market); fill.FeeCurrency {
+	case "", quote:
+		pos.fees += fill.Fee
+	case base:
+		pos.fees += fill.Fee * fill.Price
+	}
+
+	if fill.Side == types.SideBuy {
+		pos.lots = append(pos.lots, lot{amount: fill.Amount, price: fill.Price})
+		if t.method == AverageCost {
+			pos.lots = []lot{{amount: pos.amount(), price: pos.cost() / pos.amount()}}
+		}
+		return
+	}
+
+	remaining := fill.Amount
+	for remaining > 0 && len(pos.lots) > 0 {
+		sold := min(remaining, pos.lots[0].amount)
+		pos.realized += sold * (fill.Price - pos.lots[0].price)
+		pos.lots[0].amount -= sold
+		remaining -= sold
+
+		if pos.lots[0].amount <= 0 {
+			pos.lots = pos.lots[1:]
+		}
+	}
+}
+
+// Replay adds every historic trade of markets (e.g: ETH-EUR) using client, oldest first.
+//
+// To not miss a fill, start consuming the live fills before replaying, fills received by both are only added once.
+// The live fills are buffered until the replay has finished, so they're applied after the historic trades.
+func (t *Tracker) Replay(ctx context.Context, client http.HttpClientAuth, markets ...string) error {
+	t.mu.Lock()
+	t.replaying++
+	t.mu.Unlock()
+	defer t.flush()
+
+	for _, market := range markets {
+		trades, err := client.GetAllTradesWithContext(ctx, market)
+		if err != nil {
+			return err
+		}
+
+		sort.SliceStable(trades, func(i, j int) bool { return trades[i].Timestamp < trades[j].Timestamp })
+
+		t.mu.Lock()
+		for _, trade := range trades {
+			t.add(market, types.Fill(trade))
+		}
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// flush ends a replay, the fills which were added during the last running replay are applied in the order they happened.
+func (t *Tracker) flush() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.replaying--
+	if t.replaying > 0 {
+		return
+	}
+
+	sort.SliceStable(t.pending, func(i, j int) bool { return t.pending[i].fill.Timestamp < t.pending[j].fill.Timestamp })
+	for _, pending := range t.pending {
+		t.add(pending.market, pending.fill)
+	}
+	t.pending = nil
+}
+
+// Consume adds every fill of fillchn (e.g: the fills of the account stream) until it's closed.
+func (t *Tracker) Consume(fillchn <-chan ws.FillEvent) {
+	for event := range fillchn {
+		t.Add(event.Market, event.Fill)
+	}
+}
+
+// PnL returns the profit and loss of market with price as the current price, false if market has no fills.
+func (t *Tracker) PnL(market string, price float64) (MarketPnL, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	pos, found := t.positions[market]
+	if !found {
+		return MarketPnL{}, false
+	}
+
+	pnl := MarketPnL{
+		Market:   market,
+		Position: pos.amount(),
+		Realized: pos.realized,
+		Fees:     pos.fees,
+	}
+	if pnl.Position > 0 {
+		pnl.AverageCost = pos.cost() / pnl.Position
+		pnl.Unrealized = pnl.Position * (price - pnl.AverageCost)
+	}
+	return pnl, true
+}
+
+// Markets returns the markets which have fills, sorted by name.
+func (t *Tracker) Markets() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	markets := make([]string, 0, len(t.positions))
+	for market := range t.positions {
+		markets = append(markets, market)
+	}
+	slices.Sort(markets)
+	return markets
+}
+
+// Current returns the profit and loss of every market, valued with the current ticker prices using client.
+// The unrealized profit is 0 for markets without a ticker price (e.g: delisted)
+func (t *Tracker) Current(ctx context.Context, client http.HttpClient) ([]MarketPnL, error) {
+	prices, err := client.GetTickerPricesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	priceByMarket := make(map[string]float64, len(prices))
+	for _, price := range prices {
+		priceByMarket[price.Market] = price.Price
+	}
+
+	markets := t.Markets()
+	pnls := make([]MarketPnL, 0, len(markets))
+	for _, market := range markets {
+		price, hasPrice := priceByMarket[market]
+		if pnl, found := t.PnL(market, price); found {
+			if !hasPrice {
+				pnl.Unrealized = 0
+			}
+			pnls = append(pnls, pnl)
+		}
+	}
+	return pnls, nil
+}
+
+// splitMarket returns the base and quote currency of market (e.g: ETH-EUR)
+func splitMarket(market string) (string, string) {
+	base, quote, _ := strings.Cut(market, "-")
+	return base, quote
+}
@@ -0,0 +1,187 @@
+package polling
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/larscom/go-bitvavo/v2/feed"
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/util"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+// Book is a book handler which polls the book of every market.
+//
+// The first event of a market contains the whole book, every next event only contains the levels which changed
+// (a size of 0 removes the level) just like the websocket. Since polling skips nonces, apply the events to a book
+// without checking the nonce or use SubscribeTop.
+type Book struct {
+	*feed.Handler[ws.BookEvent]
+}
+
+var _ ws.BookEventHandler = (*Book)(nil)
+
+// NewBook creates a book handler which polls the book of every market, an event is emitted whenever the nonce changes.
+func NewBook(client http.HttpClient, options ...Option) *Book {
+	config := newConfig(options...)
+
+	handler := feed.NewHandler(func(event ws.BookEvent) string { return event.Market }, func(ctx context.Context, markets []string, emit func(event ws.BookEvent) bool) {
+		books := make(map[string]*levels)
+
+		run(ctx, config.interval, "book", func(ctx context.Context) error {
+			for _, market := range markets {
+				snapshot, err := client.GetOrderBookWithContext(ctx, market, config.depth...)
+				if err != nil {
+					return err
+				}
+
+				book, found := books[market]
+				if found && book.nonce == snapshot.Nonce {
+					continue
+				}
+				if !found {
+					book = newLevels()
+					books[market] = book
+				}
+
+				update := book.diff(snapshot)
+				if len(update.Bids) == 0 && len(update.Asks) == 0 && found {
+					continue
+				}
+				if !emit(ws.BookEvent{Event: "book", Market: market, Book: update}) {
+					return nil
+				}
+			}
+			return nil
+		})
+	})
+
+	return &Book{Handler: handler}
+}
+
+func (b *Book) SubscribeTop(markets []string, buffSize ...uint64) (<-chan ws.TopOfBookEvent, error) {
+	bookchn, err := b.Subscribe(markets, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
+		outchn = make(chan ws.TopOfBookEvent, int(size)*len(markets))
+	)
+
+	go func() {
+		defer close(outchn)
+
+		var (
+			books = make(map[string]*levels)
+			tops  = make(map[string]ws.TopOfBookEvent)
+		)
+		for event := range bookchn {
+			book, found := books[event.Market]
+			if !found {
+				book = newLevels()
+				books[event.Market] = book
+			}
+			book.apply(event.Book)
+
+			bestBid, bestAsk := book.top()
+			if top, emitted := tops[event.Market]; emitted && top.BestBid == bestBid && top.BestAsk == bestAsk {
+				continue
+			}
+
+			top := ws.TopOfBookEvent{Market: event.Market, Nonce: event.Book.Nonce, BestBid: bestBid, BestAsk: bestAsk}
+			tops[event.Market] = top
+			outchn <- top
+		}
+	}()
+
+	return outchn, nil
+}
+
+// levels is the size per price of both sides of a book.
+type levels struct {
+	nonce int64
+	bids  map[float64]float64
+	asks  map[float64]float64
+}
+
+func newLevels() *levels {
+	return &levels{
+		bids: make(map[float64]float64),
+		asks: make(map[float64]float64),
+	}
+}
+
+// diff replaces the levels with snapshot and returns the levels which changed, removed levels have a size of 0.
+func (l *levels) diff(snapshot types.Book) types.Book {
+	update := types.Book{
+		Nonce: snapshot.Nonce,
+		Bids:  diffSide(l.bids, snapshot.Bids),
+		Asks:  diffSide(l.asks, snapshot.Asks),
+	}
+	l.nonce = snapshot.Nonce
+	return update
+}
+
+// apply applies the levels of update, a size of 0 removes the level.
+func (l *levels) apply(update types.Book) {
+	applySide(l.bids, update.Bids)
+	applySide(l.asks, update.Asks)
+	l.nonce = update.Nonce
+}
+
+// top returns the best (highest) bid and best (lowest) ask, zero if a side is empty.
+func (l *levels) top() (types.Page, types.Page) {
+	var bestBid, bestAsk types.Page
+	for price, size := range l.bids {
+		if bestBid.Price == 0 || price > bestBid.Price {
+			bestBid = types.Page{Price: price, Size: size}
+		}
+	}
+	for price, size := range l.asks {
+		if bestAsk.Price == 0 || price < bestAsk.Price {
+			bestAsk = types.Page{Price: price, Size: size}
+		}
+	}
+	return bestBid, bestAsk
+}
+
+func diffSide(side map[float64]float64, pages []types.Page) []types.Page {
+	var (
+		changed = make([]types.Page, 0)
+		current = make(map[float64]float64, len(pages))
+	)
+	for _, page := range pages {
+		current[page.Price] = page.Size
+		if size, found := side[page.Price]; !found || size != page.Size {
+			changed = append(changed, page)
+		}
+	}
+	for price := range side {
+		if _, found := current[price]; !found {
+			changed = append(changed, types.Page{Price: price, Size: 0})
+		}
+	}
+	slices.SortFunc(changed, func(a, b types.Page) int {
+		return cmp.Compare(a.Price, b.Price)
+	})
+
+	clear(side)
+	for price, size := range current {
+		side[price] = size
+	}
+	return changed
+}
+
+func applySide(side map[float64]float64, pages []types.Page) {
+	for _, page := range pages {
+		if page.Size == 0 {
+			delete(side, page.Price)
+		} else {
+			side[page.Price] = page.Size
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Package polling implements the event handlers of the websocket client on top of the REST api,
+// so consumers written against the websocket interfaces run unchanged where websockets are blocked.
+//
+// Every subscription polls its markets in an interval and only emits what changed since the previous poll.
+package polling
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/feed"
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultInterval = 2 * time.Second
+	defaultBuffSize = 50
+
+	// The maximum number of trades which is fetched per market per poll.
+	tradesLimit = 1000
+)
+
+type config struct {
+	interval time.Duration
+	depth    []uint64
+}
+
+type Option func(*config)
+
+// The interval in which the markets of a subscription are polled.
+// default: 2s
+func WithInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.interval = interval
+	}
+}
+
+// The depth of the polled book.
+// default: the whole book
+func WithBookDepth(depth uint64) Option {
+	return func(c *config) {
+		c.depth = []uint64{depth}
+	}
+}
+
+func newConfig(options ...Option) *config {
+	config := &config{interval: defaultInterval}
+	for _, opt := range options {
+		opt(config)
+	}
+	return config
+}
+
+// NewTicker creates a ticker handler which polls the best bid, best ask and last price of every market,
+// an event is emitted whenever one of them changes.
+func NewTicker(client http.HttpClient, options ...Option) *feed.Handler[ws.TickerEvent] {
+	config := newConfig(options...)
+
+	return feed.NewHandler(func(event ws.TickerEvent) string { return event.Market }, func(ctx context.Context, markets []string, emit func(event ws.TickerEvent) bool) {
+		last := make(map[string]types.Ticker)
+
+		run(ctx, config.interval, "ticker", func(ctx context.Context) error {
+			books, err := client.GetTickerBooksWithContext(ctx)
+			if err != nil {
+				return err
+			}
+			prices, err := client.GetTickerPricesWithContext(ctx)
+			if err != nil {
+				return err
+			}
+
+			tickers := make(map[string]types.Ticker, len(markets))
+			for _, book := range books {
+				if slices.Contains(markets, book.Market) {
+					tickers[book.Market] = types.Ticker{
+						BestBid:        book.Bid,
+						BestBidStr:     book.BidStr,
+						BestBidSize:    book.BidSize,
+						BestBidSizeStr: book.BidSizeStr,
+						BestAsk:        book.Ask,
+						BestAskStr:     book.AskStr,
+						BestAskSize:    book.AskSize,
+						BestAskSizeStr: book.AskSizeStr,
+					}
+				}
+			}
+			for _, price := range prices {
+				if ticker, found := tickers[price.Market]; found {
+					ticker.LastPrice = price.Price
+					ticker.LastPriceStr = price.PriceStr
+					tickers[price.Market] = ticker
+				}
+			}
+
+			for _, market := range markets {
+				ticker, found := tickers[market]
+				if previous, emitted := last[market]; !found || (emitted && previous == ticker) {
+					continue
+				}
+				last[market] = ticker
+				emit(ws.TickerEvent{Event: "ticker", Market: market, Ticker: ticker})
+			}
+			return nil
+		})
+	})
+}
+
+// NewTicker24h creates a ticker24h handler which polls the 24 hour statistics of every market,
+// an event is emitted whenever they change (regardless of the timestamp)
+func NewTicker24h(client http.HttpClient, options ...Option) *feed.Handler[ws.Ticker24hEvent] {
+	config := newConfig(options...)
+
+	return feed.NewHandler(func(event ws.Ticker24hEvent) string { return event.Market }, func(ctx context.Context, markets []string, emit func(event ws.Ticker24hEvent) bool) {
+		last := make(map[string]types.Ticker24h)
+
+		run(ctx, config.interval, "ticker24h", func(ctx context.Context) error {
+			tickers, err := client.GetTickers24hForWithContext(ctx, markets)
+			if err != nil {
+				return err
+			}
+
+			for _, market := range markets {
+				ticker, found := tickers[market]
+				if !found {
+					continue
+				}
+
+				previous, emitted := last[market]
+				previous.Timestamp = ticker.Timestamp
+				if emitted && previous == ticker {
+					continue
+				}
+				last[market] = ticker
+				emit(ws.Ticker24hEvent{Event: "ticker24h", Market: market, Ticker24h: ticker})
+			}
+			return nil
+		})
+	})
+}
+
+// NewTrades creates a trades handler which polls the trades of every market, an event is emitted for every trade
+// which has been made after the subscription started (oldest first)
+//
+// At most 1000 trades are fetched per market per poll, decrease the interval (see: WithInterval) for busy markets.
+func NewTrades(client http.HttpClient, options ...Option) *feed.Handler[ws.TradesEvent] {
+	config := newConfig(options...)
+
+	return feed.NewHandler(func(event ws.TradesEvent) string { return event.Market }, func(ctx context.Context, markets []string, emit func(event ws.TradesEvent) bool) {
+		var (
+			// the id of the last trade per market, the trades before it have been made before the subscription
+			lastIds = make(map[string]string)
+			started = time.Now()
+		)
+
+		run(ctx, config.interval, "trades", func(ctx context.Context) error {
+			for _, market := range markets {
+				lastId, polled := lastIds[market]
+
+				params := &types.TradeParams{Limit: 1}
+				if polled && lastId != "" {
+					params = &types.TradeParams{Limit: tradesLimit, TradeIdFrom: lastId}
+				} else if polled {
+					params = &types.TradeParams{Limit: tradesLimit, Start: started}
+				}
+
+				trades, err := client.GetTradesWithContext(ctx, market, params)
+				if err != nil {
+					return err
+				}
+				slices.SortStableFunc(trades, func(a, b types.Trade) int {
+					return cmp.Compare(a.Timestamp, b.Timestamp)
+				})
+
+				if !polled {
+					lastIds[market] = ""
+				}
+				for _, trade := range trades {
+					if trade.Id == lastId {
+						continue
+					}
+					lastIds[market] = trade.Id
+					if polled && !emit(ws.TradesEvent{Event: "trade", Market: market, Trade: trade}) {
+						return nil
+					}
+				}
+				if polled && len(trades) >= tradesLimit {
+					log.Warn().Str("market", market).Msg("Polled the maximum number of trades, trades may have been skipped")
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// run calls poll immediately and then every interval until ctx is done, a failed poll is retried at the next interval.
+func run(ctx context.Context, interval time.Duration, channel string, poll func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := poll(ctx); err != nil && ctx.Err() == nil {
+			log.Warn().Err(err).Str("channel", channel).Msg("Failed to poll, retrying at the next interval")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
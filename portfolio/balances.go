@@ -0,0 +1,238 @@
+package portfolio
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const defaultBalanceBuffSize = 50
+
+// BalanceChange is a change of the available and/or in order amount of a symbol.
+type BalanceChange struct {
+	// The short name of the asset (e.g: BTC)
+	Symbol string
+
+	// The balance before the change, zero if the symbol had no balance.
+	Previous types.Balance
+
+	// The balance after the change, zero if the symbol has no balance anymore.
+	Current types.Balance
+}
+
+// BalanceWatcher polls the balances every interval and after every fill of the account stream (if any)
+// and emits a change for every symbol whose balance changed, since the websocket doesn't push balance updates.
+// It's safe for concurrent use.
+type BalanceWatcher struct {
+	client   http.HttpClientAuth
+	interval time.Duration
+	buffSize uint64
+
+	account ws.AccountEventHandler
+	markets []string
+
+	mu       sync.RWMutex
+	balances map[string]types.Balance
+
+	changes   chan BalanceChange
+	refreshch chan struct{}
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+type BalanceOption func(*BalanceWatcher)
+
+// The interval in which the balances are polled.
+// default: 1m
+func WithBalanceInterval(interval time.Duration) BalanceOption {
+	return func(w *BalanceWatcher) {
+		w.interval = interval
+	}
+}
+
+// The buffer size of the changes channel, changes are dropped when it's full.
+// default: 50
+func WithBalanceBuffSize(buffSize uint64) BalanceOption {
+	return func(w *BalanceWatcher) {
+		w.buffSize = buffSize
+	}
+}
+
+// Poll the balances after every fill in markets (e.g: ETH-EUR) of the account stream, which is subscribed to markets
+// until the watcher is closed, so account can't have a subscription to markets already.
+func WithBalanceAccount(account ws.AccountEventHandler, markets []string) BalanceOption {
+	return func(w *BalanceWatcher) {
+		w.account = account
+		w.markets = markets
+	}
+}
+
+// NewBalanceWatcher creates a watcher which polls the balances of client immediately, the first poll isn't emitted
+// as a change but is available with Balances.
+func NewBalanceWatcher(client http.HttpClientAuth, options ...BalanceOption) (*BalanceWatcher, error) {
+	watcher := &BalanceWatcher{
+		client:    client,
+		interval:  defaultInterval,
+		buffSize:  defaultBalanceBuffSize,
+		refreshch: make(chan struct{}, 1),
+	}
+	for _, opt := range options {
+		opt(watcher)
+	}
+	watcher.changes = make(chan BalanceChange, watcher.buffSize)
+
+	var fillchn <-chan ws.FillEvent
+	if watcher.account != nil {
+		orderchn, fills, err := watcher.account.Subscribe(watcher.markets)
+		if err != nil {
+			return nil, err
+		}
+		fillchn = fills
+
+		watcher.wg.Add(1)
+		go func() {
+			defer watcher.wg.Done()
+			for range orderchn {
+				// the order events aren't needed, but must be consumed so the stream doesn't block
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher.cancel = cancel
+
+	watcher.wg.Add(1)
+	go watcher.run(ctx, fillchn)
+
+	return watcher, nil
+}
+
+// Balances returns the latest balance of every symbol which has a balance, nil if it hasn't been polled yet.
+func (w *BalanceWatcher) Balances() []types.Balance {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.balances == nil {
+		return nil
+	}
+
+	balances := make([]types.Balance, 0, len(w.balances))
+	for _, balance := range w.balances {
+		balances = append(balances, balance)
+	}
+	slices.SortFunc(balances, func(a, b types.Balance) int {
+		return cmp.Compare(a.Symbol, b.Symbol)
+	})
+	return balances
+}
+
+// Changes returns a channel which receives a change for every symbol whose balance changed.
+// The channel is closed when the watcher is closed.
+func (w *BalanceWatcher) Changes() <-chan BalanceChange {
+	return w.changes
+}
+
+// Refresh polls the balances as soon as possible (e.g: after placing an order)
+func (w *BalanceWatcher) Refresh() {
+	select {
+	case w.refreshch <- struct{}{}:
+	default:
+	}
+}
+
+// Close stops the watcher and unsubscribes from the account stream, if any.
+func (w *BalanceWatcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		if w.account != nil {
+			err = w.account.Unsubscribe(w.markets)
+		}
+		w.cancel()
+		w.wg.Wait()
+		close(w.changes)
+	})
+	return err
+}
+
+func (w *BalanceWatcher) run(ctx context.Context, fillchn <-chan ws.FillEvent) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.poll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.refreshch:
+		case _, ok := <-fillchn:
+			if !ok {
+				fillchn = nil
+				continue
+			}
+		}
+		w.poll(ctx)
+	}
+}
+
+// poll fetches the balances and publishes every change, the previous balances are kept if it fails.
+func (w *BalanceWatcher) poll(ctx context.Context) {
+	balances, err := w.client.GetBalanceWithContext(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Warn().Err(err).Msg("failed to poll the balances")
+		}
+		return
+	}
+
+	current := make(map[string]types.Balance, len(balances))
+	for _, balance := range balances {
+		current[balance.Symbol] = balance
+	}
+
+	w.mu.Lock()
+	previous := w.balances
+	w.balances = current
+	w.mu.Unlock()
+
+	if previous == nil {
+		return
+	}
+
+	changes := make([]BalanceChange, 0)
+	for symbol, balance := range current {
+		if before := previous[symbol]; before != balance {
+			changes = append(changes, BalanceChange{Symbol: symbol, Previous: before, Current: balance})
+		}
+	}
+	for symbol, before := range previous {
+		if _, found := current[symbol]; !found {
+			changes = append(changes, BalanceChange{Symbol: symbol, Previous: before, Current: types.Balance{Symbol: symbol}})
+		}
+	}
+	slices.SortFunc(changes, func(a, b BalanceChange) int {
+		return cmp.Compare(a.Symbol, b.Symbol)
+	})
+
+	for _, change := range changes {
+		w.publish(change)
+	}
+}
+
+func (w *BalanceWatcher) publish(change BalanceChange) {
+	select {
+	case w.changes <- change:
+	default:
+		log.Warn().Str("symbol", change.Symbol).Msg("changes channel is full, dropping balance change")
+	}
+}
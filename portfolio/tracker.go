@@ -0,0 +1,196 @@
+// Package portfolio keeps a valued snapshot of the balances of your account up to date,
+// so it can be read synchronously or consumed as a stream of changes.
+package portfolio
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const defaultInterval = time.Minute
+
+// Tracker values the balances in a quote currency every interval and after every fill of the account stream (if any)
+// It's safe for concurrent use.
+type Tracker struct {
+	client   http.HttpClientAuth
+	quote    string
+	interval time.Duration
+
+	account ws.AccountEventHandler
+	markets []string
+
+	mu        sync.RWMutex
+	snapshot  types.Portfolio
+	updatedAt time.Time
+
+	changes   chan types.Portfolio
+	refreshch chan struct{}
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+type Option func(*Tracker)
+
+// The interval in which the portfolio is valued.
+// default: 1m
+func WithInterval(interval time.Duration) Option {
+	return func(t *Tracker) {
+		t.interval = interval
+	}
+}
+
+// Value the portfolio after every fill in markets (e.g: ETH-EUR) of the account stream, which is subscribed to markets
+// until the tracker is closed, so account can't have a subscription to markets already.
+func WithAccount(account ws.AccountEventHandler, markets []string) Option {
+	return func(t *Tracker) {
+		t.account = account
+		t.markets = markets
+	}
+}
+
+// New creates a tracker which values the balances of client in quote currency (e.g: EUR) and values it immediately.
+func New(client http.HttpClientAuth, quote string, options ...Option) (*Tracker, error) {
+	tracker := &Tracker{
+		client:    client,
+		quote:     quote,
+		interval:  defaultInterval,
+		changes:   make(chan types.Portfolio, 1),
+		refreshch: make(chan struct{}, 1),
+	}
+	for _, opt := range options {
+		opt(tracker)
+	}
+
+	var fillchn <-chan ws.FillEvent
+	if tracker.account != nil {
+		orderchn, fills, err := tracker.account.Subscribe(tracker.markets)
+		if err != nil {
+			return nil, err
+		}
+		fillchn = fills
+
+		tracker.wg.Add(1)
+		go func() {
+			defer tracker.wg.Done()
+			for range orderchn {
+				// the order events aren't needed, but must be consumed so the stream doesn't block
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tracker.cancel = cancel
+
+	tracker.wg.Add(1)
+	go tracker.run(ctx, fillchn)
+
+	return tracker, nil
+}
+
+// Snapshot returns the latest portfolio and the time it was valued, false if it hasn't been valued yet.
+func (t *Tracker) Snapshot() (types.Portfolio, time.Time, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.snapshot, t.updatedAt, !t.updatedAt.IsZero()
+}
+
+// Changes returns a channel which receives the portfolio whenever its value has changed.
+//
+// Only the latest portfolio is kept if it isn't consumed in time. The channel is closed when the tracker is closed.
+func (t *Tracker) Changes() <-chan types.Portfolio {
+	return t.changes
+}
+
+// Refresh values the portfolio as soon as possible (e.g: after a deposit)
+func (t *Tracker) Refresh() {
+	select {
+	case t.refreshch <- struct{}{}:
+	default:
+	}
+}
+
+// Close stops the tracker and unsubscribes from the account stream, if any.
+func (t *Tracker) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		if t.account != nil {
+			err = t.account.Unsubscribe(t.markets)
+		}
+		t.cancel()
+		t.wg.Wait()
+		close(t.changes)
+	})
+	return err
+}
+
+func (t *Tracker) run(ctx context.Context, fillchn <-chan ws.FillEvent) {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	t.value(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-t.refreshch:
+		case _, ok := <-fillchn:
+			if !ok {
+				fillchn = nil
+				continue
+			}
+		}
+		t.value(ctx)
+	}
+}
+
+// value values the portfolio and publishes it if it has changed, the previous snapshot is kept if it fails.
+func (t *Tracker) value(ctx context.Context) {
+	portfolio, err := t.client.GetPortfolioValueWithContext(ctx, t.quote)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Warn().Err(err).Str("quote", t.quote).Msg("failed to value the portfolio")
+		}
+		return
+	}
+
+	t.mu.Lock()
+	changed := t.updatedAt.IsZero() || !equal(t.snapshot, portfolio)
+	t.snapshot = portfolio
+	t.updatedAt = time.Now()
+	t.mu.Unlock()
+
+	if changed {
+		t.publish(portfolio)
+	}
+}
+
+// publish sends portfolio on the changes channel, replacing the previous portfolio if it hasn't been consumed.
+func (t *Tracker) publish(portfolio types.Portfolio) {
+	select {
+	case t.changes <- portfolio:
+		return
+	default:
+	}
+
+	select {
+	case <-t.changes:
+	default:
+	}
+	t.changes <- portfolio
+}
+
+func equal(a types.Portfolio, b types.Portfolio) bool {
+	return a.Quote == b.Quote && a.Total == b.Total && slices.Equal(a.Assets, b.Assets)
+}
@@ -0,0 +1,209 @@
+// Package replay implements the event handlers of the websocket client on top of historical data,
+// so strategies written against the websocket interfaces can be backtested unchanged.
+//
+// The events are replayed in the order of their time, at the original pace multiplied by the speed.
+package replay
+
+import (
+	"context"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/feed"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+// Timed is an event and the time it was received.
+type Timed[T any] struct {
+	Time  time.Time
+	Event T
+}
+
+type config struct {
+	speed float64
+}
+
+type Option func(*config)
+
+// The speed of the replay relative to the original pace (e.g: 60 replays an hour in a minute),
+// 0 replays every event immediately.
+// default: 1
+func WithSpeed(speed float64) Option {
+	return func(c *config) {
+		c.speed = speed
+	}
+}
+
+func newConfig(options ...Option) *config {
+	config := &config{speed: 1}
+	for _, opt := range options {
+		opt(config)
+	}
+	return config
+}
+
+// Producer returns a feed.Producer which replays events, the time between two events is divided by the speed.
+func Producer[T any](events []Timed[T], options ...Option) feed.Producer[T] {
+	var (
+		config = newConfig(options...)
+		sorted = slices.Clone(events)
+	)
+	slices.SortStableFunc(sorted, func(a, b Timed[T]) int { return a.Time.Compare(b.Time) })
+
+	return func(ctx context.Context, markets []string, emit func(event T) bool) {
+		var previous time.Time
+		for _, event := range sorted {
+			if config.speed > 0 && !previous.IsZero() {
+				if wait := time.Duration(float64(event.Time.Sub(previous)) / config.speed); wait > 0 {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(wait):
+					}
+				}
+			}
+			previous = event.Time
+
+			if !emit(event.Event) {
+				return
+			}
+		}
+	}
+}
+
+// NewTicker creates a ticker handler which replays events.
+func NewTicker(events []Timed[ws.TickerEvent], options ...Option) *feed.Handler[ws.TickerEvent] {
+	return feed.NewHandler(func(event ws.TickerEvent) string { return event.Market }, Producer(events, options...))
+}
+
+// NewTicker24h creates a ticker24h handler which replays events.
+func NewTicker24h(events []Timed[ws.Ticker24hEvent], options ...Option) *feed.Handler[ws.Ticker24hEvent] {
+	return feed.NewHandler(func(event ws.Ticker24hEvent) string { return event.Market }, Producer(events, options...))
+}
+
+// NewTrades creates a trades handler which replays events.
+func NewTrades(events []Timed[ws.TradesEvent], options ...Option) *feed.Handler[ws.TradesEvent] {
+	return feed.NewHandler(func(event ws.TradesEvent) string { return event.Market }, Producer(events, options...))
+}
+
+// Candles is a candles handler which replays the events of every interval.
+type Candles struct {
+	mu       sync.Mutex
+	handlers map[types.Interval]*feed.Handler[ws.CandlesEvent]
+	closed   map[types.Interval]feed.Producer[ws.CandlesEvent]
+}
+
+var _ ws.CandlesEventHandler = (*Candles)(nil)
+
+// NewCandles creates a candles handler which replays events, subscribing to an interval without events succeeds
+// but its channel is closed immediately.
+func NewCandles(events []Timed[ws.CandlesEvent], options ...Option) *Candles {
+	byInterval := make(map[types.Interval][]Timed[ws.CandlesEvent])
+	for _, event := range events {
+		byInterval[event.Event.Interval] = append(byInterval[event.Event.Interval], event)
+	}
+
+	candles := &Candles{
+		handlers: make(map[types.Interval]*feed.Handler[ws.CandlesEvent]),
+		closed:   make(map[types.Interval]feed.Producer[ws.CandlesEvent]),
+	}
+	for interval, events := range byInterval {
+		candles.handlers[interval] = feed.NewHandler(candlesMarket, Producer(events, options...))
+		candles.closed[interval] = Producer(closedCandles(events), options...)
+	}
+	return candles
+}
+
+func candlesMarket(event ws.CandlesEvent) string {
+	return event.Market
+}
+
+func (c *Candles) handler(interval types.Interval) *feed.Handler[ws.CandlesEvent] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	handler, found := c.handlers[interval]
+	if !found {
+		handler = feed.NewHandler(candlesMarket, Producer[ws.CandlesEvent](nil))
+		c.handlers[interval] = handler
+	}
+	return handler
+}
+
+func (c *Candles) Subscribe(markets []string, interval types.Interval, buffSize ...uint64) (<-chan ws.CandlesEvent, error) {
+	return c.handler(interval).Subscribe(markets, buffSize...)
+}
+
+func (c *Candles) SubscribeSeq(markets []string, interval types.Interval, buffSize ...uint64) (iter.Seq[ws.CandlesEvent], error) {
+	return c.handler(interval).SubscribeSeq(markets, buffSize...)
+}
+
+func (c *Candles) SubscribeGroups(groups []ws.MarketGroup, interval types.Interval, buffSize ...uint64) (<-chan ws.CandlesEvent, error) {
+	return c.handler(interval).SubscribeGroups(groups, buffSize...)
+}
+
+// SubscribeClosed only emits finalized candles, a candle is finalized once the first candle of the next period
+// has been replayed. The last candle of every market is emitted at the end of the replay.
+func (c *Candles) SubscribeClosed(markets []string, interval types.Interval, buffSize ...uint64) (<-chan ws.CandlesEvent, error) {
+	produce, found := c.closed[interval]
+	if !found {
+		produce = Producer[ws.CandlesEvent](nil)
+	}
+	return c.handler(interval).SubscribeFunc(markets, produce, buffSize...)
+}
+
+func (c *Candles) Reader(markets []string, interval types.Interval, buffSize ...uint64) (<-chan ws.CandlesEvent, error) {
+	return c.handler(interval).Reader(markets, buffSize...)
+}
+
+func (c *Candles) Unsubscribe(markets []string, interval types.Interval) error {
+	return c.handler(interval).Unsubscribe(markets)
+}
+
+func (c *Candles) UnsubscribeAll() error {
+	c.mu.Lock()
+	handlers := slices.Collect(maps.Values(c.handlers))
+	c.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := handler.UnsubscribeAll(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closedCandles returns the last update of every candle at the time the next candle of its market started,
+// followed by the last candle of every market.
+func closedCandles(events []Timed[ws.CandlesEvent]) []Timed[ws.CandlesEvent] {
+	sorted := slices.Clone(events)
+	slices.SortStableFunc(sorted, func(a, b Timed[ws.CandlesEvent]) int { return a.Time.Compare(b.Time) })
+
+	var (
+		closed  = make([]Timed[ws.CandlesEvent], 0, len(sorted))
+		pending = make(map[string]Timed[ws.CandlesEvent])
+		markets = make([]string, 0)
+	)
+	for _, event := range sorted {
+		market := event.Event.Market
+		last, found := pending[market]
+		if !found {
+			markets = append(markets, market)
+		} else if last.Event.Candle.Timestamp != event.Event.Candle.Timestamp {
+			closed = append(closed, Timed[ws.CandlesEvent]{Time: event.Time, Event: last.Event})
+		}
+		pending[market] = event
+	}
+
+	for _, market := range markets {
+		last := pending[market]
+		closed = append(closed, Timed[ws.CandlesEvent]{Time: last.Time, Event: last.Event})
+	}
+	slices.SortStableFunc(closed, func(a, b Timed[ws.CandlesEvent]) int { return a.Time.Compare(b.Time) })
+
+	return closed
+}
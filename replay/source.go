@@ -0,0 +1,198 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/larscom/go-bitvavo/v2/export"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+// ReadCandlesCSV reads the candles of market with interval from a CSV with a header row (e.g: written by export.Candles)
+//
+// The timestamp column is required and contains milliseconds since 1 Jan 1970 or RFC3339 times, missing price columns are empty.
+// A candle is replayed at the end of its period, which is when it would have been finalized.
+func ReadCandlesCSV(r io.Reader, market string, interval types.Interval) ([]Timed[ws.CandlesEvent], error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+	duration := interval.Duration()
+
+	return readCSV(r, func(timestamp time.Time, row map[string]string) (Timed[ws.CandlesEvent], error) {
+		candle := types.Candle{
+			Timestamp: timestamp.UnixMilli(),
+			OpenStr:   row[export.ColumnOpen],
+			HighStr:   row[export.ColumnHigh],
+			LowStr:    row[export.ColumnLow],
+			CloseStr:  row[export.ColumnClose],
+			VolumeStr: row[export.ColumnVolume],
+		}
+		var err error
+		if candle.Open, err = parseFloat(candle.OpenStr); err != nil {
+			return Timed[ws.CandlesEvent]{}, err
+		}
+		if candle.High, err = parseFloat(candle.HighStr); err != nil {
+			return Timed[ws.CandlesEvent]{}, err
+		}
+		if candle.Low, err = parseFloat(candle.LowStr); err != nil {
+			return Timed[ws.CandlesEvent]{}, err
+		}
+		if candle.Close, err = parseFloat(candle.CloseStr); err != nil {
+			return Timed[ws.CandlesEvent]{}, err
+		}
+		if candle.Volume, err = parseFloat(candle.VolumeStr); err != nil {
+			return Timed[ws.CandlesEvent]{}, err
+		}
+
+		return Timed[ws.CandlesEvent]{
+			Time:  timestamp.Add(duration),
+			Event: ws.CandlesEvent{Event: "candle", Market: market, Interval: interval, Candle: candle},
+		}, nil
+	})
+}
+
+// ReadTradesCSV reads the trades of market from a CSV with a header row (e.g: written by export.Trades)
+//
+// The timestamp column is required and contains milliseconds since 1 Jan 1970 or RFC3339 times.
+func ReadTradesCSV(r io.Reader, market string) ([]Timed[ws.TradesEvent], error) {
+	return readCSV(r, func(timestamp time.Time, row map[string]string) (Timed[ws.TradesEvent], error) {
+		trade := types.Trade{
+			Id:        row[export.ColumnId],
+			AmountStr: row[export.ColumnAmount],
+			PriceStr:  row[export.ColumnPrice],
+			Side:      types.Side(row[export.ColumnSide]),
+			Timestamp: timestamp.UnixMilli(),
+		}
+
+		var err error
+		if trade.Amount, err = parseFloat(trade.AmountStr); err != nil {
+			return Timed[ws.TradesEvent]{}, err
+		}
+		if trade.Price, err = parseFloat(trade.PriceStr); err != nil {
+			return Timed[ws.TradesEvent]{}, err
+		}
+
+		return Timed[ws.TradesEvent]{
+			Time:  timestamp,
+			Event: ws.TradesEvent{Event: "trade", Market: market, Trade: trade},
+		}, nil
+	})
+}
+
+// Frame is a websocket message in the format of Bitvavo and the time it was received.
+type Frame struct {
+	// Is a timestamp in milliseconds since 1 Jan 1970.
+	Timestamp int64 `json:"timestamp"`
+
+	// The message as received from the websocket.
+	Message json.RawMessage `json:"message"`
+}
+
+// ReadFrames reads recorded websocket messages, one JSON encoded Frame per line,
+// and decodes every message as T (e.g: ws.TickerEvent)
+func ReadFrames[T any](r io.Reader) ([]Timed[T], error) {
+	var (
+		scanner = bufio.NewScanner(r)
+		events  = make([]Timed[T], 0)
+		line    int
+	)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var frame Frame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		var event T
+		if err := json.Unmarshal(frame.Message, &event); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, Timed[T]{Time: time.UnixMilli(frame.Timestamp), Event: event})
+	}
+
+	return events, scanner.Err()
+}
+
+// WriteFrame writes message which was received at receivedAt as a single line, which can be read by ReadFrames.
+func WriteFrame(w io.Writer, receivedAt time.Time, message []byte) error {
+	bytes, err := json.Marshal(Frame{Timestamp: receivedAt.UnixMilli(), Message: message})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(bytes, '\n'))
+	return err
+}
+
+func readCSV[T any](r io.Reader, parse func(timestamp time.Time, row map[string]string) (T, error)) ([]T, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	index := -1
+	for i, column := range header {
+		if column == export.ColumnTimestamp {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, errors.New("missing timestamp column")
+	}
+
+	items := make([]T, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		timestamp, err := parseTimestamp(record[index])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[column] = record[i]
+		}
+
+		item, err := parse(timestamp, row)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		items = append(items, item)
+	}
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(millis), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func parseFloat(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,320 @@
+// Package risk checks every new or updated order against configurable limits before it's sent,
+// so orders which violate them are rejected locally instead of reaching the exchange.
+//
+// Only orders which are placed with the REST api are checked (including the decimal client of ToDecimalClient),
+// the websocket client of this module doesn't place orders.
+package risk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/util"
+)
+
+type Rule string
+
+const (
+	RuleBannedMarket   Rule = "bannedMarket"
+	RuleMaxNotional    Rule = "maxNotional"
+	RuleMaxOpenOrders  Rule = "maxOpenOrders"
+	RuleMaxDailyVolume Rule = "maxDailyVolume"
+)
+
+// RejectedError is returned whenever an order violates a limit, use errors.As to inspect it.
+type RejectedError struct {
+	// The limit which has been violated.
+	Rule Rule
+
+	// The market of the order (e.g: ETH-EUR)
+	Market string
+
+	// The value of the limit.
+	Limit float64
+
+	// The value the order would reach (e.g: its notional or the number of open orders including the order)
+	Value float64
+}
+
+func (e *RejectedError) Error() string {
+	if e.Rule == RuleBannedMarket {
+		return fmt.Sprintf("order rejected: market %s is banned", e.Market)
+	}
+	return fmt.Sprintf("order rejected: %s exceeded for market %s, limit=%g, value=%g", e.Rule, e.Market, e.Limit, e.Value)
+}
+
+// Limits which are enforced for every new order, a zero value disables the limit.
+type Limits struct {
+	// The maximum notional of a single order in quote currency (e.g: EUR)
+	MaxNotional float64
+
+	// The maximum number of open orders per market, including the new order.
+	MaxOpenOrders int
+
+	// The maximum notional in quote currency of all orders which are placed through the client per day (UTC)
+	MaxDailyVolume float64
+
+	// Markets in which no orders can be placed (e.g: ETH-EUR)
+	BannedMarkets []string
+}
+
+// Client places orders with the wrapped client after they passed the limits, every other method is passed through.
+// It's safe for concurrent use.
+type Client struct {
+	http.HttpClientAuth
+
+	public http.HttpClient
+	limits Limits
+	now    func() time.Time
+
+	mu     sync.Mutex
+	day    time.Time
+	volume float64
+}
+
+// NewClient creates a client which places orders with client after checking them against limits.
+// The public client is used to get the latest price of market orders with only an amount.
+func NewClient(client http.HttpClientAuth, public http.HttpClient, limits Limits) *Client {
+	return &Client{
+		HttpClientAuth: client,
+		public:         public,
+		limits:         limits,
+		now:            time.Now,
+	}
+}
+
+// Check returns a RejectedError if order violates a limit, without placing it.
+func (c *Client) Check(ctx context.Context, order types.OrderNew) error {
+	_, err := c.check(ctx, order)
+	return err
+}
+
+// DailyVolume returns the notional in quote currency of the orders which have been placed today (UTC)
+func (c *Client) DailyVolume() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resetVolumeIfExpired()
+	return c.volume
+}
+
+func (c *Client) NewOrder(market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	return c.NewOrderWithContext(context.Background(), market, side, orderType, order)
+}
+
+func (c *Client) NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	order.Market = market
+	order.Side = side
+	order.OrderType = orderType
+
+	notional, err := c.check(ctx, order)
+	if err != nil {
+		return types.Order{}, err
+	}
+	day, err := c.reserve(market, notional)
+	if err != nil {
+		return types.Order{}, err
+	}
+
+	placed, err := c.HttpClientAuth.NewOrderWithContext(ctx, market, side, orderType, order)
+	if err != nil {
+		c.release(day, notional)
+		return types.Order{}, err
+	}
+	return placed, nil
+}
+
+func (c *Client) UpdateOrder(market string, orderId string, order types.OrderUpdate) (types.Order, error) {
+	return c.UpdateOrderWithContext(context.Background(), market, orderId, order)
+}
+
+// UpdateOrderWithContext checks the notional of the order after the update before updating it,
+// an increase of the notional is added to the daily volume.
+func (c *Client) UpdateOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderUpdate) (types.Order, error) {
+	increase, err := c.checkUpdate(ctx, market, orderId, order)
+	if err != nil {
+		return types.Order{}, err
+	}
+	day, err := c.reserve(market, increase)
+	if err != nil {
+		return types.Order{}, err
+	}
+
+	updated, err := c.HttpClientAuth.UpdateOrderWithContext(ctx, market, orderId, order)
+	if err != nil {
+		c.release(day, increase)
+		return types.Order{}, err
+	}
+	return updated, nil
+}
+
+// ToDecimalClient returns a decimal client which checks its new and updated orders against the same limits.
+func (c *Client) ToDecimalClient() http.HttpClientAuthDec {
+	return &decimalClient{HttpClientAuthDec: c.HttpClientAuth.ToDecimalClient(), client: c}
+}
+
+func (c *Client) NewOrders(orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error) {
+	return c.NewOrdersWithContext(context.Background(), orders, failFast...)
+}
+
+func (c *Client) NewOrdersWithContext(ctx context.Context, orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error) {
+	return http.NewOrdersWith(ctx, orders, len(failFast) > 0 && failFast[0], func(ctx context.Context, order types.OrderNew) (types.Order, error) {
+		return c.NewOrderWithContext(ctx, order.Market, order.Side, order.OrderType, order)
+	})
+}
+
+func (c *Client) ReplaceOrder(market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	return c.ReplaceOrderWithContext(context.Background(), market, orderId, order, reduceByFilled...)
+}
+
+func (c *Client) ReplaceOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	return http.ReplaceOrderWith(ctx, c, market, orderId, order, len(reduceByFilled) > 0 && reduceByFilled[0])
+}
+
+// check returns the notional of order if it passes every limit which can be checked without reserving.
+func (c *Client) check(ctx context.Context, order types.OrderNew) (float64, error) {
+	if slices.Contains(c.limits.BannedMarkets, order.Market) {
+		return 0, &RejectedError{Rule: RuleBannedMarket, Market: order.Market}
+	}
+
+	var notional float64
+	if c.limits.MaxNotional > 0 || c.limits.MaxDailyVolume > 0 {
+		n, err := c.notional(ctx, order)
+		if err != nil {
+			return 0, err
+		}
+		notional = n
+	}
+
+	if c.limits.MaxNotional > 0 && notional > c.limits.MaxNotional {
+		return 0, &RejectedError{Rule: RuleMaxNotional, Market: order.Market, Limit: c.limits.MaxNotional, Value: notional}
+	}
+
+	if c.limits.MaxDailyVolume > 0 {
+		if volume := c.DailyVolume() + notional; volume > c.limits.MaxDailyVolume {
+			return 0, &RejectedError{Rule: RuleMaxDailyVolume, Market: order.Market, Limit: c.limits.MaxDailyVolume, Value: volume}
+		}
+	}
+
+	if c.limits.MaxOpenOrders > 0 {
+		open, err := c.GetOrdersOpenWithContext(ctx, order.Market)
+		if err != nil {
+			return 0, err
+		}
+		if count := len(open) + 1; count > c.limits.MaxOpenOrders {
+			return 0, &RejectedError{Rule: RuleMaxOpenOrders, Market: order.Market, Limit: float64(c.limits.MaxOpenOrders), Value: float64(count)}
+		}
+	}
+
+	return notional, nil
+}
+
+// checkUpdate returns the increase of the notional of the order with orderId if the update passes every limit
+// which can be checked without reserving, the order is fetched to complete the fields which aren't updated.
+func (c *Client) checkUpdate(ctx context.Context, market string, orderId string, update types.OrderUpdate) (float64, error) {
+	if slices.Contains(c.limits.BannedMarkets, market) {
+		return 0, &RejectedError{Rule: RuleBannedMarket, Market: market}
+	}
+	if c.limits.MaxNotional <= 0 && c.limits.MaxDailyVolume <= 0 {
+		return 0, nil
+	}
+
+	current, err := c.GetOrderWithContext(ctx, market, orderId)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		before   = updatedNotional(current, types.OrderUpdate{})
+		notional = updatedNotional(current, update)
+		increase = max(0, notional-before)
+	)
+
+	if c.limits.MaxNotional > 0 && notional > c.limits.MaxNotional {
+		return 0, &RejectedError{Rule: RuleMaxNotional, Market: market, Limit: c.limits.MaxNotional, Value: notional}
+	}
+	if c.limits.MaxDailyVolume > 0 {
+		if volume := c.DailyVolume() + increase; volume > c.limits.MaxDailyVolume {
+			return 0, &RejectedError{Rule: RuleMaxDailyVolume, Market: market, Limit: c.limits.MaxDailyVolume, Value: volume}
+		}
+	}
+	return increase, nil
+}
+
+// updatedNotional returns the value in quote currency of the remaining amount of order after update.
+func updatedNotional(order types.Order, update types.OrderUpdate) float64 {
+	if update.AmountQuote > 0 {
+		return update.AmountQuote
+	}
+
+	remaining := order.AmountRemaining
+	if update.AmountRemaining > 0 {
+		remaining = update.AmountRemaining
+	} else if update.Amount > 0 {
+		remaining = max(0, update.Amount-order.FilledAmount)
+	}
+
+	price := order.Price
+	if update.Price > 0 {
+		price = update.Price
+	} else if price == 0 {
+		price = util.IfOrElse(update.TriggerAmount > 0, func() float64 { return update.TriggerAmount }, order.TriggerAmount)
+	}
+	return remaining * price
+}
+
+// notional returns the value of order in quote currency, market orders with only an amount are valued at the latest price.
+func (c *Client) notional(ctx context.Context, order types.OrderNew) (float64, error) {
+	if order.AmountQuote > 0 {
+		return order.AmountQuote, nil
+	}
+	if order.Price > 0 {
+		return order.Amount * order.Price, nil
+	}
+	if order.TriggerAmount > 0 {
+		return order.Amount * order.TriggerAmount, nil
+	}
+
+	ticker, err := c.public.GetTickerPriceWithContext(ctx, order.Market)
+	if err != nil {
+		return 0, err
+	}
+	return order.Amount * ticker.Price, nil
+}
+
+// reserve adds notional to the daily volume of the returned day, unless it would exceed the limit in the meantime.
+func (c *Client) reserve(market string, notional float64) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resetVolumeIfExpired()
+	if c.limits.MaxDailyVolume > 0 && c.volume+notional > c.limits.MaxDailyVolume {
+		return c.day, &RejectedError{Rule: RuleMaxDailyVolume, Market: market, Limit: c.limits.MaxDailyVolume, Value: c.volume + notional}
+	}
+	c.volume += notional
+	return c.day, nil
+}
+
+// release subtracts the notional of an order which failed to be placed from the daily volume of day.
+func (c *Client) release(day time.Time, notional float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resetVolumeIfExpired()
+	if day.Equal(c.day) {
+		c.volume = max(0, c.volume-notional)
+	}
+}
+
+func (c *Client) resetVolumeIfExpired() {
+	day := c.now().UTC().Truncate(24 * time.Hour)
+	if !day.Equal(c.day) {
+		c.day = day
+		c.volume = 0
+	}
+}
@@ -0,0 +1,74 @@
+package risk
+
+import (
+	"context"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/typesdec"
+)
+
+// decimalClient checks the orders of the decimal client against the limits of client.
+type decimalClient struct {
+	http.HttpClientAuthDec
+
+	client *Client
+}
+
+func (d *decimalClient) NewOrder(market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error) {
+	return d.NewOrderWithContext(context.Background(), market, side, orderType, order)
+}
+
+func (d *decimalClient) NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order typesdec.OrderNew) (typesdec.Order, error) {
+	notional, err := d.client.check(ctx, types.OrderNew{
+		Market:        market,
+		Side:          side,
+		OrderType:     orderType,
+		Amount:        order.Amount.InexactFloat64(),
+		Price:         order.Price.InexactFloat64(),
+		AmountQuote:   order.AmountQuote.InexactFloat64(),
+		TriggerAmount: order.TriggerAmount.InexactFloat64(),
+	})
+	if err != nil {
+		return typesdec.Order{}, err
+	}
+	day, err := d.client.reserve(market, notional)
+	if err != nil {
+		return typesdec.Order{}, err
+	}
+
+	placed, err := d.HttpClientAuthDec.NewOrderWithContext(ctx, market, side, orderType, order)
+	if err != nil {
+		d.client.release(day, notional)
+		return typesdec.Order{}, err
+	}
+	return placed, nil
+}
+
+func (d *decimalClient) UpdateOrder(market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error) {
+	return d.UpdateOrderWithContext(context.Background(), market, orderId, order)
+}
+
+func (d *decimalClient) UpdateOrderWithContext(ctx context.Context, market string, orderId string, order typesdec.OrderUpdate) (typesdec.Order, error) {
+	increase, err := d.client.checkUpdate(ctx, market, orderId, types.OrderUpdate{
+		Amount:          order.Amount.InexactFloat64(),
+		AmountQuote:     order.AmountQuote.InexactFloat64(),
+		AmountRemaining: order.AmountRemaining.InexactFloat64(),
+		Price:           order.Price.InexactFloat64(),
+		TriggerAmount:   order.TriggerAmount.InexactFloat64(),
+	})
+	if err != nil {
+		return typesdec.Order{}, err
+	}
+	day, err := d.client.reserve(market, increase)
+	if err != nil {
+		return typesdec.Order{}, err
+	}
+
+	updated, err := d.HttpClientAuthDec.UpdateOrderWithContext(ctx, market, orderId, order)
+	if err != nil {
+		d.client.release(day, increase)
+		return typesdec.Order{}, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,287 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/orderbook"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// The number of decimals of a shrunk amount.
+const shrinkDecimals = 8
+
+// Estimate is the expected execution of a market order against the book.
+type Estimate struct {
+	// The mid price of the book.
+	Mid float64
+
+	// The expected average fill price.
+	Price float64
+
+	// How far price is away from mid, relative to mid (e.g: 0.002 for 0.2%)
+	Slippage float64
+
+	// The amount in base currency which can be filled.
+	Amount float64
+
+	// The amount in quote currency which can be filled.
+	AmountQuote float64
+
+	// Whether the book is deep enough to fill the whole order.
+	Complete bool
+}
+
+// SlippageError is returned whenever the estimated slippage of a market order exceeds the maximum, use errors.As to inspect it.
+type SlippageError struct {
+	// The market of the order (e.g: ETH-EUR)
+	Market string
+
+	// The side of the order.
+	Side types.Side
+
+	// The maximum slippage.
+	MaxSlippage float64
+
+	// The estimated execution of the order.
+	Estimate Estimate
+}
+
+func (e *SlippageError) Error() string {
+	if !e.Estimate.Complete {
+		return fmt.Sprintf("order rejected: book of market %s is not deep enough, filled=%g, price=%g, mid=%g",
+			e.Market, e.Estimate.Amount, e.Estimate.Price, e.Estimate.Mid)
+	}
+	return fmt.Sprintf("order rejected: slippage exceeded for market %s, max=%g, slippage=%g, price=%g, mid=%g",
+		e.Market, e.MaxSlippage, e.Estimate.Slippage, e.Estimate.Price, e.Estimate.Mid)
+}
+
+// SlippageGuard estimates the fill price of market orders before they are placed with the wrapped client,
+// every other order and method is passed through. It's safe for concurrent use.
+type SlippageGuard struct {
+	http.HttpClientAuth
+
+	public      http.HttpClient
+	maxSlippage float64
+	books       func(market string) (*orderbook.Book, bool)
+	depth       []uint64
+	shrink      bool
+}
+
+type SlippageOption func(*SlippageGuard)
+
+// Consult local books (e.g: kept up to date by the websocket) instead of fetching the book for every market order,
+// whenever books returns false the book is fetched.
+// default: fetch the book
+func WithBooks(books func(market string) (*orderbook.Book, bool)) SlippageOption {
+	return func(g *SlippageGuard) {
+		g.books = books
+	}
+}
+
+// The depth of the fetched book.
+// default: the whole book
+func WithBookDepth(depth uint64) SlippageOption {
+	return func(g *SlippageGuard) {
+		g.depth = []uint64{depth}
+	}
+}
+
+// Shrink the amount of a market order to what can be filled within the maximum slippage instead of rejecting it,
+// the amount is rounded down to 8 decimals.
+// default: reject
+func WithShrink() SlippageOption {
+	return func(g *SlippageGuard) {
+		g.shrink = true
+	}
+}
+
+// NewSlippageGuard creates a guard which rejects market orders whose estimated fill price is more than maxSlippage
+// (e.g: 0.005 for 0.5%) away from the mid price before placing them with client.
+// The public client is used to fetch the book.
+func NewSlippageGuard(client http.HttpClientAuth, public http.HttpClient, maxSlippage float64, options ...SlippageOption) *SlippageGuard {
+	guard := &SlippageGuard{
+		HttpClientAuth: client,
+		public:         public,
+		maxSlippage:    maxSlippage,
+	}
+	for _, opt := range options {
+		opt(guard)
+	}
+	return guard
+}
+
+// Estimate returns the expected execution of the market order against the book of its market.
+func (g *SlippageGuard) Estimate(ctx context.Context, order types.OrderNew) (Estimate, error) {
+	book, err := g.book(ctx, order.Market)
+	if err != nil {
+		return Estimate{}, err
+	}
+	return estimate(book, order), nil
+}
+
+func (g *SlippageGuard) NewOrder(market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	return g.NewOrderWithContext(context.Background(), market, side, orderType, order)
+}
+
+func (g *SlippageGuard) NewOrderWithContext(ctx context.Context, market string, side types.Side, orderType types.OrderType, order types.OrderNew) (types.Order, error) {
+	if orderType != types.OrderTypeMarket {
+		return g.HttpClientAuth.NewOrderWithContext(ctx, market, side, orderType, order)
+	}
+
+	order.Market = market
+	order.Side = side
+	order.OrderType = orderType
+
+	book, err := g.book(ctx, market)
+	if err != nil {
+		return types.Order{}, err
+	}
+
+	if estimate := estimate(book, order); !estimate.Complete || estimate.Slippage > g.maxSlippage {
+		if !g.shrink {
+			return types.Order{}, &SlippageError{Market: market, Side: side, MaxSlippage: g.maxSlippage, Estimate: estimate}
+		}
+		if order, err = g.shrinkOrder(book, order, estimate); err != nil {
+			return types.Order{}, err
+		}
+	}
+
+	return g.HttpClientAuth.NewOrderWithContext(ctx, market, side, orderType, order)
+}
+
+func (g *SlippageGuard) NewOrders(orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error) {
+	return g.NewOrdersWithContext(context.Background(), orders, failFast...)
+}
+
+func (g *SlippageGuard) NewOrdersWithContext(ctx context.Context, orders []types.OrderNew, failFast ...bool) ([]http.OrderResult, error) {
+	return http.NewOrdersWith(ctx, orders, len(failFast) > 0 && failFast[0], func(ctx context.Context, order types.OrderNew) (types.Order, error) {
+		return g.NewOrderWithContext(ctx, order.Market, order.Side, order.OrderType, order)
+	})
+}
+
+func (g *SlippageGuard) ReplaceOrder(market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	return g.ReplaceOrderWithContext(context.Background(), market, orderId, order, reduceByFilled...)
+}
+
+func (g *SlippageGuard) ReplaceOrderWithContext(ctx context.Context, market string, orderId string, order types.OrderNew, reduceByFilled ...bool) (http.ReplaceResult, error) {
+	return http.ReplaceOrderWith(ctx, g, market, orderId, order, len(reduceByFilled) > 0 && reduceByFilled[0])
+}
+
+// shrinkOrder returns order with the amount which can be filled within the maximum slippage.
+func (g *SlippageGuard) shrinkOrder(book *orderbook.Book, order types.OrderNew, estimate Estimate) (types.OrderNew, error) {
+	var (
+		levels = book.Asks()
+		limit  = estimate.Mid * (1 + g.maxSlippage)
+	)
+	if order.Side == types.SideSell {
+		levels = book.Bids()
+		limit = estimate.Mid * (1 - g.maxSlippage)
+	}
+
+	amount, amountQuote := within(levels, limit, order.Side)
+	if order.AmountQuote > 0 {
+		order.AmountQuote = floor(min(order.AmountQuote, amountQuote))
+	} else {
+		order.Amount = floor(min(order.Amount, amount))
+	}
+
+	if order.Amount <= 0 && order.AmountQuote <= 0 {
+		return order, &SlippageError{Market: order.Market, Side: order.Side, MaxSlippage: g.maxSlippage, Estimate: estimate}
+	}
+	return order, nil
+}
+
+func (g *SlippageGuard) book(ctx context.Context, market string) (*orderbook.Book, error) {
+	if g.books != nil {
+		if book, found := g.books(market); found && book.Synced() {
+			return book, nil
+		}
+	}
+
+	snapshot, err := g.public.GetOrderBookWithContext(ctx, market, g.depth...)
+	if err != nil {
+		return nil, err
+	}
+	book := orderbook.New(market)
+	book.Snapshot(snapshot)
+	return book, nil
+}
+
+// estimate returns the expected execution of the market order against book.
+func estimate(book *orderbook.Book, order types.OrderNew) Estimate {
+	mid, found := book.Mid()
+	if !found {
+		return Estimate{}
+	}
+
+	levels := book.Asks()
+	if order.Side == types.SideSell {
+		levels = book.Bids()
+	}
+
+	estimate := Estimate{Mid: mid}
+	estimate.Amount, estimate.AmountQuote = walk(levels, order.Amount, order.AmountQuote)
+	if estimate.Amount > 0 {
+		estimate.Price = estimate.AmountQuote / estimate.Amount
+		estimate.Slippage = math.Abs(estimate.Price-mid) / mid
+	}
+	if order.AmountQuote > 0 {
+		estimate.Complete = estimate.AmountQuote >= order.AmountQuote
+	} else {
+		estimate.Complete = estimate.Amount >= order.Amount
+	}
+	return estimate
+}
+
+// walk returns the amount in base and quote currency which is filled by taking levels until amount (base)
+// or amountQuote (quote) is reached.
+func walk(levels []types.Page, amount float64, amountQuote float64) (float64, float64) {
+	var base, quote float64
+	for _, level := range levels {
+		size := level.Size
+		if amountQuote > 0 {
+			size = min(size, (amountQuote-quote)/level.Price)
+		} else {
+			size = min(size, amount-base)
+		}
+		if size <= 0 {
+			break
+		}
+		base += size
+		quote += size * level.Price
+	}
+	return base, quote
+}
+
+// within returns the largest amount in base and quote currency which is filled by taking levels
+// with an average price which doesn't cross limit (above limit for asks, below limit for bids)
+func within(levels []types.Page, limit float64, side types.Side) (float64, float64) {
+	var base, quote float64
+	for _, level := range levels {
+		crosses := level.Price > limit
+		if side == types.SideSell {
+			crosses = level.Price < limit
+		}
+		if !crosses {
+			base += level.Size
+			quote += level.Size * level.Price
+			continue
+		}
+
+		// solve (quote + size * price) / (base + size) = limit
+		if size := min(level.Size, (limit*base-quote)/(level.Price-limit)); size > 0 {
+			base += size
+			quote += size * level.Price
+		}
+		break
+	}
+	return base, quote
+}
+
+func floor(value float64) float64 {
+	factor := math.Pow10(shrinkDecimals)
+	return math.Floor(value*factor) / factor
+}
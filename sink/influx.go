@@ -0,0 +1,174 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+// Influx writes the ticker, candle and trade events in line protocol to InfluxDB or any other line protocol endpoint
+// (e.g: Telegraf or VictoriaMetrics), the events of the other streams are skipped.
+//
+// The measurements are ticker (tag: market), candles (tags: market, interval) and trades (tags: market, side)
+// with the timestamps in milliseconds.
+type Influx struct {
+	url        string
+	token      string
+	httpclient *http.Client
+}
+
+var _ BatchSink = (*Influx)(nil)
+
+type InfluxOption func(*Influx)
+
+// The token which is sent in the Authorization header.
+// default: no token
+func WithInfluxToken(token string) InfluxOption {
+	return func(i *Influx) {
+		i.token = token
+	}
+}
+
+// The http.Client which sends the requests.
+// default: http.DefaultClient
+func WithInfluxHTTPClient(httpclient *http.Client) InfluxOption {
+	return func(i *Influx) {
+		i.httpclient = httpclient
+	}
+}
+
+// NewInflux creates a sink which posts to url, the write endpoint with millisecond precision
+// (e.g: http://localhost:8086/api/v2/write?org=my-org&bucket=bitvavo&precision=ms)
+func NewInflux(url string, options ...InfluxOption) *Influx {
+	influx := &Influx{
+		url:        url,
+		httpclient: http.DefaultClient,
+	}
+	for _, opt := range options {
+		opt(influx)
+	}
+	return influx
+}
+
+func (i *Influx) Write(ctx context.Context, event Event) error {
+	return i.WriteBatch(ctx, []Event{event})
+}
+
+func (i *Influx) WriteBatch(ctx context.Context, events []Event) error {
+	var body bytes.Buffer
+	for _, event := range events {
+		if line, ok := encodeLine(event); ok {
+			body.WriteString(line)
+			body.WriteByte('\n')
+		}
+	}
+	if body.Len() == 0 {
+		return nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, i.url, &body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if i.token != "" {
+		request.Header.Set("Authorization", fmt.Sprintf("Token %s", i.token))
+	}
+
+	response, err := i.httpclient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		bytes, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("did not get OK response, code=%d, body=%s", response.StatusCode, string(bytes))
+	}
+	return nil
+}
+
+// encodeLine returns event in line protocol, false if its stream isn't supported.
+func encodeLine(event Event) (string, bool) {
+	switch payload := event.Payload.(type) {
+	case ws.TickerEvent:
+		var (
+			ticker = payload.Ticker
+			fields = make([]string, 0, 5)
+		)
+		// the ticker only contains the values which have changed
+		if ticker.BestBidStr != "" {
+			fields = append(fields, floatField("bestBid", ticker.BestBid))
+		}
+		if ticker.BestBidSizeStr != "" {
+			fields = append(fields, floatField("bestBidSize", ticker.BestBidSize))
+		}
+		if ticker.BestAskStr != "" {
+			fields = append(fields, floatField("bestAsk", ticker.BestAsk))
+		}
+		if ticker.BestAskSizeStr != "" {
+			fields = append(fields, floatField("bestAskSize", ticker.BestAskSize))
+		}
+		if ticker.LastPriceStr != "" {
+			fields = append(fields, floatField("lastPrice", ticker.LastPrice))
+		}
+		if len(fields) == 0 {
+			return "", false
+		}
+		return line("ticker", []string{tag("market", payload.Market)}, fields, event.Time.UnixMilli()), true
+	case ws.CandlesEvent:
+		candle := payload.Candle
+		return line(
+			"candles",
+			[]string{tag("interval", payload.Interval.String()), tag("market", payload.Market)},
+			[]string{
+				floatField("open", candle.Open),
+				floatField("high", candle.High),
+				floatField("low", candle.Low),
+				floatField("close", candle.Close),
+				floatField("volume", candle.Volume),
+			},
+			candle.Timestamp,
+		), true
+	case ws.TradesEvent:
+		trade := payload.Trade
+		return line(
+			"trades",
+			[]string{tag("market", payload.Market), tag("side", string(trade.Side))},
+			[]string{
+				floatField("price", trade.Price),
+				floatField("amount", trade.Amount),
+				stringField("id", trade.Id),
+			},
+			trade.Timestamp,
+		), true
+	default:
+		return "", false
+	}
+}
+
+func line(measurement string, tags []string, fields []string, timestamp int64) string {
+	return fmt.Sprintf("%s,%s %s %d", measurement, strings.Join(tags, ","), strings.Join(fields, ","), timestamp)
+}
+
+var tagEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+func tag(key string, value string) string {
+	return fmt.Sprintf("%s=%s", key, tagEscaper.Replace(value))
+}
+
+func floatField(key string, value float64) string {
+	return fmt.Sprintf("%s=%s", key, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+var stringEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`)
+
+func stringField(key string, value string) string {
+	return fmt.Sprintf(`%s="%s"`, key, stringEscaper.Replace(value))
+}
@@ -0,0 +1,264 @@
+package sink
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultBatchSize     = 100
+	defaultFlushInterval = time.Second
+	defaultRetries       = 3
+	defaultBackoff       = time.Second
+	defaultBuffSize      = 1000
+)
+
+// Pump reads the event streams which are added to it and writes their events in batches to a sink.
+//
+// A batch is written whenever it's full or the flush interval has passed, a batch which still fails after
+// the retries is dropped and passed to the error handler.
+type Pump struct {
+	sink          EventSink
+	batchSize     int
+	flushInterval time.Duration
+	retries       int
+	backoff       time.Duration
+	buffSize      int
+	onError       func(events []Event, err error)
+
+	mu        sync.Mutex
+	eventch   chan Event
+	ctx       context.Context
+	cancel    context.CancelFunc
+	sources   sync.WaitGroup
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type Option func(*Pump)
+
+// The maximum number of events which are written at once.
+// default: 100
+func WithBatchSize(batchSize int) Option {
+	return func(p *Pump) {
+		p.batchSize = max(batchSize, 1)
+	}
+}
+
+// The interval in which a batch which isn't full is written.
+// default: 1s
+func WithFlushInterval(interval time.Duration) Option {
+	return func(p *Pump) {
+		p.flushInterval = interval
+	}
+}
+
+// The number of times a failed batch is retried, the wait between retries starts at backoff and doubles every retry.
+// default: 3 retries, 1s backoff
+func WithRetry(retries int, backoff time.Duration) Option {
+	return func(p *Pump) {
+		p.retries = retries
+		p.backoff = backoff
+	}
+}
+
+// The number of events which are buffered for the sink, the streams block whenever it's full.
+// default: 1000
+func WithBuffSize(buffSize int) Option {
+	return func(p *Pump) {
+		p.buffSize = buffSize
+	}
+}
+
+// Handle the batches which still failed after the retries (e.g: to write them somewhere else)
+// default: the error is logged
+func WithErrorHandler(onError func(events []Event, err error)) Option {
+	return func(p *Pump) {
+		p.onError = onError
+	}
+}
+
+// NewPump creates a pump which writes to sink, add streams with the methods of the pump.
+func NewPump(sink EventSink, options ...Option) *Pump {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pump := &Pump{
+		sink:          sink,
+		batchSize:     defaultBatchSize,
+		flushInterval: defaultFlushInterval,
+		retries:       defaultRetries,
+		backoff:       defaultBackoff,
+		buffSize:      defaultBuffSize,
+		onError: func(events []Event, err error) {
+			log.Error().Err(err).Int("events", len(events)).Msg("Failed to write events to the sink, dropping them")
+		},
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	for _, opt := range options {
+		opt(pump)
+	}
+	pump.eventch = make(chan Event, pump.buffSize)
+
+	go pump.run()
+
+	return pump
+}
+
+// Ticker pumps the events of tickerchn until it's closed or the pump is closed.
+func (p *Pump) Ticker(tickerchn <-chan ws.TickerEvent) {
+	pipe(p, StreamTicker, tickerchn, func(event ws.TickerEvent) string { return event.Market })
+}
+
+// Ticker24h pumps the events of ticker24hchn until it's closed or the pump is closed.
+func (p *Pump) Ticker24h(ticker24hchn <-chan ws.Ticker24hEvent) {
+	pipe(p, StreamTicker24h, ticker24hchn, func(event ws.Ticker24hEvent) string { return event.Market })
+}
+
+// Candles pumps the events of candlechn until it's closed or the pump is closed.
+func (p *Pump) Candles(candlechn <-chan ws.CandlesEvent) {
+	pipe(p, StreamCandles, candlechn, func(event ws.CandlesEvent) string { return event.Market })
+}
+
+// Trades pumps the events of tradechn until it's closed or the pump is closed.
+func (p *Pump) Trades(tradechn <-chan ws.TradesEvent) {
+	pipe(p, StreamTrades, tradechn, func(event ws.TradesEvent) string { return event.Market })
+}
+
+// Book pumps the events of bookchn until it's closed or the pump is closed.
+func (p *Pump) Book(bookchn <-chan ws.BookEvent) {
+	pipe(p, StreamBook, bookchn, func(event ws.BookEvent) string { return event.Market })
+}
+
+// Orders pumps the events of orderchn until it's closed or the pump is closed.
+func (p *Pump) Orders(orderchn <-chan ws.OrderEvent) {
+	pipe(p, StreamOrders, orderchn, func(event ws.OrderEvent) string { return event.Market })
+}
+
+// Fills pumps the events of fillchn until it's closed or the pump is closed.
+func (p *Pump) Fills(fillchn <-chan ws.FillEvent) {
+	pipe(p, StreamFills, fillchn, func(event ws.FillEvent) string { return event.Market })
+}
+
+// Close stops reading the streams and waits until the buffered events have been written.
+// The streams aren't unsubscribed, so they should be unsubscribed or drained afterwards.
+func (p *Pump) Close() error {
+	p.closeOnce.Do(func() {
+		p.mu.Lock()
+		p.cancel()
+		p.mu.Unlock()
+
+		p.sources.Wait()
+		close(p.eventch)
+	})
+	<-p.done
+	return nil
+}
+
+func pipe[T any](p *Pump, stream Stream, chn <-chan T, market func(event T) string) {
+	// no streams are added while closing, so the events channel isn't closed before every stream stopped
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ctx.Err() != nil {
+		return
+	}
+
+	p.sources.Add(1)
+	go func() {
+		defer p.sources.Done()
+		for {
+			select {
+			case <-p.ctx.Done():
+				return
+			case event, ok := <-chn:
+				if !ok {
+					return
+				}
+				select {
+				case p.eventch <- Event{Stream: stream, Market: market(event), Time: time.Now(), Payload: event}:
+				case <-p.ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+}
+
+func (p *Pump) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, p.batchSize)
+	for {
+		select {
+		case event, ok := <-p.eventch:
+			if !ok {
+				p.flush(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= p.batchSize {
+				p.flush(batch)
+				batch = make([]Event, 0, p.batchSize)
+			}
+		case <-ticker.C:
+			p.flush(batch)
+			batch = make([]Event, 0, p.batchSize)
+		}
+	}
+}
+
+// flush writes batch to the sink and retries the events which haven't been written with backoff whenever it fails.
+func (p *Pump) flush(batch []Event) {
+	if len(batch) == 0 {
+		return
+	}
+
+	var (
+		err     error
+		backoff = p.backoff
+		pending = batch
+	)
+	for attempt := 0; attempt <= p.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		var written int
+		written, err = p.write(pending)
+		pending = pending[written:]
+		if err == nil {
+			return
+		}
+		log.Warn().Err(err).Int("attempt", attempt+1).Msg("Failed to write events to the sink")
+	}
+	p.onError(pending, err)
+}
+
+// write writes events to the sink and returns the number of events which have been written.
+func (p *Pump) write(events []Event) (int, error) {
+	// the writes aren't bound to the pump, so the last batch is still written while closing
+	ctx := context.Background()
+
+	if sink, ok := p.sink.(BatchSink); ok {
+		if err := sink.WriteBatch(ctx, events); err != nil {
+			return 0, err
+		}
+		return len(events), nil
+	}
+
+	for i, event := range events {
+		if err := p.sink.Write(ctx, event); err != nil {
+			return i, err
+		}
+	}
+	return len(events), nil
+}
@@ -0,0 +1,93 @@
+// Package sink pumps the event streams of the websocket into sinks (e.g: Kafka, NATS or a file) with batching and retries,
+// so data pipelines can persist market data with a few lines.
+package sink
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+type Stream string
+
+const (
+	StreamTicker    Stream = "ticker"
+	StreamTicker24h Stream = "ticker24h"
+	StreamCandles   Stream = "candles"
+	StreamTrades    Stream = "trades"
+	StreamBook      Stream = "book"
+	StreamOrders    Stream = "orders"
+	StreamFills     Stream = "fills"
+)
+
+type Event struct {
+	// The stream the event was received from.
+	Stream Stream `json:"stream"`
+
+	// The market of the event (e.g: ETH-EUR)
+	Market string `json:"market"`
+
+	// The time the event was received.
+	Time time.Time `json:"time"`
+
+	// The event itself (e.g: ws.TickerEvent for StreamTicker)
+	Payload any `json:"payload"`
+}
+
+// EventSink writes events to a destination (e.g: a Kafka topic)
+type EventSink interface {
+	// Write writes a single event, an error causes a retry of the event.
+	Write(ctx context.Context, event Event) error
+}
+
+// BatchSink is an EventSink which can write multiple events at once, the pump writes whole batches to it.
+type BatchSink interface {
+	EventSink
+
+	// WriteBatch writes events at once, an error causes a retry of all events.
+	WriteBatch(ctx context.Context, events []Event) error
+}
+
+// SinkFunc is a function which implements EventSink.
+type SinkFunc func(ctx context.Context, event Event) error
+
+func (f SinkFunc) Write(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// JSONLines writes every event as a single line of JSON (e.g: to a file)
+type JSONLines struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+var _ BatchSink = (*JSONLines)(nil)
+
+// NewJSONLines creates a sink which writes to w.
+func NewJSONLines(w io.Writer) *JSONLines {
+	return &JSONLines{w: w}
+}
+
+func (j *JSONLines) Write(ctx context.Context, event Event) error {
+	return j.WriteBatch(ctx, []Event{event})
+}
+
+func (j *JSONLines) WriteBatch(ctx context.Context, events []Event) error {
+	lines := make([]byte, 0)
+	for _, event := range events {
+		bytes, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		lines = append(append(lines, bytes...), '\n')
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, err := j.w.Write(lines)
+	return err
+}
@@ -0,0 +1,364 @@
+// Package sqlitejournal appends every order and fill event of the account stream to an embedded SQLite database,
+// so small bots get a durable history without running a database server.
+//
+// The journal works with any SQLite driver for database/sql (e.g: modernc.org/sqlite), which is registered by your application.
+package sqlitejournal
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS orders (
+		id                  INTEGER PRIMARY KEY AUTOINCREMENT,
+		received_at         INTEGER NOT NULL,
+		market              TEXT NOT NULL,
+		order_id            TEXT NOT NULL,
+		client_order_id     TEXT NOT NULL,
+		created             INTEGER NOT NULL,
+		updated             INTEGER NOT NULL,
+		status              TEXT NOT NULL,
+		side                TEXT NOT NULL,
+		order_type          TEXT NOT NULL,
+		amount              TEXT NOT NULL,
+		amount_remaining    TEXT NOT NULL,
+		price               TEXT NOT NULL,
+		trigger_price       TEXT NOT NULL,
+		time_in_force       TEXT NOT NULL,
+		post_only           INTEGER NOT NULL,
+		filled_amount       TEXT NOT NULL,
+		filled_amount_quote TEXT NOT NULL,
+		fee_paid            TEXT NOT NULL,
+		fee_currency        TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS orders_market_received_at ON orders (market, received_at)`,
+	`CREATE INDEX IF NOT EXISTS orders_order_id ON orders (order_id)`,
+	`CREATE TABLE IF NOT EXISTS fills (
+		id           INTEGER PRIMARY KEY AUTOINCREMENT,
+		received_at  INTEGER NOT NULL,
+		market       TEXT NOT NULL,
+		fill_id      TEXT NOT NULL UNIQUE,
+		order_id     TEXT NOT NULL,
+		timestamp    INTEGER NOT NULL,
+		side         TEXT NOT NULL,
+		amount       TEXT NOT NULL,
+		price        TEXT NOT NULL,
+		taker        INTEGER NOT NULL,
+		fee          TEXT NOT NULL,
+		fee_currency TEXT NOT NULL,
+		settled      INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS fills_market_timestamp ON fills (market, timestamp)`,
+	`CREATE INDEX IF NOT EXISTS fills_order_id ON fills (order_id)`,
+}
+
+const (
+	orderColumns = `id, received_at, market, order_id, client_order_id, created, updated, status, side, order_type, amount,
+		amount_remaining, price, trigger_price, time_in_force, post_only, filled_amount, filled_amount_quote, fee_paid, fee_currency`
+
+	fillColumns = `id, received_at, market, fill_id, order_id, timestamp, side, amount, price, taker, fee, fee_currency, settled`
+)
+
+// Entry is an event which has been appended to the journal.
+type Entry[T any] struct {
+	// The id of the entry, which increases with every appended event.
+	Id int64
+
+	// The time the event was appended.
+	ReceivedAt time.Time
+
+	// The event itself.
+	Event T
+}
+
+// Query filters the entries of the journal, every empty field matches all entries.
+type Query struct {
+	// The market (e.g: ETH-EUR)
+	Market string
+
+	// The id of the order.
+	OrderId string
+
+	// Only entries which have been received at or after since.
+	Since time.Time
+
+	// Only entries which have been received before until.
+	Until time.Time
+
+	// The maximum number of entries, the oldest entries are returned first.
+	Limit int
+}
+
+// Journal is an append-only log of order and fill events. It's safe for concurrent use.
+type Journal struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// New creates the tables of the journal in db if they don't exist yet.
+func New(ctx context.Context, db *sql.DB) (*Journal, error) {
+	for _, statement := range schema {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return nil, err
+		}
+	}
+	return &Journal{db: db, now: time.Now}, nil
+}
+
+// AppendOrder appends an order event, every update of an order is a new entry.
+func (j *Journal) AppendOrder(ctx context.Context, event ws.OrderEvent) error {
+	order := event.Order
+	_, err := j.db.ExecContext(ctx, `INSERT INTO orders (`+strings.Replace(orderColumns, "id, ", "", 1)+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		j.now().UnixMilli(),
+		event.Market,
+		order.OrderId,
+		order.ClientOrderId,
+		order.Created,
+		order.Updated,
+		string(order.Status),
+		string(order.Side),
+		string(order.OrderType),
+		order.AmountStr,
+		order.AmountRemainingStr,
+		order.PriceStr,
+		order.TriggerPriceStr,
+		string(order.TimeInForce),
+		order.PostOnly,
+		order.FilledAmountStr,
+		order.FilledAmountQuoteStr,
+		order.FeePaidStr,
+		order.FeeCurrency,
+	)
+	return err
+}
+
+// AppendFill appends a fill event, a fill which has already been appended is ignored.
+func (j *Journal) AppendFill(ctx context.Context, event ws.FillEvent) error {
+	fill := event.Fill
+	_, err := j.db.ExecContext(ctx, `INSERT OR IGNORE INTO fills (`+strings.Replace(fillColumns, "id, ", "", 1)+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		j.now().UnixMilli(),
+		event.Market,
+		fill.FillId,
+		fill.OrderId,
+		fill.Timestamp,
+		string(fill.Side),
+		fill.AmountStr,
+		fill.PriceStr,
+		fill.Taker,
+		fill.FeeStr,
+		fill.FeeCurrency,
+		fill.Settled,
+	)
+	return err
+}
+
+// Consume appends every event of the account stream until both channels are closed or ctx is done,
+// failures are logged so the stream keeps being consumed.
+func (j *Journal) Consume(ctx context.Context, orderchn <-chan ws.OrderEvent, fillchn <-chan ws.FillEvent) {
+	for orderchn != nil || fillchn != nil {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-orderchn:
+			if !ok {
+				orderchn = nil
+				continue
+			}
+			if err := j.AppendOrder(ctx, event); err != nil {
+				log.Err(err).Str("orderId", event.Order.OrderId).Msg("Failed to append order to the journal")
+			}
+		case event, ok := <-fillchn:
+			if !ok {
+				fillchn = nil
+				continue
+			}
+			if err := j.AppendFill(ctx, event); err != nil {
+				log.Err(err).Str("fillId", event.Fill.FillId).Msg("Failed to append fill to the journal")
+			}
+		}
+	}
+}
+
+// Orders returns the order events which match query, oldest first.
+func (j *Journal) Orders(ctx context.Context, query Query) ([]Entry[ws.OrderEvent], error) {
+	where, args := query.where()
+	rows, err := j.db.QueryContext(ctx, "SELECT "+orderColumns+" FROM orders"+where+" ORDER BY id"+query.limit(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll(rows, scanOrder)
+}
+
+// LatestOrders returns the last known state of every order of market (e.g: ETH-EUR), or of every market if market is empty.
+func (j *Journal) LatestOrders(ctx context.Context, market string) ([]types.Order, error) {
+	where, args := Query{Market: market}.where()
+	rows, err := j.db.QueryContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE id IN (SELECT max(id) FROM orders"+where+" GROUP BY order_id) ORDER BY id", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := scanAll(rows, scanOrder)
+	if err != nil {
+		return nil, err
+	}
+	orders := make([]types.Order, len(entries))
+	for i, entry := range entries {
+		orders[i] = entry.Event.Order
+	}
+	return orders, nil
+}
+
+// Fills returns the fill events which match query, oldest first.
+func (j *Journal) Fills(ctx context.Context, query Query) ([]Entry[ws.FillEvent], error) {
+	where, args := query.where()
+	rows, err := j.db.QueryContext(ctx, "SELECT "+fillColumns+" FROM fills"+where+" ORDER BY id"+query.limit(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll(rows, scanFill)
+}
+
+func (q Query) where() (string, []any) {
+	var (
+		conditions = make([]string, 0)
+		args       = make([]any, 0)
+	)
+	if q.Market != "" {
+		conditions = append(conditions, "market = ?")
+		args = append(args, q.Market)
+	}
+	if q.OrderId != "" {
+		conditions = append(conditions, "order_id = ?")
+		args = append(args, q.OrderId)
+	}
+	if !q.Since.IsZero() {
+		conditions = append(conditions, "received_at >= ?")
+		args = append(args, q.Since.UnixMilli())
+	}
+	if !q.Until.IsZero() {
+		conditions = append(conditions, "received_at < ?")
+		args = append(args, q.Until.UnixMilli())
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (q Query) limit() string {
+	if q.Limit <= 0 {
+		return ""
+	}
+	return " LIMIT " + strconv.Itoa(q.Limit)
+}
+
+func scanAll[T any](rows *sql.Rows, scan func(rows *sql.Rows) (Entry[T], error)) ([]Entry[T], error) {
+	defer rows.Close()
+
+	entries := make([]Entry[T], 0)
+	for rows.Next() {
+		entry, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func scanOrder(rows *sql.Rows) (Entry[ws.OrderEvent], error) {
+	var (
+		entry      Entry[ws.OrderEvent]
+		receivedAt int64
+		order      types.Order
+	)
+	if err := rows.Scan(
+		&entry.Id,
+		&receivedAt,
+		&entry.Event.Market,
+		&order.OrderId,
+		&order.ClientOrderId,
+		&order.Created,
+		&order.Updated,
+		&order.Status,
+		&order.Side,
+		&order.OrderType,
+		&order.AmountStr,
+		&order.AmountRemainingStr,
+		&order.PriceStr,
+		&order.TriggerPriceStr,
+		&order.TimeInForce,
+		&order.PostOnly,
+		&order.FilledAmountStr,
+		&order.FilledAmountQuoteStr,
+		&order.FeePaidStr,
+		&order.FeeCurrency,
+	); err != nil {
+		return entry, err
+	}
+
+	order.Market = entry.Event.Market
+	order.Amount = parseFloat(order.AmountStr)
+	order.AmountRemaining = parseFloat(order.AmountRemainingStr)
+	order.Price = parseFloat(order.PriceStr)
+	order.TriggerPrice = parseFloat(order.TriggerPriceStr)
+	order.FilledAmount = parseFloat(order.FilledAmountStr)
+	order.FilledAmountQuote = parseFloat(order.FilledAmountQuoteStr)
+	order.FeePaid = parseFloat(order.FeePaidStr)
+
+	entry.ReceivedAt = time.UnixMilli(receivedAt)
+	entry.Event.Event = "order"
+	entry.Event.Order = order
+	return entry, nil
+}
+
+func scanFill(rows *sql.Rows) (Entry[ws.FillEvent], error) {
+	var (
+		entry      Entry[ws.FillEvent]
+		receivedAt int64
+		fill       types.Fill
+	)
+	if err := rows.Scan(
+		&entry.Id,
+		&receivedAt,
+		&entry.Event.Market,
+		&fill.FillId,
+		&fill.OrderId,
+		&fill.Timestamp,
+		&fill.Side,
+		&fill.AmountStr,
+		&fill.PriceStr,
+		&fill.Taker,
+		&fill.FeeStr,
+		&fill.FeeCurrency,
+		&fill.Settled,
+	); err != nil {
+		return entry, err
+	}
+
+	fill.Amount = parseFloat(fill.AmountStr)
+	fill.Price = parseFloat(fill.PriceStr)
+	fill.Fee = parseFloat(fill.FeeStr)
+
+	entry.ReceivedAt = time.UnixMilli(receivedAt)
+	entry.Event.Event = "fill"
+	entry.Event.Fill = fill
+	return entry, nil
+}
+
+// parseFloat parses a number which has been stored as its original string value, an empty value is 0.
+func parseFloat(value string) float64 {
+	f, _ := strconv.ParseFloat(value, 64)
+	return f
+}
@@ -0,0 +1,76 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// MarketColumns are the columns written by WriteMarketsCSV.
+	MarketColumns = []string{"market", "fills", "bought", "boughtQuote", "sold", "soldQuote", "fees"}
+
+	// AssetColumns are the columns written by WriteAssetsCSV.
+	AssetColumns = []string{"symbol", "tradedIn", "tradedOut", "deposited", "withdrawn", "fees", "net"}
+)
+
+// WriteJSON writes the whole statement to w as indented JSON.
+func (s Statement) WriteJSON(w io.Writer) error {
+	bytes, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bytes)
+	return err
+}
+
+// WriteMarketsCSV writes the totals per market to w as CSV, with MarketColumns as the first row.
+func (s Statement) WriteMarketsCSV(w io.Writer) error {
+	rows := make([][]string, 0, len(s.Markets))
+	for _, totals := range s.Markets {
+		rows = append(rows, []string{
+			totals.Market,
+			strconv.Itoa(totals.Fills),
+			formatFloat(totals.Bought),
+			formatFloat(totals.BoughtQuote),
+			formatFloat(totals.Sold),
+			formatFloat(totals.SoldQuote),
+			formatFloat(totals.Fees),
+		})
+	}
+	return writeCSV(w, MarketColumns, rows)
+}
+
+// WriteAssetsCSV writes the totals per asset to w as CSV, with AssetColumns as the first row.
+func (s Statement) WriteAssetsCSV(w io.Writer) error {
+	rows := make([][]string, 0, len(s.Assets))
+	for _, totals := range s.Assets {
+		rows = append(rows, []string{
+			totals.Symbol,
+			formatFloat(totals.TradedIn),
+			formatFloat(totals.TradedOut),
+			formatFloat(totals.Deposited),
+			formatFloat(totals.Withdrawn),
+			formatFloat(totals.Fees),
+			formatFloat(totals.Net),
+		})
+	}
+	return writeCSV(w, AssetColumns, rows)
+}
+
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
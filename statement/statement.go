@@ -0,0 +1,263 @@
+// Package statement aggregates the fills, fees, deposits and withdrawals of your account within a date range
+// into a statement with totals per market and per asset, which can be written as JSON or CSV for bookkeeping.
+package statement
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+const (
+	// The number of items which is requested per page.
+	pageLimit = 1000
+
+	// The status of a deposit or withdrawal which didn't happen.
+	statusCanceled = "canceled"
+)
+
+// Fill is a fill of one of your orders.
+type Fill struct {
+	// The market of the fill (e.g: ETH-EUR)
+	Market string `json:"market"`
+
+	// The fill itself.
+	Fill types.Fill `json:"fill"`
+}
+
+// MarketTotals are the totals of the fills of a single market.
+type MarketTotals struct {
+	// The market (e.g: ETH-EUR)
+	Market string `json:"market"`
+
+	// The number of fills.
+	Fills int `json:"fills"`
+
+	// The amount in base currency which has been bought.
+	Bought float64 `json:"bought"`
+
+	// The amount in quote currency which has been paid for Bought.
+	BoughtQuote float64 `json:"boughtQuote"`
+
+	// The amount in base currency which has been sold.
+	Sold float64 `json:"sold"`
+
+	// The amount in quote currency which has been received for Sold.
+	SoldQuote float64 `json:"soldQuote"`
+
+	// The fees in quote currency, fees paid in base currency are converted with the price of the fill.
+	Fees float64 `json:"fees"`
+}
+
+// AssetTotals are the totals of every movement of a single asset.
+type AssetTotals struct {
+	// The short name of the asset (e.g: BTC)
+	Symbol string `json:"symbol"`
+
+	// The amount which has been received by trading.
+	TradedIn float64 `json:"tradedIn"`
+
+	// The amount which has been paid by trading.
+	TradedOut float64 `json:"tradedOut"`
+
+	// The amount which has been deposited.
+	Deposited float64 `json:"deposited"`
+
+	// The amount which has been withdrawn.
+	Withdrawn float64 `json:"withdrawn"`
+
+	// The fees paid in this asset (trading, deposit and withdrawal fees)
+	Fees float64 `json:"fees"`
+
+	// The change of the balance: TradedIn - TradedOut + Deposited - Withdrawn - Fees
+	Net float64 `json:"net"`
+}
+
+// Statement is the activity of your account within a date range.
+type Statement struct {
+	// The start of the range (inclusive)
+	Start time.Time `json:"start"`
+
+	// The end of the range (exclusive)
+	End time.Time `json:"end"`
+
+	// The totals per market, sorted by market.
+	Markets []MarketTotals `json:"markets"`
+
+	// The totals per asset, sorted by symbol.
+	Assets []AssetTotals `json:"assets"`
+
+	// Every fill, oldest first.
+	Fills []Fill `json:"fills"`
+
+	// Every deposit which hasn't been canceled, oldest first.
+	Deposits []types.DepositHistory `json:"deposits"`
+
+	// Every withdrawal which hasn't been canceled, oldest first.
+	Withdrawals []types.WithdrawalHistory `json:"withdrawals"`
+}
+
+// Generate fetches the fills of markets (e.g: ETH-EUR) and every deposit and withdrawal between start and end with client
+// and aggregates them into a statement. Every history is requested in pages, so ranges of any length are supported.
+func Generate(ctx context.Context, client http.HttpClientAuth, start time.Time, end time.Time, markets []string) (Statement, error) {
+	statement := Statement{Start: start, End: end}
+
+	for _, market := range markets {
+		trades, err := fetchPages(start, end, func(end time.Time) ([]types.TradeHistoric, error) {
+			return client.GetTradesWithContext(ctx, market, &types.TradeParams{Start: start, End: end, Limit: pageLimit})
+		}, func(trade types.TradeHistoric) int64 { return trade.Timestamp })
+		if err != nil {
+			return Statement{}, fmt.Errorf("failed to get trades of market %s: %w", market, err)
+		}
+		for _, trade := range trades {
+			statement.Fills = append(statement.Fills, Fill{Market: market, Fill: types.Fill(trade)})
+		}
+	}
+
+	deposits, err := fetchPages(start, end, func(end time.Time) ([]types.DepositHistory, error) {
+		return client.GetDepositHistoryWithContext(ctx, &types.DepositHistoryParams{Start: start, End: end, Limit: pageLimit})
+	}, func(deposit types.DepositHistory) int64 { return deposit.Timestamp })
+	if err != nil {
+		return Statement{}, fmt.Errorf("failed to get deposit history: %w", err)
+	}
+	for _, deposit := range deposits {
+		if deposit.Status != statusCanceled {
+			statement.Deposits = append(statement.Deposits, deposit)
+		}
+	}
+
+	withdrawals, err := fetchPages(start, end, func(end time.Time) ([]types.WithdrawalHistory, error) {
+		return client.GetWithdrawalHistoryWithContext(ctx, &types.WithdrawalHistoryParams{Start: start, End: end, Limit: pageLimit})
+	}, func(withdrawal types.WithdrawalHistory) int64 { return withdrawal.Timestamp })
+	if err != nil {
+		return Statement{}, fmt.Errorf("failed to get withdrawal history: %w", err)
+	}
+	for _, withdrawal := range withdrawals {
+		if withdrawal.Status != statusCanceled {
+			statement.Withdrawals = append(statement.Withdrawals, withdrawal)
+		}
+	}
+
+	statement.aggregate()
+	return statement, nil
+}
+
+// aggregate sorts the fills and calculates the totals per market and per asset.
+func (s *Statement) aggregate() {
+	slices.SortStableFunc(s.Fills, func(a, b Fill) int {
+		return cmp.Compare(a.Fill.Timestamp, b.Fill.Timestamp)
+	})
+
+	var (
+		markets = make(map[string]*MarketTotals)
+		assets  = make(map[string]*AssetTotals)
+	)
+	asset := func(symbol string) *AssetTotals {
+		totals, found := assets[symbol]
+		if !found {
+			totals = &AssetTotals{Symbol: symbol}
+			assets[symbol] = totals
+		}
+		return totals
+	}
+
+	for _, item := range s.Fills {
+		fill := item.Fill
+		totals, found := markets[item.Market]
+		if !found {
+			totals = &MarketTotals{Market: item.Market}
+			markets[item.Market] = totals
+		}
+
+		var (
+			base, quote, _ = strings.Cut(item.Market, "-")
+			amountQuote    = fill.Amount * fill.Price
+		)
+		totals.Fills++
+		if fill.Side == types.SideBuy {
+			totals.Bought += fill.Amount
+			totals.BoughtQuote += amountQuote
+			asset(base).TradedIn += fill.Amount
+			asset(quote).TradedOut += amountQuote
+		} else {
+			totals.Sold += fill.Amount
+			totals.SoldQuote += amountQuote
+			asset(base).TradedOut += fill.Amount
+			asset(quote).TradedIn += amountQuote
+		}
+
+		switch fill.FeeCurrency {
+		case quote:
+			totals.Fees += fill.Fee
+		case base:
+			totals.Fees += fill.Fee * fill.Price
+		}
+		if fill.FeeCurrency != "" {
+			asset(fill.FeeCurrency).Fees += fill.Fee
+		}
+	}
+
+	for _, deposit := range s.Deposits {
+		totals := asset(deposit.Symbol)
+		totals.Deposited += deposit.Amount
+		totals.Fees += deposit.Fee
+	}
+	for _, withdrawal := range s.Withdrawals {
+		totals := asset(withdrawal.Symbol)
+		totals.Withdrawn += withdrawal.Amount
+		totals.Fees += withdrawal.Fee
+	}
+
+	s.Markets = make([]MarketTotals, 0, len(markets))
+	for _, totals := range markets {
+		s.Markets = append(s.Markets, *totals)
+	}
+	slices.SortFunc(s.Markets, func(a, b MarketTotals) int {
+		return cmp.Compare(a.Market, b.Market)
+	})
+
+	s.Assets = make([]AssetTotals, 0, len(assets))
+	for _, totals := range assets {
+		totals.Net = totals.TradedIn - totals.TradedOut + totals.Deposited - totals.Withdrawn - totals.Fees
+		s.Assets = append(s.Assets, *totals)
+	}
+	slices.SortFunc(s.Assets, func(a, b AssetTotals) int {
+		return cmp.Compare(a.Symbol, b.Symbol)
+	})
+}
+
+// fetchPages fetches every item between start and end oldest first, by requesting pages which end
+// before the oldest item of the previous page until a page isn't full.
+func fetchPages[T any](start time.Time, end time.Time, fetch func(end time.Time) ([]T, error), timestamp func(item T) int64) ([]T, error) {
+	items := make([]T, 0)
+	for {
+		page, err := fetch(end)
+		if err != nil {
+			return nil, err
+		}
+
+		oldest := end.UnixMilli()
+		for _, item := range page {
+			if ts := timestamp(item); ts >= start.UnixMilli() && ts < end.UnixMilli() {
+				items = append(items, item)
+				oldest = min(oldest, ts)
+			}
+		}
+
+		if len(page) < pageLimit || oldest >= end.UnixMilli() || !time.UnixMilli(oldest).After(start) {
+			break
+		}
+		end = time.UnixMilli(oldest)
+	}
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		return cmp.Compare(timestamp(a), timestamp(b))
+	})
+	return items, nil
+}
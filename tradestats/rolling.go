@@ -0,0 +1,198 @@
+// Package tradestats computes statistics (e.g: the rolling 24h high and low or the VWAP) per market from the trades stream,
+// so they are updated on every trade instead of being polled.
+package tradestats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+type Stats struct {
+	// The market (e.g: ETH-EUR)
+	Market string `json:"market"`
+
+	// The price of the first trade in the window.
+	Open float64 `json:"open"`
+
+	// The highest price in the window.
+	High float64 `json:"high"`
+
+	// The lowest price in the window.
+	Low float64 `json:"low"`
+
+	// The price of the last trade in the window.
+	Last float64 `json:"last"`
+
+	// The volume in base currency of the window.
+	Volume float64 `json:"volume"`
+
+	// The volume in quote currency of the window.
+	VolumeQuote float64 `json:"volumeQuote"`
+
+	// The volume weighted average price of the window.
+	VWAP float64 `json:"vwap"`
+
+	// The number of trades in the window.
+	Trades int `json:"trades"`
+
+	// The time of the first trade in the window.
+	Start time.Time `json:"start"`
+
+	// The time of the last trade in the window.
+	End time.Time `json:"end"`
+}
+
+// Rolling keeps the statistics of the trades of every market within a rolling window (e.g: the last 24 hours)
+// It's safe for concurrent use.
+type Rolling struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	markets map[string]*rollingMarket
+}
+
+type rollingMarket struct {
+	trades      []types.Trade
+	volume      float64
+	volumeQuote float64
+
+	// the indexes (relative to offset) of the trades which can still become the high or low of the window,
+	// the prices of maxima are decreasing and the prices of minima are increasing
+	maxima []int
+	minima []int
+	offset int
+}
+
+// NewRolling creates statistics over the trades of the last window (e.g: 24h)
+func NewRolling(window time.Duration) *Rolling {
+	return &Rolling{
+		window:  window,
+		now:     time.Now,
+		markets: make(map[string]*rollingMarket),
+	}
+}
+
+// Backfill adds the trades of market within the window, so the statistics are complete immediately.
+func (r *Rolling) Backfill(ctx context.Context, client http.HttpClient, market string) error {
+	end := r.now()
+	trades, err := client.GetTradesRangeWithContext(ctx, market, end.Add(-r.window), end)
+	if err != nil {
+		return err
+	}
+
+	for _, trade := range trades {
+		r.Add(market, trade)
+	}
+	return nil
+}
+
+// Consume adds every trade of tradechn until it's closed.
+func (r *Rolling) Consume(tradechn <-chan ws.TradesEvent) {
+	for event := range tradechn {
+		r.Add(event.Market, event.Trade)
+	}
+}
+
+// Add adds trade of market, trades which are older than the last trade of market are ignored.
+func (r *Rolling) Add(market string, trade types.Trade) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, found := r.markets[market]
+	if !found {
+		m = new(rollingMarket)
+		r.markets[market] = m
+	}
+	if len(m.trades) > 0 && trade.Timestamp < m.trades[len(m.trades)-1].Timestamp {
+		return
+	}
+
+	index := m.offset + len(m.trades)
+	m.trades = append(m.trades, trade)
+	m.volume += trade.Amount
+	m.volumeQuote += trade.Amount * trade.Price
+
+	for len(m.maxima) > 0 && m.price(m.maxima[len(m.maxima)-1]) <= trade.Price {
+		m.maxima = m.maxima[:len(m.maxima)-1]
+	}
+	m.maxima = append(m.maxima, index)
+	for len(m.minima) > 0 && m.price(m.minima[len(m.minima)-1]) >= trade.Price {
+		m.minima = m.minima[:len(m.minima)-1]
+	}
+	m.minima = append(m.minima, index)
+
+	m.expire(r.now().Add(-r.window).UnixMilli())
+}
+
+// Stats returns the statistics of market over the window, false if market has no trades within the window.
+func (r *Rolling) Stats(market string) (Stats, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, found := r.markets[market]
+	if !found {
+		return Stats{}, false
+	}
+	m.expire(r.now().Add(-r.window).UnixMilli())
+	if len(m.trades) == 0 {
+		return Stats{}, false
+	}
+
+	var (
+		first = m.trades[0]
+		last  = m.trades[len(m.trades)-1]
+	)
+	stats := Stats{
+		Market:      market,
+		Open:        first.Price,
+		High:        m.price(m.maxima[0]),
+		Low:         m.price(m.minima[0]),
+		Last:        last.Price,
+		Volume:      m.volume,
+		VolumeQuote: m.volumeQuote,
+		Trades:      len(m.trades),
+		Start:       first.Time(),
+		End:         last.Time(),
+	}
+	if stats.Volume > 0 {
+		stats.VWAP = stats.VolumeQuote / stats.Volume
+	}
+	return stats, true
+}
+
+func (m *rollingMarket) price(index int) float64 {
+	return m.trades[index-m.offset].Price
+}
+
+// expire removes the trades before cutoff (in milliseconds)
+func (m *rollingMarket) expire(cutoff int64) {
+	expired := 0
+	for expired < len(m.trades) && m.trades[expired].Timestamp < cutoff {
+		m.volume -= m.trades[expired].Amount
+		m.volumeQuote -= m.trades[expired].Amount * m.trades[expired].Price
+		expired++
+	}
+	if expired == 0 {
+		return
+	}
+
+	m.trades = m.trades[expired:]
+	m.offset += expired
+	for len(m.maxima) > 0 && m.maxima[0] < m.offset {
+		m.maxima = m.maxima[1:]
+	}
+	for len(m.minima) > 0 && m.minima[0] < m.offset {
+		m.minima = m.minima[1:]
+	}
+	if len(m.trades) == 0 {
+		// prevents the sums from drifting because of rounding errors
+		m.volume = 0
+		m.volumeQuote = 0
+	}
+}
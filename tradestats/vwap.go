@@ -0,0 +1,182 @@
+package tradestats
+
+import (
+	"sync"
+	"time"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+)
+
+const defaultBuffSize = 50
+
+type VWAPUpdate struct {
+	// The market (e.g: ETH-EUR)
+	Market string `json:"market"`
+
+	// The volume weighted average price since the start of the session.
+	Session float64 `json:"session"`
+
+	// The volume in base currency since the start of the session.
+	SessionVolume float64 `json:"sessionVolume"`
+
+	// The volume weighted average price within the window, 0 without a window.
+	Window float64 `json:"window"`
+
+	// The volume in base currency within the window, 0 without a window.
+	WindowVolume float64 `json:"windowVolume"`
+
+	// The time of the trade which caused the update.
+	Time time.Time `json:"time"`
+}
+
+// VWAP computes the volume weighted average price of every market since the start of a session (e.g: an execution)
+// and within a rolling window, to benchmark the prices of executions against. It's safe for concurrent use.
+type VWAP struct {
+	window   *Rolling
+	buffSize int
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	start    time.Time
+
+	updates chan VWAPUpdate
+	closed  bool
+}
+
+type session struct {
+	volume      float64
+	volumeQuote float64
+}
+
+type Option func(*VWAP)
+
+// Compute the VWAP within a rolling window (e.g: 1h) as well.
+// default: no window
+func WithWindow(window time.Duration) Option {
+	return func(v *VWAP) {
+		v.window = NewRolling(window)
+	}
+}
+
+// The size of the buffer of the updates channel, an update is dropped whenever the buffer is full.
+// default: 50
+func WithBuffSize(buffSize int) Option {
+	return func(v *VWAP) {
+		v.buffSize = buffSize
+	}
+}
+
+// NewVWAP creates a VWAP calculator, the session of every market starts with its first trade.
+func NewVWAP(options ...Option) *VWAP {
+	vwap := &VWAP{
+		buffSize: defaultBuffSize,
+		sessions: make(map[string]*session),
+	}
+	for _, opt := range options {
+		opt(vwap)
+	}
+	vwap.updates = make(chan VWAPUpdate, vwap.buffSize)
+
+	return vwap
+}
+
+// Consume adds every trade of tradechn until it's closed.
+func (v *VWAP) Consume(tradechn <-chan ws.TradesEvent) {
+	for event := range tradechn {
+		v.Add(event.Market, event.Trade)
+	}
+}
+
+// Add adds trade of market, trades before the start of the session are ignored.
+func (v *VWAP) Add(market string, trade types.Trade) {
+	if v.window != nil {
+		v.window.Add(market, trade)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.closed || trade.Timestamp < v.start.UnixMilli() {
+		return
+	}
+
+	s, found := v.sessions[market]
+	if !found {
+		s = new(session)
+		v.sessions[market] = s
+	}
+	s.volume += trade.Amount
+	s.volumeQuote += trade.Amount * trade.Price
+
+	update := VWAPUpdate{
+		Market:        market,
+		SessionVolume: s.volume,
+		Time:          trade.Time(),
+	}
+	if s.volume > 0 {
+		update.Session = s.volumeQuote / s.volume
+	}
+	if v.window != nil {
+		if stats, found := v.window.Stats(market); found {
+			update.Window = stats.VWAP
+			update.WindowVolume = stats.Volume
+		}
+	}
+
+	select {
+	case v.updates <- update:
+	default:
+	}
+}
+
+// Session returns the VWAP of market since the start of the session, false if market has no trades in the session.
+func (v *VWAP) Session(market string) (float64, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	s, found := v.sessions[market]
+	if !found || s.volume == 0 {
+		return 0, false
+	}
+	return s.volumeQuote / s.volume, true
+}
+
+// Window returns the VWAP of market within the window, false without a window or trades within the window.
+func (v *VWAP) Window(market string) (float64, bool) {
+	if v.window == nil {
+		return 0, false
+	}
+
+	stats, found := v.window.Stats(market)
+	if !found || stats.Volume == 0 {
+		return 0, false
+	}
+	return stats.VWAP, true
+}
+
+// Reset starts a new session for every market at start, trades before start are ignored.
+func (v *VWAP) Reset(start time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.start = start
+	clear(v.sessions)
+}
+
+// Updates returns the channel which receives the VWAP of a market after each of its trades, it's closed by Close.
+func (v *VWAP) Updates() <-chan VWAPUpdate {
+	return v.updates
+}
+
+// Close closes the updates channel, trades are ignored afterwards.
+func (v *VWAP) Close() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.closed {
+		v.closed = true
+		close(v.updates)
+	}
+	return nil
+}
@@ -20,22 +20,94 @@ type Fee struct {
 	Volume float64 `json:"volume"`
 }
 
+func (f Fee) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newFeeJSON(f, nil))
+}
+
 func (f *Fee) UnmarshalJSON(bytes []byte) error {
-	var j map[string]string
+	var j map[string]any
+
+	if err := json.Unmarshal(bytes, &j); err != nil {
+		return err
+	}
+
+	fee := object{data: j}
+	f.read(&fee)
+
+	return fee.err
+}
+
+// read reads the taker, maker and volume of fee.
+func (f *Fee) read(fee *object) {
+	f.Taker, _ = fee.number("taker")
+	f.Maker, _ = fee.number("maker")
+	f.Volume, _ = fee.number("volume")
+}
+
+type MarketFee struct {
+	// Your fee tier, which depends on your trading volume in the last 30 days.
+	Tier int64 `json:"tier"`
+
+	Fee
+}
+
+func (m MarketFee) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newFeeJSON(m.Fee, &m.Tier))
+}
+
+func (m *MarketFee) UnmarshalJSON(bytes []byte) error {
+	var j map[string]any
 
 	if err := json.Unmarshal(bytes, &j); err != nil {
 		return err
 	}
 
-	var (
-		taker  = j["taker"]
-		maker  = j["maker"]
-		volume = j["volume"]
-	)
+	fee := object{data: j}
+	m.Tier = fee.int("tier")
+	m.Fee.read(&fee)
+
+	return fee.err
+}
+
+type FeeEstimate struct {
+	// The fee rate which applies (e.g: 0.0025 for 0.25%)
+	Rate float64 `json:"rate"`
+
+	// The value of the order in quote currency (amount * price)
+	Value float64 `json:"value"`
 
-	f.Taker = util.IfOrElse(len(taker) > 0, func() float64 { return util.MustFloat64(taker) }, 0)
-	f.Maker = util.IfOrElse(len(maker) > 0, func() float64 { return util.MustFloat64(maker) }, 0)
-	f.Volume = util.IfOrElse(len(volume) > 0, func() float64 { return util.MustFloat64(volume) }, 0)
+	// The expected fee in quote currency.
+	Fee float64 `json:"fee"`
+
+	// The expected total in quote currency, which is the value plus the fee for a buy and the value minus the fee for a sell.
+	Total float64 `json:"total"`
+}
 
-	return nil
+// Estimate returns the expected fee of an order of amount at price, with the taker fee if taker is true or else the maker fee.
+func (f Fee) Estimate(side Side, amount float64, price float64, taker bool) FeeEstimate {
+	estimate := FeeEstimate{
+		Rate:  util.IfOrElse(taker, func() float64 { return f.Taker }, f.Maker),
+		Value: amount * price,
+	}
+	estimate.Fee = estimate.Value * estimate.Rate
+	estimate.Total = util.IfOrElse(side == SideSell, func() float64 { return estimate.Value - estimate.Fee }, estimate.Value+estimate.Fee)
+
+	return estimate
+}
+
+// feeJSON is the format in which Bitvavo sends the fees of the account or a market.
+type feeJSON struct {
+	Tier   *int64 `json:"tier,omitempty"`
+	Taker  string `json:"taker"`
+	Maker  string `json:"maker"`
+	Volume string `json:"volume"`
+}
+
+func newFeeJSON(fee Fee, tier *int64) feeJSON {
+	return feeJSON{
+		Tier:   tier,
+		Taker:  formatRequiredNumber("", fee.Taker),
+		Maker:  formatRequiredNumber("", fee.Maker),
+		Volume: formatRequiredNumber("", fee.Volume),
+	}
 }
@@ -40,6 +40,34 @@ type Asset struct {
 	Message string `json:"message"`
 }
 
+func (m Asset) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Symbol               string   `json:"symbol"`
+		Name                 string   `json:"name"`
+		Decimals             int64    `json:"decimals"`
+		DepositFee           string   `json:"depositFee,omitempty"`
+		DepositConfirmations int64    `json:"depositConfirmations"`
+		DepositStatus        string   `json:"depositStatus,omitempty"`
+		WithdrawalFee        string   `json:"withdrawalFee,omitempty"`
+		WithdrawalMinAmount  string   `json:"withdrawalMinAmount,omitempty"`
+		WithdrawalStatus     string   `json:"withdrawalStatus,omitempty"`
+		Networks             []string `json:"networks"`
+		Message              string   `json:"message,omitempty"`
+	}{
+		Symbol:               m.Symbol,
+		Name:                 m.Name,
+		Decimals:             m.Decimals,
+		DepositFee:           formatNumber("", m.DepositFee),
+		DepositConfirmations: m.DepositConfirmations,
+		DepositStatus:        m.DepositStatus,
+		WithdrawalFee:        formatNumber("", m.WithdrawalFee),
+		WithdrawalMinAmount:  formatNumber("", m.WithdrawalMinAmount),
+		WithdrawalStatus:     m.WithdrawalStatus,
+		Networks:             m.Networks,
+		Message:              m.Message,
+	})
+}
+
 func (m *Asset) UnmarshalJSON(bytes []byte) error {
 	var j map[string]any
 
@@ -80,3 +108,39 @@ func (m *Asset) UnmarshalJSON(bytes []byte) error {
 
 	return nil
 }
+
+type WithdrawalCost struct {
+	// Short version of the asset name.
+	Symbol string `json:"symbol"`
+
+	// The requested amount.
+	Amount float64 `json:"amount"`
+
+	// Fixed fee for withdrawing this asset.
+	Fee float64 `json:"fee"`
+
+	// The amount that is received whenever the fee is part of the amount (default)
+	NetAmount float64 `json:"netAmount"`
+
+	// The amount that is deducted from your balance whenever the fee is added on top of the amount (see: Withdrawal.AddWithdrawalFee)
+	TotalAmount float64 `json:"totalAmount"`
+
+	// The minimum amount for which a withdrawal can be made.
+	MinAmount float64 `json:"minAmount"`
+
+	// True if the withdrawal can be made, the amount is at least the minimum amount and withdrawals are OK.
+	Allowed bool `json:"allowed"`
+}
+
+// EstimateWithdrawalCost returns the fee, the net amount and the total amount of withdrawing amount of the asset.
+func (m Asset) EstimateWithdrawalCost(amount float64) WithdrawalCost {
+	return WithdrawalCost{
+		Symbol:      m.Symbol,
+		Amount:      amount,
+		Fee:         m.WithdrawalFee,
+		NetAmount:   max(amount-m.WithdrawalFee, 0),
+		TotalAmount: amount + m.WithdrawalFee,
+		MinAmount:   m.WithdrawalMinAmount,
+		Allowed:     m.WithdrawalStatus == "OK" && amount >= m.WithdrawalMinAmount && amount > m.WithdrawalFee,
+	}
+}
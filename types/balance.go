@@ -17,6 +17,18 @@ type Balance struct {
 	InOrder float64 `json:"inOrder"`
 }
 
+func (b Balance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Symbol    string `json:"symbol"`
+		Available string `json:"available,omitempty"`
+		InOrder   string `json:"inOrder,omitempty"`
+	}{
+		Symbol:    b.Symbol,
+		Available: formatNumber("", b.Available),
+		InOrder:   formatNumber("", b.InOrder),
+	})
+}
+
 func (b *Balance) UnmarshalJSON(bytes []byte) error {
 	var j map[string]string
 
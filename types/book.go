@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"github.com/goccy/go-json"
 	"github.com/larscom/go-bitvavo/v2/util"
 )
@@ -26,6 +27,44 @@ type Page struct {
 	Size float64 `json:"size"`
 }
 
+// String returns the page as size@price (e.g: 0.5@2000.1)
+func (p Page) String() string {
+	return fmt.Sprintf("%s@%s", formatRequiredNumber("", p.Size), formatRequiredNumber("", p.Price))
+}
+
+// String returns the nonce, the number of bids and asks and the best bid and ask (e.g: nonce=5 bids=10 asks=12 bid=0.5@2000 ask=1@2001)
+func (b Book) String() string {
+	str := fmt.Sprintf("nonce=%d bids=%d asks=%d", b.Nonce, len(b.Bids), len(b.Asks))
+	if len(b.Bids) > 0 {
+		str += fmt.Sprintf(" bid=%s", b.Bids[0])
+	}
+	if len(b.Asks) > 0 {
+		str += fmt.Sprintf(" ask=%s", b.Asks[0])
+	}
+	return str
+}
+
+// MarshalJSON marshals the bids and asks as arrays of price and size.
+func (b Book) MarshalJSON() ([]byte, error) {
+	pages := func(pages []Page) [][2]string {
+		levels := make([][2]string, len(pages))
+		for i, page := range pages {
+			levels[i] = [2]string{formatRequiredNumber("", page.Price), formatRequiredNumber("", page.Size)}
+		}
+		return levels
+	}
+
+	return json.Marshal(struct {
+		Nonce int64       `json:"nonce"`
+		Bids  [][2]string `json:"bids"`
+		Asks  [][2]string `json:"asks"`
+	}{
+		Nonce: b.Nonce,
+		Bids:  pages(b.Bids),
+		Asks:  pages(b.Asks),
+	})
+}
+
 func (b *Book) UnmarshalJSON(bytes []byte) error {
 	var j map[string]any
 
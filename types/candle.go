@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/goccy/go-json"
@@ -35,6 +36,92 @@ func (c *CandleParams) Params() url.Values {
 	return params
 }
 
+// Interval is the time period of a candle.
+type Interval string
+
+const (
+	Interval1m  Interval = "1m"
+	Interval5m  Interval = "5m"
+	Interval15m Interval = "15m"
+	Interval30m Interval = "30m"
+	Interval1h  Interval = "1h"
+	Interval2h  Interval = "2h"
+	Interval4h  Interval = "4h"
+	Interval6h  Interval = "6h"
+	Interval8h  Interval = "8h"
+	Interval12h Interval = "12h"
+	Interval1d  Interval = "1d"
+	Interval1W  Interval = "1W"
+	Interval1M  Interval = "1M"
+)
+
+var intervalDurations = map[Interval]time.Duration{
+	Interval1m:  time.Minute,
+	Interval5m:  5 * time.Minute,
+	Interval15m: 15 * time.Minute,
+	Interval30m: 30 * time.Minute,
+	Interval1h:  time.Hour,
+	Interval2h:  2 * time.Hour,
+	Interval4h:  4 * time.Hour,
+	Interval6h:  6 * time.Hour,
+	Interval8h:  8 * time.Hour,
+	Interval12h: 12 * time.Hour,
+	Interval1d:  24 * time.Hour,
+	Interval1W:  7 * 24 * time.Hour,
+	Interval1M:  28 * 24 * time.Hour,
+}
+
+var intervals = []Interval{
+	Interval1m,
+	Interval5m,
+	Interval15m,
+	Interval30m,
+	Interval1h,
+	Interval2h,
+	Interval4h,
+	Interval6h,
+	Interval8h,
+	Interval12h,
+	Interval1d,
+	Interval1W,
+	Interval1M,
+}
+
+// ParseInterval returns the Interval for value, or an error if value isn't a known interval (e.g: 5min)
+func ParseInterval(value string) (Interval, error) {
+	return parseEnum("interval", value, intervals)
+}
+
+func (i Interval) String() string {
+	return string(i)
+}
+
+// IsValid returns true if i is a known interval.
+func (i Interval) IsValid() bool {
+	return slices.Contains(intervals, i)
+}
+
+// Validate returns an error if i isn't a known interval.
+func (i Interval) Validate() error {
+	_, err := ParseInterval(string(i))
+	return err
+}
+
+// Duration returns the duration of the interval, 0 if it isn't a known interval (see: Validate)
+//
+// The duration of the 1M interval is the shortest month (28 days)
+func (i Interval) Duration() time.Duration {
+	return intervalDurations[i]
+}
+
+func (i Interval) MarshalJSON() ([]byte, error) {
+	return marshalEnum("interval", i, intervals)
+}
+
+func (i *Interval) UnmarshalJSON(bytes []byte) error {
+	return unmarshalEnum(bytes, i)
+}
+
 type Candle struct {
 	// Timestamp in unix milliseconds.
 	Timestamp int64   `json:"timestamp"`
@@ -43,6 +130,37 @@ type Candle struct {
 	Low       float64 `json:"low"`
 	Close     float64 `json:"close"`
 	Volume    float64 `json:"volume"`
+
+	// The original string values of Open, High, Low, Close and Volume, as received from Bitvavo.
+	OpenStr   string `json:"-"`
+	HighStr   string `json:"-"`
+	LowStr    string `json:"-"`
+	CloseStr  string `json:"-"`
+	VolumeStr string `json:"-"`
+}
+
+// Time returns the start time of the candle.
+func (c Candle) Time() time.Time {
+	return unixMilli(c.Timestamp)
+}
+
+// String returns the start time, prices and volume of the candle (e.g: 2024-01-02T15:00:00Z open=1900 high=2050 low=1890 close=2000 volume=15.5)
+func (c Candle) String() string {
+	return fmt.Sprintf("%s open=%s high=%s low=%s close=%s volume=%s", formatTime(c.Timestamp),
+		formatRequiredNumber(c.OpenStr, c.Open), formatRequiredNumber(c.HighStr, c.High), formatRequiredNumber(c.LowStr, c.Low),
+		formatRequiredNumber(c.CloseStr, c.Close), formatRequiredNumber(c.VolumeStr, c.Volume))
+}
+
+// MarshalJSON marshals the candle as an array of the timestamp followed by open, high, low, close and volume.
+func (c Candle) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{
+		c.Timestamp,
+		formatRequiredNumber(c.OpenStr, c.Open),
+		formatRequiredNumber(c.HighStr, c.High),
+		formatRequiredNumber(c.LowStr, c.Low),
+		formatRequiredNumber(c.CloseStr, c.Close),
+		formatRequiredNumber(c.VolumeStr, c.Volume),
+	})
 }
 
 func (c *Candle) UnmarshalJSON(bytes []byte) error {
@@ -52,11 +170,16 @@ func (c *Candle) UnmarshalJSON(bytes []byte) error {
 	}
 
 	c.Timestamp = int64(j[0].(float64))
-	c.Open = util.MustFloat64(j[1].(string))
-	c.High = util.MustFloat64(j[2].(string))
-	c.Low = util.MustFloat64(j[3].(string))
-	c.Close = util.MustFloat64(j[4].(string))
-	c.Volume = util.MustFloat64(j[5].(string))
+	c.OpenStr = j[1].(string)
+	c.HighStr = j[2].(string)
+	c.LowStr = j[3].(string)
+	c.CloseStr = j[4].(string)
+	c.VolumeStr = j[5].(string)
+	c.Open = util.MustFloat64(c.OpenStr)
+	c.High = util.MustFloat64(c.HighStr)
+	c.Low = util.MustFloat64(c.LowStr)
+	c.Close = util.MustFloat64(c.CloseStr)
+	c.Volume = util.MustFloat64(c.VolumeStr)
 
 	return nil
 }
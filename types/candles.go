@@ -0,0 +1,136 @@
+package types
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Candles is a series of candles of the same market and interval, convert a []Candle to use its helpers (e.g: types.Candles(candles).SortAsc())
+type Candles []Candle
+
+// OHLCV is a series of candles as parallel arrays, the values of a candle share the same index.
+type OHLCV struct {
+	// Timestamps in unix milliseconds.
+	Timestamp []int64
+	Open      []float64
+	High      []float64
+	Low       []float64
+	Close     []float64
+	Volume    []float64
+}
+
+// Len returns the number of candles.
+func (o OHLCV) Len() int {
+	return len(o.Timestamp)
+}
+
+// CandleGap is a period without candles in a series.
+type CandleGap struct {
+	// The start time of the first missing candle.
+	Start time.Time
+
+	// The start time of the candle after the gap.
+	End time.Time
+
+	// The number of missing candles.
+	Missing int
+}
+
+// SortAsc sorts the candles by timestamp, the oldest first.
+func (c Candles) SortAsc() {
+	slices.SortFunc(c, func(a, b Candle) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+}
+
+// SortDesc sorts the candles by timestamp, the newest first (which is the order in which they're returned by GetCandles)
+func (c Candles) SortDesc() {
+	slices.SortFunc(c, func(a, b Candle) int {
+		return cmp.Compare(b.Timestamp, a.Timestamp)
+	})
+}
+
+// Gaps returns every period in which candles are missing with interval (e.g: 5m) between the first and last candle,
+// the candles must be sorted ascending.
+func (c Candles) Gaps(interval Interval) ([]CandleGap, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+
+	gaps := make([]CandleGap, 0)
+	for i := 1; i < len(c); i++ {
+		expected := nextCandle(c[i-1].Timestamp, interval)
+		if missing := countCandles(expected, c[i].Timestamp, interval); missing > 0 {
+			gaps = append(gaps, CandleGap{Start: time.UnixMilli(expected), End: c[i].Time(), Missing: missing})
+		}
+	}
+	return gaps, nil
+}
+
+// OHLCV returns the candles as parallel arrays in the same order.
+func (c Candles) OHLCV() OHLCV {
+	ohlcv := OHLCV{
+		Timestamp: make([]int64, len(c)),
+		Open:      make([]float64, len(c)),
+		High:      make([]float64, len(c)),
+		Low:       make([]float64, len(c)),
+		Close:     make([]float64, len(c)),
+		Volume:    make([]float64, len(c)),
+	}
+	for i, candle := range c {
+		ohlcv.Timestamp[i] = candle.Timestamp
+		ohlcv.Open[i] = candle.Open
+		ohlcv.High[i] = candle.High
+		ohlcv.Low[i] = candle.Low
+		ohlcv.Close[i] = candle.Close
+		ohlcv.Volume[i] = candle.Volume
+	}
+	return ohlcv
+}
+
+// Find returns the candle with interval (e.g: 5m) which contains t, false if there is no such candle.
+// The candles must be sorted ascending.
+func (c Candles) Find(t time.Time, interval Interval) (Candle, bool) {
+	if !interval.IsValid() {
+		return Candle{}, false
+	}
+
+	timestamp := t.UnixMilli()
+	// the index of the first candle after t, so the candle before it is the only one which can contain t
+	i, _ := slices.BinarySearchFunc(c, timestamp+1, func(candle Candle, timestamp int64) int {
+		return cmp.Compare(candle.Timestamp, timestamp)
+	})
+	if i == 0 {
+		return Candle{}, false
+	}
+
+	candle := c[i-1]
+	if timestamp >= nextCandle(candle.Timestamp, interval) {
+		return Candle{}, false
+	}
+	return candle, true
+}
+
+// nextCandle returns the timestamp of the candle after the candle at timestamp with interval,
+// the 1M interval follows the calendar instead of its shortest duration.
+func nextCandle(timestamp int64, interval Interval) int64 {
+	if interval == Interval1M {
+		return time.UnixMilli(timestamp).UTC().AddDate(0, 1, 0).UnixMilli()
+	}
+	return timestamp + interval.Duration().Milliseconds()
+}
+
+// countCandles returns the number of candles with interval which start at or after from and before to.
+func countCandles(from int64, to int64, interval Interval) int {
+	if interval != Interval1M {
+		step := interval.Duration().Milliseconds()
+		return int(max(0, (to-from+step-1)/step))
+	}
+
+	count := 0
+	for next := from; next < to; next = nextCandle(next, interval) {
+		count++
+	}
+	return count
+}
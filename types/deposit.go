@@ -36,6 +36,53 @@ type DepositAsset struct {
 	Description string `json:"description"`
 }
 
+type DepositFiat struct {
+	// IBAN number to wire your deposit to.
+	IBAN string `json:"iban"`
+
+	// Optional code sometimes necessary for international transfers.
+	BIC string `json:"bic"`
+
+	// Description which must be used for the deposit.
+	Description string `json:"description"`
+}
+
+type DepositCrypto struct {
+	// The address to which cryptocurrencies can be sent to increase the account balance.
+	Address string `json:"address"`
+
+	// If a paymentid is supplied, attaching this to your deposit is required. This is mostly called a note, memo or tag.
+	PaymentId string `json:"paymentid"`
+}
+
+// IsFiat returns true if the deposit asset contains bank account information (e.g: for EUR)
+func (d DepositAsset) IsFiat() bool {
+	return d.IBAN != ""
+}
+
+// Fiat returns the bank account information of a fiat deposit, false if it's not a fiat deposit.
+func (d DepositAsset) Fiat() (DepositFiat, bool) {
+	if !d.IsFiat() {
+		return DepositFiat{}, false
+	}
+	return DepositFiat{
+		IBAN:        d.IBAN,
+		BIC:         d.BIC,
+		Description: d.Description,
+	}, true
+}
+
+// Crypto returns the address of a digital deposit, false if it's not a digital deposit.
+func (d DepositAsset) Crypto() (DepositCrypto, bool) {
+	if d.Address == "" {
+		return DepositCrypto{}, false
+	}
+	return DepositCrypto{
+		Address:   d.Address,
+		PaymentId: d.PaymentId,
+	}, true
+}
+
 type DepositHistoryParams struct {
 	// When no symbol is specified, all deposits will be returned.
 	Symbol string `json:"symbol"`
@@ -104,6 +151,33 @@ type DepositHistory struct {
 	Status string `json:"status"`
 }
 
+// Time returns the time the deposit was made.
+func (d DepositHistory) Time() time.Time {
+	return unixMilli(d.Timestamp)
+}
+
+func (d DepositHistory) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Timestamp int64  `json:"timestamp"`
+		Symbol    string `json:"symbol"`
+		Amount    string `json:"amount,omitempty"`
+		Address   string `json:"address,omitempty"`
+		PaymentId string `json:"paymentId,omitempty"`
+		TxId      string `json:"txId,omitempty"`
+		Fee       string `json:"fee,omitempty"`
+		Status    string `json:"status,omitempty"`
+	}{
+		Timestamp: d.Timestamp,
+		Symbol:    d.Symbol,
+		Amount:    formatNumber("", d.Amount),
+		Address:   d.Address,
+		PaymentId: d.PaymentId,
+		TxId:      d.TxId,
+		Fee:       formatNumber("", d.Fee),
+		Status:    d.Status,
+	})
+}
+
 func (d *DepositHistory) UnmarshalJSON(bytes []byte) error {
 	var j map[string]any
 
@@ -0,0 +1,221 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/goccy/go-json"
+)
+
+var errInvalidEnum = func(kind string, value string) error {
+	return fmt.Errorf("invalid %s: %q", kind, value)
+}
+
+// Side is the side of an order, trade or fill.
+type Side string
+
+const (
+	SideBuy  Side = "buy"
+	SideSell Side = "sell"
+)
+
+var sides = []Side{SideBuy, SideSell}
+
+// ParseSide returns the Side for value, or an error if value isn't a known side.
+func ParseSide(value string) (Side, error) {
+	return parseEnum("side", value, sides)
+}
+
+func (s Side) String() string {
+	return string(s)
+}
+
+// IsValid returns true if s is a known side.
+func (s Side) IsValid() bool {
+	return slices.Contains(sides, s)
+}
+
+func (s Side) MarshalJSON() ([]byte, error) {
+	return marshalEnum("side", s, sides)
+}
+
+func (s *Side) UnmarshalJSON(bytes []byte) error {
+	return unmarshalEnum(bytes, s)
+}
+
+// OrderType is the type of an order.
+type OrderType string
+
+const (
+	OrderTypeMarket          OrderType = "market"
+	OrderTypeLimit           OrderType = "limit"
+	OrderTypeStopLoss        OrderType = "stopLoss"
+	OrderTypeStopLossLimit   OrderType = "stopLossLimit"
+	OrderTypeTakeProfit      OrderType = "takeProfit"
+	OrderTypeTakeProfitLimit OrderType = "takeProfitLimit"
+)
+
+var orderTypes = []OrderType{
+	OrderTypeMarket,
+	OrderTypeLimit,
+	OrderTypeStopLoss,
+	OrderTypeStopLossLimit,
+	OrderTypeTakeProfit,
+	OrderTypeTakeProfitLimit,
+}
+
+// ParseOrderType returns the OrderType for value, or an error if value isn't a known order type.
+func ParseOrderType(value string) (OrderType, error) {
+	return parseEnum("order type", value, orderTypes)
+}
+
+func (o OrderType) String() string {
+	return string(o)
+}
+
+// IsValid returns true if o is a known order type.
+func (o OrderType) IsValid() bool {
+	return slices.Contains(orderTypes, o)
+}
+
+func (o OrderType) MarshalJSON() ([]byte, error) {
+	return marshalEnum("order type", o, orderTypes)
+}
+
+func (o *OrderType) UnmarshalJSON(bytes []byte) error {
+	return unmarshalEnum(bytes, o)
+}
+
+// TimeInForce determines how long a limit order remains active.
+type TimeInForce string
+
+const (
+	// Good-Til-Canceled: the order remains on the order book until it is filled or canceled.
+	TimeInForceGTC TimeInForce = "GTC"
+
+	// Immediate-Or-Cancel: the order fills against existing orders and the remaining amount is canceled.
+	TimeInForceIOC TimeInForce = "IOC"
+
+	// Fill-Or-Kill: the order fills against existing orders in its entirety, or is canceled.
+	TimeInForceFOK TimeInForce = "FOK"
+)
+
+var timeInForces = []TimeInForce{TimeInForceGTC, TimeInForceIOC, TimeInForceFOK}
+
+// ParseTimeInForce returns the TimeInForce for value, or an error if value isn't a known time in force.
+func ParseTimeInForce(value string) (TimeInForce, error) {
+	return parseEnum("time in force", value, timeInForces)
+}
+
+func (t TimeInForce) String() string {
+	return string(t)
+}
+
+// IsValid returns true if t is a known time in force.
+func (t TimeInForce) IsValid() bool {
+	return slices.Contains(timeInForces, t)
+}
+
+func (t TimeInForce) MarshalJSON() ([]byte, error) {
+	return marshalEnum("time in force", t, timeInForces)
+}
+
+func (t *TimeInForce) UnmarshalJSON(bytes []byte) error {
+	return unmarshalEnum(bytes, t)
+}
+
+// OrderStatus is the current status of an order.
+type OrderStatus string
+
+const (
+	StatusNew                         OrderStatus = "new"
+	StatusAwaitingTrigger             OrderStatus = "awaitingTrigger"
+	StatusCanceled                    OrderStatus = "canceled"
+	StatusCanceledAuction             OrderStatus = "canceledAuction"
+	StatusCanceledSelfTradePrevention OrderStatus = "canceledSelfTradePrevention"
+	StatusCanceledIOC                 OrderStatus = "canceledIOC"
+	StatusCanceledFOK                 OrderStatus = "canceledFOK"
+	StatusCanceledMarketProtection    OrderStatus = "canceledMarketProtection"
+	StatusCanceledPostOnly            OrderStatus = "canceledPostOnly"
+	StatusFilled                      OrderStatus = "filled"
+	StatusPartiallyFilled             OrderStatus = "partiallyFilled"
+	StatusExpired                     OrderStatus = "expired"
+	StatusRejected                    OrderStatus = "rejected"
+)
+
+var orderStatuses = []OrderStatus{
+	StatusNew,
+	StatusAwaitingTrigger,
+	StatusCanceled,
+	StatusCanceledAuction,
+	StatusCanceledSelfTradePrevention,
+	StatusCanceledIOC,
+	StatusCanceledFOK,
+	StatusCanceledMarketProtection,
+	StatusCanceledPostOnly,
+	StatusFilled,
+	StatusPartiallyFilled,
+	StatusExpired,
+	StatusRejected,
+}
+
+// ParseOrderStatus returns the OrderStatus for value, or an error if value isn't a known order status.
+func ParseOrderStatus(value string) (OrderStatus, error) {
+	return parseEnum("order status", value, orderStatuses)
+}
+
+func (o OrderStatus) String() string {
+	return string(o)
+}
+
+// IsValid returns true if o is a known order status.
+func (o OrderStatus) IsValid() bool {
+	return slices.Contains(orderStatuses, o)
+}
+
+// IsFinal returns true if an order with status o can't change anymore (e.g: filled or canceled)
+func (o OrderStatus) IsFinal() bool {
+	return o.IsValid() && o != StatusNew && o != StatusAwaitingTrigger && o != StatusPartiallyFilled
+}
+
+func (o OrderStatus) MarshalJSON() ([]byte, error) {
+	return marshalEnum("order status", o, orderStatuses)
+}
+
+func (o *OrderStatus) UnmarshalJSON(bytes []byte) error {
+	return unmarshalEnum(bytes, o)
+}
+
+func parseEnum[T ~string](kind string, value string, members []T) (T, error) {
+	if !slices.Contains(members, T(value)) {
+		return "", errInvalidEnum(kind, value)
+	}
+	return T(value), nil
+}
+
+// parseOptionalEnum is like parseEnum, but accepts an empty value as well (e.g: a field which wasn't sent)
+func parseOptionalEnum[T ~string](kind string, value string, members []T) (T, error) {
+	if value == "" {
+		return "", nil
+	}
+	return parseEnum(kind, value, members)
+}
+
+func marshalEnum[T ~string](kind string, value T, members []T) ([]byte, error) {
+	if _, err := parseOptionalEnum(kind, string(value), members); err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(value))
+}
+
+// unmarshalEnum accepts any value, so a value which Bitvavo adds later doesn't fail the whole response.
+// The raw value is kept, use IsValid to check whether it's a known value.
+func unmarshalEnum[T ~string](bytes []byte, target *T) error {
+	var value string
+	if err := json.Unmarshal(bytes, &value); err != nil {
+		return err
+	}
+	*target = T(value)
+
+	return nil
+}
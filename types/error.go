@@ -1,7 +1,9 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/larscom/go-bitvavo/v2/util"
 )
@@ -16,3 +18,89 @@ func (b *BitvavoErr) Error() string {
 	msg := fmt.Sprintf("code %d: %s", b.Code, b.Message)
 	return fmt.Sprint(util.IfOrElse(len(b.Action) > 0, func() string { return fmt.Sprintf("%s action: %s", msg, b.Action) }, msg))
 }
+
+// Bitvavo error codes (see: https://docs.bitvavo.com/#tag/Error-messages)
+const (
+	ErrorCodeUnknown                = 101
+	ErrorCodeInvalidJSON            = 102
+	ErrorCodeRateLimited            = 103
+	ErrorCodeOrderRateLimited       = 104
+	ErrorCodeBanned                 = 105
+	ErrorCodeEngineOverloaded       = 107
+	ErrorCodeEngineTimeout          = 108
+	ErrorCodeEngineNoResponse       = 109
+	ErrorCodeInvalidEndpoint        = 110
+	ErrorCodeInvalidParameter       = 205
+	ErrorCodeInsufficientBalance    = 216
+	ErrorCodeMinimumOrderSize       = 217
+	ErrorCodeOrderNotFound          = 240
+	ErrorCodeAuthenticationRequired = 300
+	ErrorCodeInvalidTimestamp       = 302
+	ErrorCodeInvalidWindow          = 303
+	ErrorCodeRequestExpired         = 304
+	ErrorCodeInvalidApiKey          = 305
+	ErrorCodeApiKeyNotActivated     = 306
+	ErrorCodeIpNotWhitelisted       = 307
+	ErrorCodeInvalidSignature       = 309
+	ErrorCodeTradingNotAllowed      = 310
+	ErrorCodeWithdrawalNotAllowed   = 311
+)
+
+// Sentinel errors to compare with errors.Is, only the code is compared.
+var (
+	ErrRateLimited         = &BitvavoErr{Code: ErrorCodeRateLimited, Message: "rate limited"}
+	ErrBanned              = &BitvavoErr{Code: ErrorCodeBanned, Message: "temporarily banned"}
+	ErrInsufficientBalance = &BitvavoErr{Code: ErrorCodeInsufficientBalance, Message: "insufficient balance"}
+	ErrOrderNotFound       = &BitvavoErr{Code: ErrorCodeOrderNotFound, Message: "order not found"}
+	ErrRequestExpired      = &BitvavoErr{Code: ErrorCodeRequestExpired, Message: "request was not received within the access window"}
+	ErrInvalidSignature    = &BitvavoErr{Code: ErrorCodeInvalidSignature, Message: "invalid signature"}
+)
+
+// Is reports whether target is a BitvavoErr with the same code, so errors.Is(err, types.ErrOrderNotFound) works.
+func (b *BitvavoErr) Is(target error) bool {
+	t, ok := target.(*BitvavoErr)
+	return ok && t != nil && b.Code == t.Code
+}
+
+// ErrorCode returns the Bitvavo error code of err, or 0 if err isn't (wrapping) a BitvavoErr.
+func ErrorCode(err error) int {
+	var bitvavoErr *BitvavoErr
+	if errors.As(err, &bitvavoErr) {
+		return bitvavoErr.Code
+	}
+	return 0
+}
+
+// HasErrorCode returns true if err is (wrapping) a BitvavoErr with one of codes.
+func HasErrorCode(err error, codes ...int) bool {
+	code := ErrorCode(err)
+	return code != 0 && slices.Contains(codes, code)
+}
+
+// IsRateLimited returns true if err means the rate limit has been reached, or your api key or ip has been banned for it.
+func IsRateLimited(err error) bool {
+	return HasErrorCode(err, ErrorCodeRateLimited, ErrorCodeOrderRateLimited, ErrorCodeBanned)
+}
+
+// IsInsufficientBalance returns true if err means the balance is too low for the order or withdrawal.
+func IsInsufficientBalance(err error) bool {
+	return HasErrorCode(err, ErrorCodeInsufficientBalance)
+}
+
+// IsOrderNotFound returns true if err means the order doesn't exist (anymore)
+func IsOrderNotFound(err error) bool {
+	return HasErrorCode(err, ErrorCodeOrderNotFound)
+}
+
+// IsAmbiguous returns true if err doesn't tell whether the exchange executed the request
+// (e.g: a network failure or a timeout of the matching engine)
+func IsAmbiguous(err error) bool {
+	code := ErrorCode(err)
+	return code == 0 || code == ErrorCodeUnknown || code == ErrorCodeEngineOverloaded ||
+		code == ErrorCodeEngineTimeout || code == ErrorCodeEngineNoResponse
+}
+
+// IsRequestExpired returns true if err means the request wasn't received within the access window (e.g: clock skew)
+func IsRequestExpired(err error) bool {
+	return HasErrorCode(err, ErrorCodeRequestExpired)
+}
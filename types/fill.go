@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/goccy/go-json"
 	"github.com/larscom/go-bitvavo/v2/util"
 )
@@ -18,19 +21,28 @@ type Fill struct {
 	// The amount in base currency for which the trade has been made
 	Amount float64 `json:"amount"`
 
+	// The original string value of Amount, as received from Bitvavo.
+	AmountStr string `json:"-"`
+
 	// The side for the taker
 	// Enum: "buy" | "sell"
-	Side string `json:"side"`
+	Side Side `json:"side"`
 
 	// The price in quote currency for which the trade has been made
 	Price float64 `json:"price"`
 
+	// The original string value of Price, as received from Bitvavo.
+	PriceStr string `json:"-"`
+
 	// True for takers, false for makers
 	Taker bool `json:"taker"`
 
 	// The amount of fee that has been paid. Value is negative for rebates. Only available if settled is true
 	Fee float64 `json:"fee"`
 
+	// The original string value of Fee, as received from Bitvavo.
+	FeeStr string `json:"-"`
+
 	// Currency in which the fee has been paid. Only available if settled is true
 	FeeCurrency string `json:"feeCurrency"`
 
@@ -39,6 +51,51 @@ type Fill struct {
 	Settled bool `json:"settled"`
 }
 
+// Time returns the time the fill was made.
+func (f Fill) Time() time.Time {
+	return unixMilli(f.Timestamp)
+}
+
+// String returns the side, amount, price and fee of the fill (e.g: buy 0.5@2000 fee=2.5 EUR fillId=...)
+func (f Fill) String() string {
+	return fmt.Sprintf("%s %s@%s fee=%s %s fillId=%s", f.Side,
+		formatRequiredNumber(f.AmountStr, f.Amount), formatRequiredNumber(f.PriceStr, f.Price),
+		formatRequiredNumber(f.FeeStr, f.Fee), f.FeeCurrency, f.FillId)
+}
+
+// fillJSON is the format in which Bitvavo sends a fill.
+type fillJSON struct {
+	FillId      string `json:"fillId,omitempty"`
+	OrderId     string `json:"orderId,omitempty"`
+	Timestamp   int64  `json:"timestamp"`
+	Amount      string `json:"amount,omitempty"`
+	Side        Side   `json:"side,omitempty"`
+	Price       string `json:"price,omitempty"`
+	Taker       bool   `json:"taker"`
+	Fee         string `json:"fee,omitempty"`
+	FeeCurrency string `json:"feeCurrency,omitempty"`
+	Settled     bool   `json:"settled"`
+}
+
+func newFillJSON(f Fill) fillJSON {
+	return fillJSON{
+		FillId:      f.FillId,
+		OrderId:     f.OrderId,
+		Timestamp:   f.Timestamp,
+		Amount:      formatNumber(f.AmountStr, f.Amount),
+		Side:        f.Side,
+		Price:       formatNumber(f.PriceStr, f.Price),
+		Taker:       f.Taker,
+		Fee:         formatNumber(f.FeeStr, f.Fee),
+		FeeCurrency: f.FeeCurrency,
+		Settled:     f.Settled,
+	}
+}
+
+func (f Fill) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newFillJSON(f))
+}
+
 func (f *Fill) UnmarshalJSON(bytes []byte) error {
 	var j map[string]any
 
@@ -47,30 +104,30 @@ func (f *Fill) UnmarshalJSON(bytes []byte) error {
 	}
 
 	var (
-		fillId = getOrEmpty[string]("fillId", j)
-		id     = getOrEmpty[string]("id", j)
-
-		orderId     = getOrEmpty[string]("orderId", j)
-		timestamp   = getOrEmpty[float64]("timestamp", j)
-		amount      = getOrEmpty[string]("amount", j)
-		side        = getOrEmpty[string]("side", j)
-		price       = getOrEmpty[string]("price", j)
-		taker       = getOrEmpty[bool]("taker", j)
-		fee         = getOrEmpty[string]("fee", j)
-		feeCurrency = getOrEmpty[string]("feeCurrency", j)
-		settled     = getOrEmpty[bool]("settled", j)
+		fill = object{data: j}
+
+		fillId = fill.string("fillId")
+		id     = fill.string("id")
+
+		side = fill.string("side")
 	)
 
-	f.OrderId = orderId
+	f.OrderId = fill.string("orderId")
 	f.FillId = util.IfOrElse(len(fillId) > 0, func() string { return fillId }, id)
-	f.Timestamp = int64(timestamp)
-	f.Amount = util.IfOrElse(len(amount) > 0, func() float64 { return util.MustFloat64(amount) }, 0)
-	f.Side = side
-	f.Price = util.IfOrElse(len(price) > 0, func() float64 { return util.MustFloat64(price) }, 0)
-	f.Taker = taker
-	f.Fee = util.IfOrElse(len(fee) > 0, func() float64 { return util.MustFloat64(fee) }, 0)
-	f.FeeCurrency = feeCurrency
-	f.Settled = settled
+	f.Timestamp = fill.int("timestamp")
+	f.Amount, f.AmountStr = fill.number("amount")
+	f.Price, f.PriceStr = fill.number("price")
+	f.Taker = fill.bool("taker")
+	f.Fee, f.FeeStr = fill.number("fee")
+	f.FeeCurrency = fill.string("feeCurrency")
+	f.Settled = fill.bool("settled")
+
+	if fill.err != nil {
+		return fill.err
+	}
+
+	// an unknown side is kept as is (see: Side.IsValid)
+	f.Side = Side(side)
 
 	return nil
 }
@@ -1,8 +1,19 @@
 package types
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
 	"github.com/goccy/go-json"
-	"github.com/larscom/go-bitvavo/v2/util"
+)
+
+// The status of a market.
+const (
+	MarketStatusTrading = "trading"
+	MarketStatusHalted  = "halted"
+	MarketStatusAuction = "auction"
 )
 
 type Market struct {
@@ -23,22 +34,114 @@ type Market struct {
 	// Examples of precision 6 are: 11313.1, 7500.11, 7500.25, 500.123, 0.00123456.
 	PricePrecision int64 `json:"pricePrecision"`
 
-	// The minimum amount in quote currency (amountQuote or amount * price) for valid orders.
+	// The minimum amount in base currency for valid orders.
 	MinOrderInBaseAsset float64 `json:"minOrderInBaseAsset"`
 
-	// The minimum amount in base currency for valid orders.
+	// The minimum amount in quote currency (amountQuote or amount * price) for valid orders.
 	MinOrderInQuoteAsset float64 `json:"minOrderInQuoteAsset"`
 
-	// // The maximum amount in quote currency (amountQuote or amount * price) for valid orders.
+	// The maximum amount in base currency for valid orders.
 	MaxOrderInBaseAsset float64 `json:"maxOrderInBaseAsset"`
 
-	// The maximum amount in base currency for valid orders.
+	// The maximum amount in quote currency (amountQuote or amount * price) for valid orders.
 	MaxOrderInQuoteAsset float64 `json:"maxOrderInQuoteAsset"`
 
+	// The smallest price increment, prices must be a multiple of it (0 if the market only has a price precision)
+	TickSize float64 `json:"tickSize"`
+
+	// The maximum number of decimals of an amount in base currency.
+	QuantityDecimals int64 `json:"quantityDecimals"`
+
+	// The maximum number of decimals of an amount in quote currency.
+	NotionalDecimals int64 `json:"notionalDecimals"`
+
+	// The maximum number of open orders in this market.
+	MaxOpenOrders int64 `json:"maxOpenOrders"`
+
+	// The fee category of this market.
+	FeeCategory string `json:"feeCategory"`
+
 	// Allowed order types for this market.
 	OrderTypes []string `json:"orderTypes"`
 }
 
+// MinOrderInBase returns the minimum amount in base currency of an order at price,
+// which is the highest of MinOrderInBaseAsset and MinOrderInQuoteAsset at price.
+func (m Market) MinOrderInBase(price float64) float64 {
+	if price <= 0 {
+		return m.MinOrderInBaseAsset
+	}
+	return max(m.MinOrderInBaseAsset, m.MinOrderInQuoteAsset/price)
+}
+
+// MinOrderInQuote returns the minimum amount in quote currency of an order at price,
+// which is the highest of MinOrderInQuoteAsset and MinOrderInBaseAsset at price.
+func (m Market) MinOrderInQuote(price float64) float64 {
+	return max(m.MinOrderInQuoteAsset, m.MinOrderInBaseAsset*price)
+}
+
+// RoundPrice rounds price to the nearest multiple of TickSize,
+// or to PricePrecision significant digits if the market has no tick size.
+func (m Market) RoundPrice(price float64) float64 {
+	if price == 0 {
+		return 0
+	}
+	if m.TickSize > 0 {
+		return roundDecimals(math.Round(price/m.TickSize)*m.TickSize, decimalsOf(m.TickSize))
+	}
+	if m.PricePrecision <= 0 {
+		return price
+	}
+	magnitude := int64(math.Floor(math.Log10(math.Abs(price)))) + 1
+	return roundDecimals(price, m.PricePrecision-magnitude)
+}
+
+// RoundAmount rounds amount (in base currency) down to QuantityDecimals, so it never exceeds amount.
+func (m Market) RoundAmount(amount float64) float64 {
+	return floorDecimals(amount, m.QuantityDecimals)
+}
+
+// RoundAmountQuote rounds amountQuote (in quote currency) down to NotionalDecimals, so it never exceeds amountQuote.
+func (m Market) RoundAmountQuote(amountQuote float64) float64 {
+	return floorDecimals(amountQuote, m.NotionalDecimals)
+}
+
+func (m Market) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Market               string   `json:"market"`
+		Status               string   `json:"status"`
+		Base                 string   `json:"base"`
+		Quote                string   `json:"quote"`
+		PricePrecision       int64    `json:"pricePrecision"`
+		MinOrderInBaseAsset  string   `json:"minOrderInBaseAsset,omitempty"`
+		MinOrderInQuoteAsset string   `json:"minOrderInQuoteAsset,omitempty"`
+		MaxOrderInBaseAsset  string   `json:"maxOrderInBaseAsset,omitempty"`
+		MaxOrderInQuoteAsset string   `json:"maxOrderInQuoteAsset,omitempty"`
+		TickSize             string   `json:"tickSize,omitempty"`
+		QuantityDecimals     int64    `json:"quantityDecimals"`
+		NotionalDecimals     int64    `json:"notionalDecimals"`
+		MaxOpenOrders        int64    `json:"maxOpenOrders"`
+		FeeCategory          string   `json:"feeCategory,omitempty"`
+		OrderTypes           []string `json:"orderTypes"`
+	}{
+		Market:               m.Market,
+		Status:               m.Status,
+		Base:                 m.Base,
+		Quote:                m.Quote,
+		PricePrecision:       m.PricePrecision,
+		MinOrderInBaseAsset:  formatNumber("", m.MinOrderInBaseAsset),
+		MinOrderInQuoteAsset: formatNumber("", m.MinOrderInQuoteAsset),
+		MaxOrderInBaseAsset:  formatNumber("", m.MaxOrderInBaseAsset),
+		MaxOrderInQuoteAsset: formatNumber("", m.MaxOrderInQuoteAsset),
+		TickSize:             formatNumber("", m.TickSize),
+		QuantityDecimals:     m.QuantityDecimals,
+		NotionalDecimals:     m.NotionalDecimals,
+		MaxOpenOrders:        m.MaxOpenOrders,
+		FeeCategory:          m.FeeCategory,
+		OrderTypes:           m.OrderTypes,
+	})
+}
+
 func (m *Market) UnmarshalJSON(bytes []byte) error {
 	var j map[string]any
 
@@ -47,33 +150,58 @@ func (m *Market) UnmarshalJSON(bytes []byte) error {
 	}
 
 	var (
-		market               = getOrEmpty[string]("market", j)
-		status               = getOrEmpty[string]("status", j)
-		base                 = getOrEmpty[string]("base", j)
-		quote                = getOrEmpty[string]("quote", j)
-		pricePrecision       = getOrEmpty[float64]("pricePrecision", j)
-		minOrderInBaseAsset  = getOrEmpty[string]("minOrderInBaseAsset", j)
-		minOrderInQuoteAsset = getOrEmpty[string]("minOrderInQuoteAsset", j)
-		maxOrderInBaseAsset  = getOrEmpty[string]("maxOrderInBaseAsset", j)
-		maxOrderInQuoteAsset = getOrEmpty[string]("maxOrderInQuoteAsset", j)
-		orderTypesAny        = getOrEmpty[[]any]("orderTypes", j)
+		market        = object{data: j}
+		orderTypesAny = market.array("orderTypes")
 	)
 
 	orderTypes := make([]string, len(orderTypesAny))
 	for i := 0; i < len(orderTypesAny); i++ {
-		orderTypes[i] = orderTypesAny[i].(string)
+		orderType, ok := orderTypesAny[i].(string)
+		if !ok {
+			return fmt.Errorf("unexpected type of field orderTypes: %T", orderTypesAny[i])
+		}
+		orderTypes[i] = orderType
 	}
 
-	m.Market = market
-	m.Status = status
-	m.Base = base
-	m.Quote = quote
-	m.PricePrecision = int64(pricePrecision)
-	m.MinOrderInBaseAsset = util.IfOrElse(len(minOrderInBaseAsset) > 0, func() float64 { return util.MustFloat64(minOrderInBaseAsset) }, 0)
-	m.MinOrderInQuoteAsset = util.IfOrElse(len(minOrderInQuoteAsset) > 0, func() float64 { return util.MustFloat64(minOrderInQuoteAsset) }, 0)
-	m.MaxOrderInBaseAsset = util.IfOrElse(len(maxOrderInBaseAsset) > 0, func() float64 { return util.MustFloat64(maxOrderInBaseAsset) }, 0)
-	m.MaxOrderInQuoteAsset = util.IfOrElse(len(maxOrderInQuoteAsset) > 0, func() float64 { return util.MustFloat64(maxOrderInQuoteAsset) }, 0)
+	// the numbers are sent as strings or numbers depending on the field, both are accepted for every field
+	m.Market = market.string("market")
+	m.Status = market.string("status")
+	m.Base = market.string("base")
+	m.Quote = market.string("quote")
+	m.PricePrecision = market.int("pricePrecision")
+	m.MinOrderInBaseAsset, _ = market.number("minOrderInBaseAsset")
+	m.MinOrderInQuoteAsset, _ = market.number("minOrderInQuoteAsset")
+	m.MaxOrderInBaseAsset, _ = market.number("maxOrderInBaseAsset")
+	m.MaxOrderInQuoteAsset, _ = market.number("maxOrderInQuoteAsset")
+	m.TickSize, _ = market.number("tickSize")
+	m.QuantityDecimals = market.int("quantityDecimals")
+	m.NotionalDecimals = market.int("notionalDecimals")
+	m.MaxOpenOrders = market.int("maxOpenOrders")
+	m.FeeCategory = market.string("feeCategory")
 	m.OrderTypes = orderTypes
 
-	return nil
+	return market.err
+}
+
+// roundDecimals rounds value to decimals (which can be negative, e.g: -2 rounds to hundreds)
+func roundDecimals(value float64, decimals int64) float64 {
+	if decimals < 0 {
+		factor := math.Pow10(int(-decimals))
+		return math.Round(value/factor) * factor
+	}
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(value, 'f', int(decimals), 64), 64)
+	return rounded
+}
+
+// floorDecimals rounds value down to decimals, a tiny margin prevents that a value which is just below
+// a multiple because of the float representation (e.g: 0.29 * 100 = 28.999999999999996) is rounded down.
+func floorDecimals(value float64, decimals int64) float64 {
+	factor := math.Pow10(int(max(0, decimals)))
+	return roundDecimals(math.Floor(value*factor+1e-9)/factor, decimals)
+}
+
+// decimalsOf returns the number of decimals of value (e.g: 2 for 0.01)
+func decimalsOf(value float64) int64 {
+	_, decimals, _ := strings.Cut(strconv.FormatFloat(value, 'f', -1, 64), ".")
+	return int64(len(decimals))
 }
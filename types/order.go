@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/goccy/go-json"
-	"github.com/larscom/go-bitvavo/v2/util"
 )
 
 type OrderParams struct {
@@ -53,15 +52,21 @@ type OrderNew struct {
 	// The market in which the order should be placed (e.g: ETH-EUR)
 	Market string `json:"market"`
 
+	// Your own identifier for the order (UUID), returned as clientOrderId on the order.
+	ClientOrderId string `json:"clientOrderId,omitempty"`
+
+	// Your identifier for the trader or the bot that placed the order, required for some accounts.
+	OperatorId int64 `json:"operatorId,omitempty"`
+
 	// When placing a buy order the base currency will be bought for the quote currency. When placing a sell order the base currency will be sold for the quote currency.
 	//
 	// Enum: "buy" | "sell"
-	Side string `json:"side"`
+	Side Side `json:"side"`
 
 	// For limit orders, amount and price are required. For market orders either amount or amountQuote is required.
 	//
 	// Enum: "market" | "limit" | "stopLoss" | "stopLossLimit" | "takeProfit" | "takeProfitLimit"
-	OrderType string `json:"orderType"`
+	OrderType OrderType `json:"orderType"`
 
 	// Specifies the amount of the base asset that will be bought/sold.
 	Amount float64 `json:"amount,omitempty"`
@@ -96,7 +101,7 @@ type OrderNew struct {
 	//
 	// Enum: "GTC" | "IOC" | "FOK"
 	// Default: "GTC"
-	TimeInForce string `json:"timeInForce,omitempty"`
+	TimeInForce TimeInForce `json:"timeInForce,omitempty"`
 
 	// Self trading is not allowed on Bitvavo. Multiple options are available to prevent this from happening.
 	// The default ‘decrementAndCancel’ decrements both orders by the amount that would have been filled, which in turn cancels the smallest of the two orders.
@@ -133,8 +138,14 @@ type OrderUpdate struct {
 	// The market for which an order should be updated
 	Market string `json:"market"`
 
-	// The id of the order which should be updated
-	OrderId string `json:"orderId"`
+	// The id of the order which should be updated, either orderId or clientOrderId is required.
+	OrderId string `json:"orderId,omitempty"`
+
+	// Your own identifier for the order (UUID) which should be updated.
+	ClientOrderId string `json:"clientOrderId,omitempty"`
+
+	// Your identifier for the trader or the bot that updated the order, required for some accounts.
+	OperatorId int64 `json:"operatorId,omitempty"`
 
 	// Updates amount to this value (and also changes amountRemaining accordingly).
 	Amount float64 `json:"amount,omitempty"`
@@ -160,7 +171,7 @@ type OrderUpdate struct {
 	//
 	// Enum: "GTC" | "IOC" | "FOK"
 	// Default: "GTC"
-	TimeInForce string `json:"timeInForce,omitempty"`
+	TimeInForce TimeInForce `json:"timeInForce,omitempty"`
 
 	// Self trading is not allowed on Bitvavo. Multiple options are available to prevent this from happening.
 	// The default ‘decrementAndCancel’ decrements both orders by the amount that would have been filled, which in turn cancels the smallest of the two orders.
@@ -190,6 +201,12 @@ type Order struct {
 	// The order id of the returned order.
 	OrderId string `json:"orderId"`
 
+	// Your own identifier for the order, empty if it wasn't set when the order was placed.
+	ClientOrderId string `json:"clientOrderId"`
+
+	// Your identifier for the trader or the bot that placed the order.
+	OperatorId int64 `json:"operatorId"`
+
 	// The market in which the order was placed.
 	Market string `json:"market"`
 
@@ -201,37 +218,55 @@ type Order struct {
 
 	// The current status of the order.
 	// Enum: "new" | "awaitingTrigger" | "canceled" | "canceledAuction" | "canceledSelfTradePrevention" | "canceledIOC" | "canceledFOK" | "canceledMarketProtection" | "canceledPostOnly" | "filled" | "partiallyFilled" | "expired" | "rejected"
-	Status string `json:"status"`
+	Status OrderStatus `json:"status"`
 
 	// Side
 	// Enum: "buy" | "sell"
-	Side string `json:"side"`
+	Side Side `json:"side"`
 
 	// OrderType
 	// Enum: "market" | "limit" | "stopLoss" | "stopLossLimit" | "takeProfit" | "takeProfitLimit"
-	OrderType string `json:"orderType"`
+	OrderType OrderType `json:"orderType"`
 
 	// Original amount.
 	Amount float64 `json:"amount"`
 
+	// The original string value of Amount, as received from Bitvavo.
+	AmountStr string `json:"-"`
+
 	// Amount remaining (lower than 'amount' after fills).
 	AmountRemaining float64 `json:"amountRemaining"`
 
+	// The original string value of AmountRemaining, as received from Bitvavo.
+	AmountRemainingStr string `json:"-"`
+
 	// The price of the order.
 	Price float64 `json:"price"`
 
+	// The original string value of Price, as received from Bitvavo.
+	PriceStr string `json:"-"`
+
 	// Amount of 'onHoldCurrency' that is reserved for this order. This is released when orders are canceled.
 	OnHold float64 `json:"onHold"`
 
+	// The original string value of OnHold, as received from Bitvavo.
+	OnHoldStr string `json:"-"`
+
 	// The currency placed on hold is the quote currency for sell orders and base currency for buy orders.
 	OnHoldCurrency string `json:"onHoldCurrency"`
 
 	// Only for stop orders: The current price used in the trigger. This is based on the triggerAmount and triggerType.
 	TriggerPrice float64 `json:"triggerPrice"`
 
+	// The original string value of TriggerPrice, as received from Bitvavo.
+	TriggerPriceStr string `json:"-"`
+
 	// Only for stop orders: The value used for the triggerType to determine the triggerPrice.
 	TriggerAmount float64 `json:"triggerAmount"`
 
+	// The original string value of TriggerAmount, as received from Bitvavo.
+	TriggerAmountStr string `json:"-"`
+
 	// Only for stop orders.
 	//
 	// Enum: "price"
@@ -248,7 +283,7 @@ type Order struct {
 	// FOK orders will fill against existing orders in its entirety, or will be canceled (if the entire order cannot be filled).
 	//
 	// Enum: "GTC" | "IOC" | "FOK"
-	TimeInForce string `json:"timeInForce"`
+	TimeInForce TimeInForce `json:"timeInForce"`
 
 	// Default: false
 	PostOnly bool `json:"postOnly"`
@@ -272,14 +307,100 @@ type Order struct {
 	// How much of this order is filled
 	FilledAmount float64 `json:"filledAmount"`
 
+	// The original string value of FilledAmount, as received from Bitvavo.
+	FilledAmountStr string `json:"-"`
+
 	// How much of this order is filled in quote currency
 	FilledAmountQuote float64 `json:"filledAmountQuote"`
 
+	// The original string value of FilledAmountQuote, as received from Bitvavo.
+	FilledAmountQuoteStr string `json:"-"`
+
 	// The currency in which the fee is paid (e.g: EUR)
 	FeeCurrency string `json:"feeCurrency"`
 
 	// How much fee is paid
 	FeePaid float64 `json:"feePaid"`
+
+	// The original string value of FeePaid, as received from Bitvavo.
+	FeePaidStr string `json:"-"`
+}
+
+// CreatedTime returns the time the order was created.
+func (o Order) CreatedTime() time.Time {
+	return unixMilli(o.Created)
+}
+
+// UpdatedTime returns the time the order was last updated.
+func (o Order) UpdatedTime() time.Time {
+	return unixMilli(o.Updated)
+}
+
+// String returns the market, side, type, amount, price and status of the order (e.g: ETH-EUR buy limit 0.5@2000 status=new filled=0 orderId=...)
+func (o Order) String() string {
+	return fmt.Sprintf("%s %s %s %s@%s status=%s filled=%s orderId=%s", o.Market, o.Side, o.OrderType,
+		formatRequiredNumber(o.AmountStr, o.Amount), formatRequiredNumber(o.PriceStr, o.Price), o.Status,
+		formatRequiredNumber(o.FilledAmountStr, o.FilledAmount), o.OrderId)
+}
+
+func (o Order) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		OrderId             string      `json:"orderId"`
+		ClientOrderId       string      `json:"clientOrderId,omitempty"`
+		OperatorId          int64       `json:"operatorId,omitempty"`
+		Market              string      `json:"market"`
+		Created             int64       `json:"created"`
+		Updated             int64       `json:"updated"`
+		Status              OrderStatus `json:"status,omitempty"`
+		Side                Side        `json:"side,omitempty"`
+		OrderType           OrderType   `json:"orderType,omitempty"`
+		Amount              string      `json:"amount,omitempty"`
+		AmountRemaining     string      `json:"amountRemaining,omitempty"`
+		Price               string      `json:"price,omitempty"`
+		OnHold              string      `json:"onHold,omitempty"`
+		OnHoldCurrency      string      `json:"onHoldCurrency,omitempty"`
+		TriggerPrice        string      `json:"triggerPrice,omitempty"`
+		TriggerAmount       string      `json:"triggerAmount,omitempty"`
+		TriggerType         string      `json:"triggerType,omitempty"`
+		TriggerReference    string      `json:"triggerReference,omitempty"`
+		TimeInForce         TimeInForce `json:"timeInForce,omitempty"`
+		PostOnly            bool        `json:"postOnly"`
+		SelfTradePrevention string      `json:"selfTradePrevention,omitempty"`
+		Visible             bool        `json:"visible"`
+		Fills               []Fill      `json:"fills,omitempty"`
+		FilledAmount        string      `json:"filledAmount,omitempty"`
+		FilledAmountQuote   string      `json:"filledAmountQuote,omitempty"`
+		FeeCurrency         string      `json:"feeCurrency,omitempty"`
+		FeePaid             string      `json:"feePaid,omitempty"`
+	}{
+		OrderId:             o.OrderId,
+		ClientOrderId:       o.ClientOrderId,
+		OperatorId:          o.OperatorId,
+		Market:              o.Market,
+		Created:             o.Created,
+		Updated:             o.Updated,
+		Status:              o.Status,
+		Side:                o.Side,
+		OrderType:           o.OrderType,
+		Amount:              formatNumber(o.AmountStr, o.Amount),
+		AmountRemaining:     formatNumber(o.AmountRemainingStr, o.AmountRemaining),
+		Price:               formatNumber(o.PriceStr, o.Price),
+		OnHold:              formatNumber(o.OnHoldStr, o.OnHold),
+		OnHoldCurrency:      o.OnHoldCurrency,
+		TriggerPrice:        formatNumber(o.TriggerPriceStr, o.TriggerPrice),
+		TriggerAmount:       formatNumber(o.TriggerAmountStr, o.TriggerAmount),
+		TriggerType:         o.TriggerType,
+		TriggerReference:    o.TriggerReference,
+		TimeInForce:         o.TimeInForce,
+		PostOnly:            o.PostOnly,
+		SelfTradePrevention: o.SelfTradePrevention,
+		Visible:             o.Visible,
+		Fills:               o.Fills,
+		FilledAmount:        formatNumber(o.FilledAmountStr, o.FilledAmount),
+		FilledAmountQuote:   formatNumber(o.FilledAmountQuoteStr, o.FilledAmountQuote),
+		FeeCurrency:         o.FeeCurrency,
+		FeePaid:             formatNumber(o.FeePaidStr, o.FeePaid),
+	})
 }
 
 func (o *Order) UnmarshalJSON(bytes []byte) error {
@@ -290,36 +411,51 @@ func (o *Order) UnmarshalJSON(bytes []byte) error {
 	}
 
 	var (
-		orderId             = getOrEmpty[string]("orderId", j)
-		market              = getOrEmpty[string]("market", j)
-		created             = getOrEmpty[float64]("created", j)
-		updated             = getOrEmpty[float64]("updated", j)
-		status              = getOrEmpty[string]("status", j)
-		side                = getOrEmpty[string]("side", j)
-		orderType           = getOrEmpty[string]("orderType", j)
-		amount              = getOrEmpty[string]("amount", j)
-		amountRemaining     = getOrEmpty[string]("amountRemaining", j)
-		price               = getOrEmpty[string]("price", j)
-		onHold              = getOrEmpty[string]("onHold", j)
-		onHoldCurrency      = getOrEmpty[string]("onHoldCurrency", j)
-		timeInForce         = getOrEmpty[string]("timeInForce", j)
-		postOnly            = getOrEmpty[bool]("postOnly", j)
-		selfTradePrevention = getOrEmpty[string]("selfTradePrevention", j)
-		visible             = getOrEmpty[bool]("visible", j)
-
-		// only for stop orders
-		triggerPrice     = getOrEmpty[string]("triggerPrice", j)
-		triggerAmount    = getOrEmpty[string]("triggerAmount", j)
-		triggerType      = getOrEmpty[string]("triggerType", j)
-		triggerReference = getOrEmpty[string]("triggerReference", j)
-
-		fillsAny          = getOrEmpty[[]any]("fills", j)
-		filledAmount      = getOrEmpty[string]("filledAmount", j)
-		filledAmountQuote = getOrEmpty[string]("filledAmountQuote", j)
-		feeCurrency       = getOrEmpty[string]("feeCurrency", j)
-		feePaid           = getOrEmpty[string]("feePaid", j)
+		order = object{data: j}
+
+		status      = order.string("status")
+		side        = order.string("side")
+		orderType   = order.string("orderType")
+		timeInForce = order.string("timeInForce")
+		fillsAny    = order.array("fills")
 	)
 
+	o.OrderId = order.string("orderId")
+	o.ClientOrderId = order.string("clientOrderId")
+	o.OperatorId = order.int("operatorId")
+	o.Market = order.string("market")
+	o.Created = order.int("created")
+	o.Updated = order.int("updated")
+	o.Amount, o.AmountStr = order.number("amount")
+	o.AmountRemaining, o.AmountRemainingStr = order.number("amountRemaining")
+	o.Price, o.PriceStr = order.number("price")
+	o.OnHold, o.OnHoldStr = order.number("onHold")
+	o.OnHoldCurrency = order.string("onHoldCurrency")
+	o.PostOnly = order.bool("postOnly")
+	o.SelfTradePrevention = order.string("selfTradePrevention")
+	o.Visible = order.bool("visible")
+
+	// only for stop orders
+	o.TriggerPrice, o.TriggerPriceStr = order.number("triggerPrice")
+	o.TriggerAmount, o.TriggerAmountStr = order.number("triggerAmount")
+	o.TriggerType = order.string("triggerType")
+	o.TriggerReference = order.string("triggerReference")
+
+	o.FilledAmount, o.FilledAmountStr = order.number("filledAmount")
+	o.FilledAmountQuote, o.FilledAmountQuoteStr = order.number("filledAmountQuote")
+	o.FeeCurrency = order.string("feeCurrency")
+	o.FeePaid, o.FeePaidStr = order.number("feePaid")
+
+	if order.err != nil {
+		return order.err
+	}
+
+	// unknown values are kept as is, so a value which Bitvavo adds later doesn't fail the whole response (see: IsValid)
+	o.Status = OrderStatus(status)
+	o.Side = Side(side)
+	o.OrderType = OrderType(orderType)
+	o.TimeInForce = TimeInForce(timeInForce)
+
 	if len(fillsAny) > 0 {
 		fillsBytes, err := json.Marshal(fillsAny)
 		if err != nil {
@@ -332,30 +468,5 @@ func (o *Order) UnmarshalJSON(bytes []byte) error {
 		o.Fills = fills
 	}
 
-	o.OrderId = orderId
-	o.Market = market
-	o.Created = int64(created)
-	o.Updated = int64(updated)
-	o.Status = status
-	o.Side = side
-	o.OrderType = orderType
-	o.Amount = util.IfOrElse(len(amount) > 0, func() float64 { return util.MustFloat64(amount) }, 0)
-	o.AmountRemaining = util.IfOrElse(len(amountRemaining) > 0, func() float64 { return util.MustFloat64(amountRemaining) }, 0)
-	o.Price = util.IfOrElse(len(price) > 0, func() float64 { return util.MustFloat64(price) }, 0)
-	o.OnHold = util.IfOrElse(len(onHold) > 0, func() float64 { return util.MustFloat64(onHold) }, 0)
-	o.OnHoldCurrency = onHoldCurrency
-	o.TriggerPrice = util.IfOrElse(len(triggerPrice) > 0, func() float64 { return util.MustFloat64(triggerPrice) }, 0)
-	o.TriggerAmount = util.IfOrElse(len(triggerAmount) > 0, func() float64 { return util.MustFloat64(triggerAmount) }, 0)
-	o.TriggerType = triggerType
-	o.TriggerReference = triggerReference
-	o.TimeInForce = timeInForce
-	o.PostOnly = postOnly
-	o.SelfTradePrevention = selfTradePrevention
-	o.Visible = visible
-	o.FilledAmount = util.IfOrElse(len(filledAmount) > 0, func() float64 { return util.MustFloat64(filledAmount) }, 0)
-	o.FilledAmountQuote = util.IfOrElse(len(filledAmountQuote) > 0, func() float64 { return util.MustFloat64(filledAmountQuote) }, 0)
-	o.FeeCurrency = feeCurrency
-	o.FeePaid = util.IfOrElse(len(feePaid) > 0, func() float64 { return util.MustFloat64(feePaid) }, 0)
-
 	return nil
 }
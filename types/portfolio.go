@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+)
+
+type Portfolio struct {
+	// The quote currency in which the assets are valued (e.g: EUR)
+	Quote string `json:"quote"`
+
+	// The value of every asset with a balance, sorted by value (highest first)
+	Assets []AssetValue `json:"assets"`
+
+	// The total value of the assets in quote currency.
+	Total float64 `json:"total"`
+}
+
+type AssetValue struct {
+	// Short version of asset name.
+	Symbol string `json:"symbol"`
+
+	// The total amount of the asset (available and inOrder)
+	Amount float64 `json:"amount"`
+
+	// The price of one unit of the asset in quote currency, 0 if there is no market to value the asset.
+	Price float64 `json:"price"`
+
+	// The value of the asset in quote currency (amount * price)
+	Value float64 `json:"value"`
+
+	// The share of the asset in the total value of the portfolio in percent (e.g: 25.5)
+	Allocation float64 `json:"allocation"`
+}
+
+// NewPortfolio values balances in quote currency (e.g: EUR) with the latest prices of the markets.
+//
+// An asset is valued with the market {symbol}-{quote} or else with the inverse market {quote}-{symbol},
+// assets without either market have a price and value of 0.
+func NewPortfolio(quote string, balances []Balance, prices []TickerPrice) Portfolio {
+	priceByMarket := make(map[string]float64, len(prices))
+	for _, price := range prices {
+		priceByMarket[price.Market] = price.Price
+	}
+
+	portfolio := Portfolio{
+		Quote:  quote,
+		Assets: make([]AssetValue, 0, len(balances)),
+	}
+	for _, balance := range balances {
+		amount := balance.Available + balance.InOrder
+		if amount == 0 {
+			continue
+		}
+
+		price := valuationPrice(balance.Symbol, quote, priceByMarket)
+		asset := AssetValue{
+			Symbol: balance.Symbol,
+			Amount: amount,
+			Price:  price,
+			Value:  amount * price,
+		}
+		portfolio.Assets = append(portfolio.Assets, asset)
+		portfolio.Total += asset.Value
+	}
+	for i := range portfolio.Assets {
+		if portfolio.Total > 0 {
+			portfolio.Assets[i].Allocation = portfolio.Assets[i].Value / portfolio.Total * 100
+		}
+	}
+	sort.SliceStable(portfolio.Assets, func(i, j int) bool { return portfolio.Assets[i].Value > portfolio.Assets[j].Value })
+
+	return portfolio
+}
+
+func valuationPrice(symbol string, quote string, priceByMarket map[string]float64) float64 {
+	if symbol == quote {
+		return 1
+	}
+	if price, found := priceByMarket[fmt.Sprintf("%s-%s", symbol, quote)]; found {
+		return price
+	}
+	if price, found := priceByMarket[fmt.Sprintf("%s-%s", quote, symbol)]; found && price > 0 {
+		return 1 / price
+	}
+	return 0
+}
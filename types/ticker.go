@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"github.com/goccy/go-json"
 	"github.com/larscom/go-bitvavo/v2/util"
 )
@@ -9,17 +10,56 @@ type Ticker struct {
 	// The price of the best (highest) bid offer available, only sent when either bestBid or bestBidSize has changed.
 	BestBid float64 `json:"bestBid"`
 
+	// The original string value of BestBid, as received from Bitvavo.
+	BestBidStr string `json:"-"`
+
 	// The size of the best (highest) bid offer available, only sent when either bestBid or bestBidSize has changed.
 	BestBidSize float64 `json:"bestBidSize"`
 
+	// The original string value of BestBidSize, as received from Bitvavo.
+	BestBidSizeStr string `json:"-"`
+
 	// The price of the best (lowest) ask offer available, only sent when either bestAsk or bestAskSize has changed.
 	BestAsk float64 `json:"bestAsk"`
 
+	// The original string value of BestAsk, as received from Bitvavo.
+	BestAskStr string `json:"-"`
+
 	// The size of the best (lowest) ask offer available, only sent when either bestAsk or bestAskSize has changed.
 	BestAskSize float64 `json:"bestAskSize"`
 
+	// The original string value of BestAskSize, as received from Bitvavo.
+	BestAskSizeStr string `json:"-"`
+
 	// The last price for which a trade has occurred, only sent when lastPrice has changed.
 	LastPrice float64 `json:"lastPrice"`
+
+	// The original string value of LastPrice, as received from Bitvavo.
+	LastPriceStr string `json:"-"`
+}
+
+// String returns the best bid and ask and the last price (e.g: bid=0.5@2000 ask=1@2001 last=2000.5)
+func (t Ticker) String() string {
+	return fmt.Sprintf("bid=%s@%s ask=%s@%s last=%s",
+		formatRequiredNumber(t.BestBidSizeStr, t.BestBidSize), formatRequiredNumber(t.BestBidStr, t.BestBid),
+		formatRequiredNumber(t.BestAskSizeStr, t.BestAskSize), formatRequiredNumber(t.BestAskStr, t.BestAsk),
+		formatRequiredNumber(t.LastPriceStr, t.LastPrice))
+}
+
+func (t Ticker) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		BestBid     string `json:"bestBid,omitempty"`
+		BestBidSize string `json:"bestBidSize,omitempty"`
+		BestAsk     string `json:"bestAsk,omitempty"`
+		BestAskSize string `json:"bestAskSize,omitempty"`
+		LastPrice   string `json:"lastPrice,omitempty"`
+	}{
+		BestBid:     formatNumber(t.BestBidStr, t.BestBid),
+		BestBidSize: formatNumber(t.BestBidSizeStr, t.BestBidSize),
+		BestAsk:     formatNumber(t.BestAskStr, t.BestAsk),
+		BestAskSize: formatNumber(t.BestAskSizeStr, t.BestAskSize),
+		LastPrice:   formatNumber(t.LastPriceStr, t.LastPrice),
+	})
 }
 
 func (t *Ticker) UnmarshalJSON(bytes []byte) error {
@@ -38,10 +78,15 @@ func (t *Ticker) UnmarshalJSON(bytes []byte) error {
 	)
 
 	t.BestBid = util.IfOrElse(len(bestBid) > 0, func() float64 { return util.MustFloat64(bestBid) }, 0)
+	t.BestBidStr = bestBid
 	t.BestBidSize = util.IfOrElse(len(bestBidSize) > 0, func() float64 { return util.MustFloat64(bestBidSize) }, 0)
+	t.BestBidSizeStr = bestBidSize
 	t.BestAsk = util.IfOrElse(len(bestAsk) > 0, func() float64 { return util.MustFloat64(bestAsk) }, 0)
+	t.BestAskStr = bestAsk
 	t.BestAskSize = util.IfOrElse(len(bestAskSize) > 0, func() float64 { return util.MustFloat64(bestAskSize) }, 0)
+	t.BestAskSizeStr = bestAskSize
 	t.LastPrice = util.IfOrElse(len(lastPrice) > 0, func() float64 { return util.MustFloat64(lastPrice) }, 0)
+	t.LastPriceStr = lastPrice
 
 	return nil
 }
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/goccy/go-json"
 	"github.com/larscom/go-bitvavo/v2/util"
 )
@@ -9,33 +12,63 @@ type Ticker24h struct {
 	// The open price of the 24 hour period.
 	Open float64 `json:"open"`
 
+	// The original string value of Open, as received from Bitvavo.
+	OpenStr string `json:"-"`
+
 	// The highest price for which a trade occurred in the 24 hour period.
 	High float64 `json:"high"`
 
+	// The original string value of High, as received from Bitvavo.
+	HighStr string `json:"-"`
+
 	// The lowest price for which a trade occurred in the 24 hour period.
 	Low float64 `json:"low"`
 
+	// The original string value of Low, as received from Bitvavo.
+	LowStr string `json:"-"`
+
 	// The last price for which a trade occurred in the 24 hour period.
 	Last float64 `json:"last"`
 
+	// The original string value of Last, as received from Bitvavo.
+	LastStr string `json:"-"`
+
 	// The total volume of the 24 hour period in base currency.
 	Volume float64 `json:"volume"`
 
+	// The original string value of Volume, as received from Bitvavo.
+	VolumeStr string `json:"-"`
+
 	// The total volume of the 24 hour period in quote currency.
 	VolumeQuote float64 `json:"volumeQuote"`
 
+	// The original string value of VolumeQuote, as received from Bitvavo.
+	VolumeQuoteStr string `json:"-"`
+
 	// The best (highest) bid offer at the current moment.
 	Bid float64 `json:"bid"`
 
+	// The original string value of Bid, as received from Bitvavo.
+	BidStr string `json:"-"`
+
 	// The size of the best (highest) bid offer.
 	BidSize float64 `json:"bidSize"`
 
+	// The original string value of BidSize, as received from Bitvavo.
+	BidSizeStr string `json:"-"`
+
 	// The best (lowest) ask offer at the current moment.
 	Ask float64 `json:"ask"`
 
+	// The original string value of Ask, as received from Bitvavo.
+	AskStr string `json:"-"`
+
 	// The size of the best (lowest) ask offer.
 	AskSize float64 `json:"askSize"`
 
+	// The original string value of AskSize, as received from Bitvavo.
+	AskSizeStr string `json:"-"`
+
 	// Timestamp in unix milliseconds.
 	Timestamp int64 `json:"timestamp"`
 
@@ -49,6 +82,67 @@ type Ticker24h struct {
 	CloseTimestamp int64 `json:"closeTimestamp"`
 }
 
+// Time returns the time the ticker was calculated.
+func (t Ticker24h) Time() time.Time {
+	return unixMilli(t.Timestamp)
+}
+
+// StartTime returns the start of the 24 hour window.
+func (t Ticker24h) StartTime() time.Time {
+	return unixMilli(t.StartTimestamp)
+}
+
+// OpenTime returns the time of the first trade in the 24 hour window.
+func (t Ticker24h) OpenTime() time.Time {
+	return unixMilli(t.OpenTimestamp)
+}
+
+// CloseTime returns the time of the last trade in the 24 hour window.
+func (t Ticker24h) CloseTime() time.Time {
+	return unixMilli(t.CloseTimestamp)
+}
+
+// String returns the prices and volume of the 24 hour window (e.g: open=1900 high=2050 low=1890 last=2000 volume=1500.5)
+func (t Ticker24h) String() string {
+	return fmt.Sprintf("open=%s high=%s low=%s last=%s volume=%s",
+		formatRequiredNumber(t.OpenStr, t.Open), formatRequiredNumber(t.HighStr, t.High), formatRequiredNumber(t.LowStr, t.Low),
+		formatRequiredNumber(t.LastStr, t.Last), formatRequiredNumber(t.VolumeStr, t.Volume))
+}
+
+func (t Ticker24h) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Open           string `json:"open,omitempty"`
+		High           string `json:"high,omitempty"`
+		Low            string `json:"low,omitempty"`
+		Last           string `json:"last,omitempty"`
+		Volume         string `json:"volume,omitempty"`
+		VolumeQuote    string `json:"volumeQuote,omitempty"`
+		Bid            string `json:"bid,omitempty"`
+		BidSize        string `json:"bidSize,omitempty"`
+		Ask            string `json:"ask,omitempty"`
+		AskSize        string `json:"askSize,omitempty"`
+		Timestamp      int64  `json:"timestamp"`
+		StartTimestamp int64  `json:"startTimestamp"`
+		OpenTimestamp  int64  `json:"openTimestamp"`
+		CloseTimestamp int64  `json:"closeTimestamp"`
+	}{
+		Open:           formatNumber(t.OpenStr, t.Open),
+		High:           formatNumber(t.HighStr, t.High),
+		Low:            formatNumber(t.LowStr, t.Low),
+		Last:           formatNumber(t.LastStr, t.Last),
+		Volume:         formatNumber(t.VolumeStr, t.Volume),
+		VolumeQuote:    formatNumber(t.VolumeQuoteStr, t.VolumeQuote),
+		Bid:            formatNumber(t.BidStr, t.Bid),
+		BidSize:        formatNumber(t.BidSizeStr, t.BidSize),
+		Ask:            formatNumber(t.AskStr, t.Ask),
+		AskSize:        formatNumber(t.AskSizeStr, t.AskSize),
+		Timestamp:      t.Timestamp,
+		StartTimestamp: t.StartTimestamp,
+		OpenTimestamp:  t.OpenTimestamp,
+		CloseTimestamp: t.CloseTimestamp,
+	})
+}
+
 func (t *Ticker24h) UnmarshalJSON(bytes []byte) error {
 	var j map[string]any
 
@@ -74,15 +168,25 @@ func (t *Ticker24h) UnmarshalJSON(bytes []byte) error {
 	)
 
 	t.Open = util.IfOrElse(len(open) > 0, func() float64 { return util.MustFloat64(open) }, 0)
+	t.OpenStr = open
 	t.High = util.IfOrElse(len(high) > 0, func() float64 { return util.MustFloat64(high) }, 0)
+	t.HighStr = high
 	t.Low = util.IfOrElse(len(low) > 0, func() float64 { return util.MustFloat64(low) }, 0)
+	t.LowStr = low
 	t.Last = util.IfOrElse(len(last) > 0, func() float64 { return util.MustFloat64(last) }, 0)
+	t.LastStr = last
 	t.Volume = util.IfOrElse(len(volume) > 0, func() float64 { return util.MustFloat64(volume) }, 0)
+	t.VolumeStr = volume
 	t.VolumeQuote = util.IfOrElse(len(volumeQuote) > 0, func() float64 { return util.MustFloat64(volumeQuote) }, 0)
+	t.VolumeQuoteStr = volumeQuote
 	t.Bid = util.IfOrElse(len(bid) > 0, func() float64 { return util.MustFloat64(bid) }, 0)
+	t.BidStr = bid
 	t.BidSize = util.IfOrElse(len(bidSize) > 0, func() float64 { return util.MustFloat64(bidSize) }, 0)
+	t.BidSizeStr = bidSize
 	t.Ask = util.IfOrElse(len(ask) > 0, func() float64 { return util.MustFloat64(ask) }, 0)
+	t.AskStr = ask
 	t.AskSize = util.IfOrElse(len(askSize) > 0, func() float64 { return util.MustFloat64(askSize) }, 0)
+	t.AskSizeStr = askSize
 	t.Timestamp = int64(timestamp)
 	t.StartTimestamp = int64(startTimestamp)
 	t.OpenTimestamp = int64(openTimestamp)
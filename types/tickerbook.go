@@ -12,14 +12,42 @@ type TickerBook struct {
 	// The highest buy order in quote currency for market currently available on Bitvavo.
 	Bid float64 `json:"bid"`
 
+	// The original string value of Bid, as received from Bitvavo.
+	BidStr string `json:"-"`
+
 	// The amount of base currency for bid in the order.
 	BidSize float64 `json:"bidSize"`
 
+	// The original string value of BidSize, as received from Bitvavo.
+	BidSizeStr string `json:"-"`
+
 	// The lowest sell order in quote currency for market currently available on Bitvavo.
 	Ask float64 `json:"ask"`
 
+	// The original string value of Ask, as received from Bitvavo.
+	AskStr string `json:"-"`
+
 	// The amount of base currency for ask in the order.
 	AskSize float64 `json:"askSize"`
+
+	// The original string value of AskSize, as received from Bitvavo.
+	AskSizeStr string `json:"-"`
+}
+
+func (t TickerBook) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Market  string `json:"market"`
+		Bid     string `json:"bid,omitempty"`
+		BidSize string `json:"bidSize,omitempty"`
+		Ask     string `json:"ask,omitempty"`
+		AskSize string `json:"askSize,omitempty"`
+	}{
+		Market:  t.Market,
+		Bid:     formatNumber(t.BidStr, t.Bid),
+		BidSize: formatNumber(t.BidSizeStr, t.BidSize),
+		Ask:     formatNumber(t.AskStr, t.Ask),
+		AskSize: formatNumber(t.AskSizeStr, t.AskSize),
+	})
 }
 
 func (t *TickerBook) UnmarshalJSON(bytes []byte) error {
@@ -38,9 +66,13 @@ func (t *TickerBook) UnmarshalJSON(bytes []byte) error {
 	)
 	t.Market = market
 	t.Bid = util.IfOrElse(len(bid) > 0, func() float64 { return util.MustFloat64(bid) }, 0)
+	t.BidStr = bid
 	t.BidSize = util.IfOrElse(len(bidSize) > 0, func() float64 { return util.MustFloat64(bidSize) }, 0)
+	t.BidSizeStr = bidSize
 	t.Ask = util.IfOrElse(len(ask) > 0, func() float64 { return util.MustFloat64(ask) }, 0)
+	t.AskStr = ask
 	t.AskSize = util.IfOrElse(len(askSize) > 0, func() float64 { return util.MustFloat64(askSize) }, 0)
+	t.AskSizeStr = askSize
 
 	return nil
 }
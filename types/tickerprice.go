@@ -11,6 +11,19 @@ type TickerPrice struct {
 
 	// The latest trade price for 1 base currency in quote currency for market. For example, 34243 Euro.
 	Price float64 `json:"price"`
+
+	// The original string value of Price, as received from Bitvavo.
+	PriceStr string `json:"-"`
+}
+
+func (t TickerPrice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Market string `json:"market"`
+		Price  string `json:"price,omitempty"`
+	}{
+		Market: t.Market,
+		Price:  formatNumber(t.PriceStr, t.Price),
+	})
 }
 
 func (t *TickerPrice) UnmarshalJSON(bytes []byte) error {
@@ -27,6 +40,7 @@ func (t *TickerPrice) UnmarshalJSON(bytes []byte) error {
 
 	t.Market = market
 	t.Price = util.IfOrElse(len(price) > 0, func() float64 { return util.MustFloat64(price) }, 0)
+	t.PriceStr = price
 
 	return nil
 }
@@ -49,6 +49,38 @@ func (t *TradeParams) Params() url.Values {
 
 type TradeHistoric Fill
 
+// Time returns the time the trade was made.
+func (t TradeHistoric) Time() time.Time {
+	return unixMilli(t.Timestamp)
+}
+
+func (t TradeHistoric) String() string {
+	return Fill(t).String()
+}
+
+func (t TradeHistoric) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newFillJSON(Fill(t)))
+}
+
+func (t *TradeHistoric) UnmarshalJSON(bytes []byte) error {
+	var fill Fill
+	if err := fill.UnmarshalJSON(bytes); err != nil {
+		return err
+	}
+
+	var j map[string]any
+	if err := json.Unmarshal(bytes, &j); err != nil {
+		return err
+	}
+
+	*t = TradeHistoric(fill)
+	if t.FillId == "" {
+		t.FillId = getOrEmpty[string]("id", j)
+	}
+
+	return nil
+}
+
 type Trade struct {
 	// The trade ID of the returned trade (UUID).
 	Id string `json:"id"`
@@ -56,17 +88,50 @@ type Trade struct {
 	// The amount in base currency for which the trade has been made.
 	Amount float64 `json:"amount"`
 
+	// The original string value of Amount, as received from Bitvavo.
+	AmountStr string `json:"-"`
+
 	// The price in quote currency for which the trade has been made.
 	Price float64 `json:"price"`
 
+	// The original string value of Price, as received from Bitvavo.
+	PriceStr string `json:"-"`
+
 	// The side for the taker.
 	// Enum: "buy" | "sell"
-	Side string `json:"side"`
+	Side Side `json:"side"`
 
 	// Timestamp in unix milliseconds.
 	Timestamp int64 `json:"timestamp"`
 }
 
+// Time returns the time the trade was made.
+func (t Trade) Time() time.Time {
+	return unixMilli(t.Timestamp)
+}
+
+// String returns the side, amount and price of the trade (e.g: buy 0.5@2000 at 2024-01-02T15:04:05Z)
+func (t Trade) String() string {
+	return fmt.Sprintf("%s %s@%s at %s", t.Side,
+		formatRequiredNumber(t.AmountStr, t.Amount), formatRequiredNumber(t.PriceStr, t.Price), formatTime(t.Timestamp))
+}
+
+func (t Trade) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Id        string `json:"id"`
+		Amount    string `json:"amount,omitempty"`
+		Price     string `json:"price,omitempty"`
+		Side      Side   `json:"side,omitempty"`
+		Timestamp int64  `json:"timestamp"`
+	}{
+		Id:        t.Id,
+		Amount:    formatNumber(t.AmountStr, t.Amount),
+		Price:     formatNumber(t.PriceStr, t.Price),
+		Side:      t.Side,
+		Timestamp: t.Timestamp,
+	})
+}
+
 func (t *Trade) UnmarshalJSON(bytes []byte) error {
 	var j map[string]any
 
@@ -82,10 +147,14 @@ func (t *Trade) UnmarshalJSON(bytes []byte) error {
 		timestamp = getOrEmpty[float64]("timestamp", j)
 	)
 
+	// an unknown side is kept as is (see: Side.IsValid)
+	t.Side = Side(side)
+
 	t.Id = id
 	t.Amount = util.IfOrElse(len(amount) > 0, func() float64 { return util.MustFloat64(amount) }, 0)
+	t.AmountStr = amount
 	t.Price = util.IfOrElse(len(price) > 0, func() float64 { return util.MustFloat64(price) }, 0)
-	t.Side = side
+	t.PriceStr = price
 	t.Timestamp = int64(timestamp)
 
 	return nil
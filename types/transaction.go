@@ -0,0 +1,178 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+type TransactionHistoryParams struct {
+	// Return transactions after fromDate.
+	FromDate time.Time `json:"fromDate"`
+
+	// Return transactions before toDate.
+	ToDate time.Time `json:"toDate"`
+
+	// The page to return, starting at 1.
+	// Default: 1
+	Page uint64 `json:"page"`
+
+	// The max number of transactions per page (1-100)
+	// Default: 100
+	MaxItems uint64 `json:"maxItems"`
+}
+
+func (t *TransactionHistoryParams) Params() url.Values {
+	params := make(url.Values)
+
+	if !t.FromDate.IsZero() {
+		params.Add("fromDate", fmt.Sprint(t.FromDate.UnixMilli()))
+	}
+	if !t.ToDate.IsZero() {
+		params.Add("toDate", fmt.Sprint(t.ToDate.UnixMilli()))
+	}
+	if t.Page > 0 {
+		params.Add("page", fmt.Sprint(t.Page))
+	}
+	if t.MaxItems > 0 {
+		params.Add("maxItems", fmt.Sprint(t.MaxItems))
+	}
+
+	return params
+}
+
+type TransactionHistory struct {
+	// The transactions on this page.
+	Items []Transaction `json:"items"`
+
+	// The current page, starting at 1.
+	CurrentPage uint64 `json:"currentPage"`
+
+	// The total number of pages.
+	TotalPages uint64 `json:"totalPages"`
+
+	// The max number of transactions per page.
+	MaxItems uint64 `json:"maxItems"`
+}
+
+type Transaction struct {
+	// The identifier of this transaction.
+	TransactionId string `json:"transactionId"`
+
+	// The time this transaction was executed in milliseconds since 1 Jan 1970
+	ExecutedAt int64 `json:"executedAt"`
+
+	// The type of this transaction.
+	//
+	// Enum: "sell" | "buy" | "staking" | "fixed_staking" | "deposit" | "withdrawal" | "affiliate" | "distribution" | "internal_transfer" | "withdrawal_cancelled" | "rebate" | "loan" | "external_transferred_funds" | "manually_assigned_bitvavo"
+	Type string `json:"type"`
+
+	// The currency in which the price is denoted (e.g: EUR)
+	PriceCurrency string `json:"priceCurrency"`
+
+	// The price of the asset at the moment of the transaction.
+	PriceAmount float64 `json:"priceAmount"`
+
+	// The currency which is sent (e.g: EUR)
+	SentCurrency string `json:"sentCurrency"`
+
+	// The amount which is sent.
+	SentAmount float64 `json:"sentAmount"`
+
+	// The currency which is received (e.g: ETH)
+	ReceivedCurrency string `json:"receivedCurrency"`
+
+	// The amount which is received.
+	ReceivedAmount float64 `json:"receivedAmount"`
+
+	// The currency in which the fees are paid.
+	FeesCurrency string `json:"feesCurrency"`
+
+	// The amount of fees paid.
+	FeesAmount float64 `json:"feesAmount"`
+
+	// The address of a deposit or withdrawal.
+	Address string `json:"address"`
+}
+
+// ExecutedTime returns the time the transaction was executed.
+func (t Transaction) ExecutedTime() time.Time {
+	return unixMilli(t.ExecutedAt)
+}
+
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	var executedAt string
+	if executed := t.ExecutedTime(); !executed.IsZero() {
+		executedAt = executed.UTC().Format(time.RFC3339Nano)
+	}
+
+	return json.Marshal(struct {
+		TransactionId    string `json:"transactionId"`
+		ExecutedAt       string `json:"executedAt,omitempty"`
+		Type             string `json:"type"`
+		PriceCurrency    string `json:"priceCurrency,omitempty"`
+		PriceAmount      string `json:"priceAmount,omitempty"`
+		SentCurrency     string `json:"sentCurrency,omitempty"`
+		SentAmount       string `json:"sentAmount,omitempty"`
+		ReceivedCurrency string `json:"receivedCurrency,omitempty"`
+		ReceivedAmount   string `json:"receivedAmount,omitempty"`
+		FeesCurrency     string `json:"feesCurrency,omitempty"`
+		FeesAmount       string `json:"feesAmount,omitempty"`
+		Address          string `json:"address,omitempty"`
+	}{
+		TransactionId:    t.TransactionId,
+		ExecutedAt:       executedAt,
+		Type:             t.Type,
+		PriceCurrency:    t.PriceCurrency,
+		PriceAmount:      formatNumber("", t.PriceAmount),
+		SentCurrency:     t.SentCurrency,
+		SentAmount:       formatNumber("", t.SentAmount),
+		ReceivedCurrency: t.ReceivedCurrency,
+		ReceivedAmount:   formatNumber("", t.ReceivedAmount),
+		FeesCurrency:     t.FeesCurrency,
+		FeesAmount:       formatNumber("", t.FeesAmount),
+		Address:          t.Address,
+	})
+}
+
+func (t *Transaction) UnmarshalJSON(bytes []byte) error {
+	var j map[string]any
+
+	if err := json.Unmarshal(bytes, &j); err != nil {
+		return err
+	}
+
+	var (
+		transaction = object{data: j}
+
+		executedAt = transaction.string("executedAt")
+	)
+
+	t.TransactionId = transaction.string("transactionId")
+	t.Type = transaction.string("type")
+	t.PriceCurrency = transaction.string("priceCurrency")
+	t.PriceAmount, _ = transaction.number("priceAmount")
+	t.SentCurrency = transaction.string("sentCurrency")
+	t.SentAmount, _ = transaction.number("sentAmount")
+	t.ReceivedCurrency = transaction.string("receivedCurrency")
+	t.ReceivedAmount, _ = transaction.number("receivedAmount")
+	t.FeesCurrency = transaction.string("feesCurrency")
+	t.FeesAmount, _ = transaction.number("feesAmount")
+	t.Address = transaction.string("address")
+
+	if transaction.err != nil {
+		return transaction.err
+	}
+
+	if len(executedAt) > 0 {
+		executed, err := time.Parse(time.RFC3339Nano, executedAt)
+		if err != nil {
+			return err
+		}
+		t.ExecutedAt = executed.UnixMilli()
+	}
+
+	return nil
+}
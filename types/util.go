@@ -1,6 +1,10 @@
 package types
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/larscom/go-bitvavo/v2/util"
 )
 
@@ -9,3 +13,143 @@ func getOrEmpty[T any](key string, data map[string]any) T {
 	value, exist := data[key]
 	return util.IfOrElse(exist && value != nil, func() T { return value.(T) }, empty)
 }
+
+// object reads the fields of a decoded JSON object without panicking. Fields of an alternate type are converted
+// whenever possible (e.g: a number which is sent as string) and the first field which can't be read is kept as err.
+type object struct {
+	data map[string]any
+	err  error
+}
+
+func (o *object) fail(err error) {
+	if o.err == nil {
+		o.err = err
+	}
+}
+
+func (o *object) failType(key string, value any) {
+	o.fail(fmt.Errorf("unexpected type of field %s: %T", key, value))
+}
+
+func (o *object) string(key string) string {
+	switch value := o.data[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		o.failType(key, value)
+		return ""
+	}
+}
+
+// number returns the value of a number which is sent as string, along with the string itself.
+func (o *object) number(key string) (float64, string) {
+	switch value := o.data[key].(type) {
+	case nil:
+		return 0, ""
+	case float64:
+		return value, strconv.FormatFloat(value, 'f', -1, 64)
+	case string:
+		if value == "" {
+			return 0, ""
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			o.fail(fmt.Errorf("invalid number of field %s: %w", key, err))
+		}
+		return parsed, value
+	default:
+		o.failType(key, value)
+		return 0, ""
+	}
+}
+
+func (o *object) int(key string) int64 {
+	switch value := o.data[key].(type) {
+	case nil:
+		return 0
+	case float64:
+		return int64(value)
+	case string:
+		if value == "" {
+			return 0
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			o.fail(fmt.Errorf("invalid integer of field %s: %w", key, err))
+		}
+		return parsed
+	default:
+		o.failType(key, value)
+		return 0
+	}
+}
+
+func (o *object) bool(key string) bool {
+	switch value := o.data[key].(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case string:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			o.fail(fmt.Errorf("invalid boolean of field %s: %w", key, err))
+		}
+		return parsed
+	default:
+		o.failType(key, value)
+		return false
+	}
+}
+
+func (o *object) array(key string) []any {
+	switch value := o.data[key].(type) {
+	case nil:
+		return nil
+	case []any:
+		return value
+	default:
+		o.failType(key, value)
+		return nil
+	}
+}
+
+// unixMilli returns the time of a timestamp in milliseconds since 1 Jan 1970, a zero timestamp returns the zero time.
+func unixMilli(timestamp int64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(timestamp)
+}
+
+// formatTime returns timestamp (in milliseconds since 1 Jan 1970) in RFC3339 format in UTC.
+func formatTime(timestamp int64) string {
+	return unixMilli(timestamp).UTC().Format(time.RFC3339)
+}
+
+// formatNumber returns the original string value as received from Bitvavo if it still equals value,
+// otherwise value is formatted without an exponent. An unset value (zero without original string value) returns
+// an empty string, so it's omitted and unmarshals to the same value again.
+func formatNumber(str string, value float64) string {
+	if str != "" {
+		if parsed, err := strconv.ParseFloat(str, 64); err == nil && parsed == value {
+			return str
+		}
+	}
+	if value == 0 && str == "" {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatRequiredNumber is the same as formatNumber, but an unset value returns 0.
+func formatRequiredNumber(str string, value float64) string {
+	if formatted := formatNumber(str, value); formatted != "" {
+		return formatted
+	}
+	return "0"
+}
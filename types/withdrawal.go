@@ -71,6 +71,33 @@ type WithdrawalHistory struct {
 	Status string `json:"status"`
 }
 
+// Time returns the time the withdrawal was made.
+func (w WithdrawalHistory) Time() time.Time {
+	return unixMilli(w.Timestamp)
+}
+
+func (w WithdrawalHistory) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Timestamp int64  `json:"timestamp"`
+		Symbol    string `json:"symbol"`
+		Amount    string `json:"amount,omitempty"`
+		Address   string `json:"address,omitempty"`
+		PaymentId string `json:"paymentId,omitempty"`
+		TxId      string `json:"txId,omitempty"`
+		Fee       string `json:"fee,omitempty"`
+		Status    string `json:"status,omitempty"`
+	}{
+		Timestamp: w.Timestamp,
+		Symbol:    w.Symbol,
+		Amount:    formatNumber("", w.Amount),
+		Address:   w.Address,
+		PaymentId: w.PaymentId,
+		TxId:      w.TxId,
+		Fee:       formatNumber("", w.Fee),
+		Status:    w.Status,
+	})
+}
+
 func (w *WithdrawalHistory) UnmarshalJSON(bytes []byte) error {
 	var j map[string]any
 
@@ -137,6 +164,18 @@ type WithDrawalResponse struct {
 	Amount float64 `json:"amount"`
 }
 
+func (r WithDrawalResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Success bool   `json:"success"`
+		Symbol  string `json:"symbol"`
+		Amount  string `json:"amount,omitempty"`
+	}{
+		Success: r.Success,
+		Symbol:  r.Symbol,
+		Amount:  formatNumber("", r.Amount),
+	})
+}
+
 func (r *WithDrawalResponse) UnmarshalJSON(bytes []byte) error {
 	var j map[string]any
 
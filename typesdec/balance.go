@@ -0,0 +1,14 @@
+package typesdec
+
+import "github.com/shopspring/decimal"
+
+type Balance struct {
+	// Short version of the asset name used in market names.
+	Symbol string `json:"symbol"`
+
+	// Balance freely available.
+	Available decimal.Decimal `json:"available"`
+
+	// Balance currently placed onHold for open orders.
+	InOrder decimal.Decimal `json:"inOrder"`
+}
@@ -0,0 +1,40 @@
+package typesdec
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/shopspring/decimal"
+)
+
+type Book struct {
+	// Integer which is increased by one for every update to the book. Useful for synchronizing.
+	Nonce int64 `json:"nonce"`
+
+	// Slice with all bids in the format [price, size], where an size of 0 means orders are no longer present at that price level.
+	Bids []Page `json:"bids"`
+
+	// Slice with all asks in the format [price, size], where an size of 0 means orders are no longer present at that price level.
+	Asks []Page `json:"asks"`
+}
+
+type Page struct {
+	// Bid / ask price.
+	Price decimal.Decimal `json:"price"`
+
+	// Size of 0 means orders are no longer present at that price level, otherwise the returned size is the new total size on that price level.
+	Size decimal.Decimal `json:"size"`
+}
+
+func (p *Page) UnmarshalJSON(bytes []byte) error {
+	var j []decimal.Decimal
+	if err := json.Unmarshal(bytes, &j); err != nil {
+		return err
+	}
+	if len(j) != 2 {
+		return errUnexpectedLength(len(j), 2)
+	}
+
+	p.Price = j[0]
+	p.Size = j[1]
+
+	return nil
+}
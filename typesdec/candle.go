@@ -0,0 +1,43 @@
+package typesdec
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-json"
+	"github.com/shopspring/decimal"
+)
+
+var errUnexpectedLength = func(length int, expected int) error {
+	return fmt.Errorf("unexpected length: %d, expected: %d", length, expected)
+}
+
+type Candle struct {
+	// Timestamp in unix milliseconds.
+	Timestamp int64           `json:"timestamp"`
+	Open      decimal.Decimal `json:"open"`
+	High      decimal.Decimal `json:"high"`
+	Low       decimal.Decimal `json:"low"`
+	Close     decimal.Decimal `json:"close"`
+	Volume    decimal.Decimal `json:"volume"`
+}
+
+func (c *Candle) UnmarshalJSON(bytes []byte) error {
+	var j []json.RawMessage
+	if err := json.Unmarshal(bytes, &j); err != nil {
+		return err
+	}
+	if len(j) != 6 {
+		return errUnexpectedLength(len(j), 6)
+	}
+
+	if err := json.Unmarshal(j[0], &c.Timestamp); err != nil {
+		return err
+	}
+	for i, value := range []*decimal.Decimal{&c.Open, &c.High, &c.Low, &c.Close, &c.Volume} {
+		if err := json.Unmarshal(j[i+1], value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
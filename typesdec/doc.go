@@ -0,0 +1,6 @@
+// Package typesdec contains the types with prices, amounts and fees as decimal.Decimal instead of float64,
+// so no precision is lost which matters for accounting.
+//
+// The fields match the fields of the types package, see the types package for their documentation.
+// Use HttpClient.ToDecimalClient and HttpClientAuth.ToDecimalClient to make requests with these types.
+package typesdec
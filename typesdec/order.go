@@ -0,0 +1,115 @@
+package typesdec
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/shopspring/decimal"
+)
+
+type OrderNew struct {
+	Market                  string            `json:"market"`
+	ClientOrderId           string            `json:"clientOrderId,omitempty"`
+	OperatorId              int64             `json:"operatorId,omitempty"`
+	Side                    types.Side        `json:"side"`
+	OrderType               types.OrderType   `json:"orderType"`
+	Amount                  decimal.Decimal   `json:"amount"`
+	Price                   decimal.Decimal   `json:"price"`
+	AmountQuote             decimal.Decimal   `json:"amountQuote"`
+	TriggerAmount           decimal.Decimal   `json:"triggerAmount"`
+	TriggerType             string            `json:"triggerType,omitempty"`
+	TriggerReference        string            `json:"triggerReference,omitempty"`
+	TimeInForce             types.TimeInForce `json:"timeInForce,omitempty"`
+	SelfTradePrevention     string            `json:"selfTradePrevention,omitempty"`
+	PostOnly                bool              `json:"postOnly,omitempty"`
+	DisableMarketProtection bool              `json:"disableMarketProtection,omitempty"`
+	ResponseRequired        bool              `json:"responseRequired,omitempty"`
+}
+
+// MarshalJSON omits the zero decimals, like the omitempty float64 fields of types.OrderNew
+func (o OrderNew) MarshalJSON() ([]byte, error) {
+	type orderNew OrderNew
+	return marshalOmitZero(orderNew(o), map[string]decimal.Decimal{
+		"amount":        o.Amount,
+		"price":         o.Price,
+		"amountQuote":   o.AmountQuote,
+		"triggerAmount": o.TriggerAmount,
+	})
+}
+
+type OrderUpdate struct {
+	Market              string            `json:"market"`
+	OrderId             string            `json:"orderId,omitempty"`
+	ClientOrderId       string            `json:"clientOrderId,omitempty"`
+	OperatorId          int64             `json:"operatorId,omitempty"`
+	Amount              decimal.Decimal   `json:"amount"`
+	AmountQuote         decimal.Decimal   `json:"amountQuote"`
+	AmountRemaining     decimal.Decimal   `json:"amountRemaining"`
+	Price               decimal.Decimal   `json:"price"`
+	TriggerAmount       decimal.Decimal   `json:"triggerAmount"`
+	TimeInForce         types.TimeInForce `json:"timeInForce,omitempty"`
+	SelfTradePrevention string            `json:"selfTradePrevention,omitempty"`
+	PostOnly            bool              `json:"postOnly,omitempty"`
+	ResponseRequired    bool              `json:"responseRequired,omitempty"`
+}
+
+// MarshalJSON omits the zero decimals, like the omitempty float64 fields of types.OrderUpdate
+func (o OrderUpdate) MarshalJSON() ([]byte, error) {
+	type orderUpdate OrderUpdate
+	return marshalOmitZero(orderUpdate(o), map[string]decimal.Decimal{
+		"amount":          o.Amount,
+		"amountQuote":     o.AmountQuote,
+		"amountRemaining": o.AmountRemaining,
+		"price":           o.Price,
+		"triggerAmount":   o.TriggerAmount,
+	})
+}
+
+type Order struct {
+	OrderId             string            `json:"orderId"`
+	ClientOrderId       string            `json:"clientOrderId"`
+	OperatorId          int64             `json:"operatorId"`
+	Market              string            `json:"market"`
+	Created             int64             `json:"created"`
+	Updated             int64             `json:"updated"`
+	Status              types.OrderStatus `json:"status"`
+	Side                types.Side        `json:"side"`
+	OrderType           types.OrderType   `json:"orderType"`
+	Amount              decimal.Decimal   `json:"amount"`
+	AmountRemaining     decimal.Decimal   `json:"amountRemaining"`
+	Price               decimal.Decimal   `json:"price"`
+	OnHold              decimal.Decimal   `json:"onHold"`
+	OnHoldCurrency      string            `json:"onHoldCurrency"`
+	TriggerPrice        decimal.Decimal   `json:"triggerPrice"`
+	TriggerAmount       decimal.Decimal   `json:"triggerAmount"`
+	TriggerType         string            `json:"triggerType"`
+	TriggerReference    string            `json:"triggerReference"`
+	TimeInForce         types.TimeInForce `json:"timeInForce"`
+	PostOnly            bool              `json:"postOnly"`
+	SelfTradePrevention string            `json:"selfTradePrevention"`
+	Visible             bool              `json:"visible"`
+	Fills               []Fill            `json:"fills"`
+	FilledAmount        decimal.Decimal   `json:"filledAmount"`
+	FilledAmountQuote   decimal.Decimal   `json:"filledAmountQuote"`
+	FeeCurrency         string            `json:"feeCurrency"`
+	FeePaid             decimal.Decimal   `json:"feePaid"`
+}
+
+// marshalOmitZero marshals v and removes the decimals which are zero.
+func marshalOmitZero(v any, decimals map[string]decimal.Decimal) ([]byte, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var j map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &j); err != nil {
+		return nil, err
+	}
+	for key, value := range decimals {
+		if value.IsZero() {
+			delete(j, key)
+		}
+	}
+
+	return json.Marshal(j)
+}
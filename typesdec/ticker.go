@@ -0,0 +1,50 @@
+package typesdec
+
+import "github.com/shopspring/decimal"
+
+type TickerPrice struct {
+	// The market you requested the latest trade price for.
+	Market string `json:"market"`
+
+	// The latest trade price for 1 unit of base currency in the amount of quote currency for the specified market.
+	Price decimal.Decimal `json:"price"`
+}
+
+type TickerBook struct {
+	// The market you requested the current best orders for.
+	Market string `json:"market"`
+
+	// The highest buy order in quote currency for market currently available on Bitvavo.
+	Bid decimal.Decimal `json:"bid"`
+
+	// The size of the highest buy order in base currency.
+	BidSize decimal.Decimal `json:"bidSize"`
+
+	// The lowest sell order in quote currency for market currently available on Bitvavo.
+	Ask decimal.Decimal `json:"ask"`
+
+	// The size of the lowest sell order in base currency.
+	AskSize decimal.Decimal `json:"askSize"`
+}
+
+type Ticker24h struct {
+	// The market you requested the 24 hour ticker for.
+	Market string `json:"market"`
+
+	Open        decimal.Decimal `json:"open"`
+	High        decimal.Decimal `json:"high"`
+	Low         decimal.Decimal `json:"low"`
+	Last        decimal.Decimal `json:"last"`
+	Volume      decimal.Decimal `json:"volume"`
+	VolumeQuote decimal.Decimal `json:"volumeQuote"`
+	Bid         decimal.Decimal `json:"bid"`
+	BidSize     decimal.Decimal `json:"bidSize"`
+	Ask         decimal.Decimal `json:"ask"`
+	AskSize     decimal.Decimal `json:"askSize"`
+
+	// Timestamps in unix milliseconds.
+	Timestamp      int64 `json:"timestamp"`
+	StartTimestamp int64 `json:"startTimestamp"`
+	OpenTimestamp  int64 `json:"openTimestamp"`
+	CloseTimestamp int64 `json:"closeTimestamp"`
+}
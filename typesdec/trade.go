@@ -0,0 +1,77 @@
+package typesdec
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/shopspring/decimal"
+)
+
+type Trade struct {
+	// The trade ID of the returned trade (UUID).
+	Id string `json:"id"`
+
+	// The amount in base currency for which the trade has been made.
+	Amount decimal.Decimal `json:"amount"`
+
+	// The price in quote currency for which the trade has been made.
+	Price decimal.Decimal `json:"price"`
+
+	// The side for the taker.
+	// Enum: "buy" | "sell"
+	Side types.Side `json:"side"`
+
+	// Timestamp in unix milliseconds.
+	Timestamp int64 `json:"timestamp"`
+}
+
+type Fill struct {
+	// The id of the returned fill
+	FillId string `json:"fillId"`
+
+	// The id of the order on which has been filled
+	OrderId string `json:"orderId"`
+
+	// The current timestamp in milliseconds since 1 Jan 1970
+	Timestamp int64 `json:"timestamp"`
+
+	// The amount in base currency for which the trade has been made
+	Amount decimal.Decimal `json:"amount"`
+
+	// The side for the taker
+	// Enum: "buy" | "sell"
+	Side types.Side `json:"side"`
+
+	// The price in quote currency for which the trade has been made
+	Price decimal.Decimal `json:"price"`
+
+	// True for takers, false for makers
+	Taker bool `json:"taker"`
+
+	// The amount of fee that has been paid. Value is negative for rebates. Only available if settled is true
+	Fee decimal.Decimal `json:"fee"`
+
+	// Currency in which the fee has been paid. Only available if settled is true
+	FeeCurrency string `json:"feeCurrency"`
+
+	// True when the fee has been deducted and the bought/sold currency is available for further trading.
+	Settled bool `json:"settled"`
+}
+
+type TradeHistoric Fill
+
+func (t *TradeHistoric) UnmarshalJSON(bytes []byte) error {
+	var j struct {
+		Fill
+		Id string `json:"id"`
+	}
+	if err := json.Unmarshal(bytes, &j); err != nil {
+		return err
+	}
+
+	*t = TradeHistoric(j.Fill)
+	if t.FillId == "" {
+		t.FillId = j.Id
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+// Package webhook posts the order and fill events of the account stream to webhooks,
+// so external systems can react to them without holding a websocket connection.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// The time the payload was signed in milliseconds since 1 Jan 1970.
+	HeaderTimestamp = "X-Bitvavo-Webhook-Timestamp"
+
+	// The hex encoded HMAC-SHA256 of the timestamp, a dot and the payload with the secret as key.
+	HeaderSignature = "X-Bitvavo-Webhook-Signature"
+
+	defaultRetries = 3
+	defaultBackoff = time.Second
+)
+
+type EventType string
+
+const (
+	EventTypeOrder EventType = "order"
+	EventTypeFill  EventType = "fill"
+)
+
+// Event is the default payload which is posted to the webhook.
+type Event struct {
+	// The type of the event.
+	Type EventType `json:"type"`
+
+	// The market (e.g: ETH-EUR)
+	Market string `json:"market"`
+
+	// The order whose status changed, only set for EventTypeOrder.
+	Order *types.Order `json:"order,omitempty"`
+
+	// The fill, only set for EventTypeFill.
+	Fill *types.Fill `json:"fill,omitempty"`
+
+	// The time the event was received.
+	Time time.Time `json:"time"`
+}
+
+// Notifier posts events to a webhook. It's safe for concurrent use.
+type Notifier struct {
+	url        string
+	secret     string
+	header     http.Header
+	httpclient *http.Client
+	retries    int
+	backoff    time.Duration
+	payload    func(event Event) (any, bool)
+
+	mu       sync.Mutex
+	statuses map[string]types.OrderStatus
+}
+
+type Option func(*Notifier)
+
+// Sign every payload with secret (see: HeaderSignature), so the webhook can verify it's sent by you.
+// default: unsigned
+func WithSecret(secret string) Option {
+	return func(n *Notifier) {
+		n.secret = secret
+	}
+}
+
+// A header which is sent with every request (e.g: an api key of the webhook)
+// default: no extra headers
+func WithHeader(key string, value string) Option {
+	return func(n *Notifier) {
+		n.header.Add(key, value)
+	}
+}
+
+// The http.Client which sends the requests.
+// default: http.DefaultClient
+func WithHTTPClient(httpclient *http.Client) Option {
+	return func(n *Notifier) {
+		n.httpclient = httpclient
+	}
+}
+
+// The number of times a failed request is retried, the wait between retries starts at backoff and doubles every retry.
+// default: 3 retries, 1s backoff
+func WithRetry(retries int, backoff time.Duration) Option {
+	return func(n *Notifier) {
+		n.retries = retries
+		n.backoff = backoff
+	}
+}
+
+// Transform an event into the payload which is marshalled to JSON (e.g: the format of a chat service),
+// return false to skip the event.
+// default: the event itself
+func WithPayload(payload func(event Event) (any, bool)) Option {
+	return func(n *Notifier) {
+		n.payload = payload
+	}
+}
+
+// New creates a notifier which posts to url.
+func New(url string, options ...Option) *Notifier {
+	notifier := &Notifier{
+		url:        url,
+		header:     make(http.Header),
+		httpclient: http.DefaultClient,
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+		payload:    func(event Event) (any, bool) { return event, true },
+		statuses:   make(map[string]types.OrderStatus),
+	}
+	for _, opt := range options {
+		opt(notifier)
+	}
+	return notifier
+}
+
+// NotifyOrder posts the order of event whenever its status differs from the last status which has been posted.
+func (n *Notifier) NotifyOrder(ctx context.Context, event ws.OrderEvent) error {
+	order := event.Order
+
+	n.mu.Lock()
+	previous, found := n.statuses[order.OrderId]
+	changed := !found || previous != order.Status
+	if order.Status.IsFinal() {
+		delete(n.statuses, order.OrderId)
+	} else {
+		n.statuses[order.OrderId] = order.Status
+	}
+	n.mu.Unlock()
+
+	if !changed {
+		return nil
+	}
+	return n.post(ctx, Event{Type: EventTypeOrder, Market: event.Market, Order: &order, Time: time.Now()})
+}
+
+// NotifyFill posts the fill of event.
+func (n *Notifier) NotifyFill(ctx context.Context, event ws.FillEvent) error {
+	fill := event.Fill
+	return n.post(ctx, Event{Type: EventTypeFill, Market: event.Market, Fill: &fill, Time: time.Now()})
+}
+
+// Consume posts every event of the account stream until both channels are closed or ctx is done,
+// failures are logged so the stream keeps being consumed.
+func (n *Notifier) Consume(ctx context.Context, orderchn <-chan ws.OrderEvent, fillchn <-chan ws.FillEvent) {
+	for orderchn != nil || fillchn != nil {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-orderchn:
+			if !ok {
+				orderchn = nil
+				continue
+			}
+			if err := n.NotifyOrder(ctx, event); err != nil {
+				log.Err(err).Str("orderId", event.Order.OrderId).Msg("Failed to notify webhook of order")
+			}
+		case event, ok := <-fillchn:
+			if !ok {
+				fillchn = nil
+				continue
+			}
+			if err := n.NotifyFill(ctx, event); err != nil {
+				log.Err(err).Str("fillId", event.Fill.FillId).Msg("Failed to notify webhook of fill")
+			}
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, event Event) error {
+	payload, ok := n.payload(event)
+	if !ok {
+		return nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	backoff := n.backoff
+	for attempt := 0; ; attempt++ {
+		err = n.send(ctx, body)
+		if err == nil || attempt >= n.retries {
+			return err
+		}
+		log.Warn().Err(err).Int("attempt", attempt+1).Msg("Failed to notify webhook, retrying")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range n.header {
+		request.Header[key] = values
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if n.secret != "" {
+		timestamp := time.Now().UnixMilli()
+		request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+		request.Header.Set(HeaderSignature, Sign(n.secret, timestamp, body))
+	}
+
+	response, err := n.httpclient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		bytes, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("did not get OK response, code=%d, body=%s", response.StatusCode, string(bytes))
+	}
+	return nil
+}
+
+// Sign returns the signature of body which has been signed at timestamp (in milliseconds) with secret.
+func Sign(secret string, timestamp int64, body []byte) string {
+	hash := hmac.New(sha256.New, []byte(secret))
+	hash.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	hash.Write([]byte("."))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Verify returns true if signature is the signature of body which has been signed at timestamp with secret,
+// use it in the webhook to verify the headers of a request.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -1,11 +1,16 @@
 package ws
 
 import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 	"github.com/larscom/go-bitvavo/v2/crypto"
+	httpc "github.com/larscom/go-bitvavo/v2/http"
 	"github.com/larscom/go-bitvavo/v2/util"
 	"github.com/rs/zerolog/log"
 
@@ -22,6 +27,19 @@ type OrderEvent struct {
 
 	// The order itself.
 	Order types.Order `json:"order"`
+
+	// Whether the event has been emitted by the reconciliation after a reconnect instead of the websocket
+	// (see: WithAccountReconciliation)
+	Synthetic bool `json:"-"`
+}
+
+// String returns the event and order on a single line (e.g: order ETH-EUR buy limit 0.5@2000 status=new ...)
+func (o OrderEvent) String() string {
+	return fmt.Sprintf("%s %s", o.Event, o.Order)
+}
+
+func (o OrderEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(o.Order, o.Event, o.Market)
 }
 
 func (o *OrderEvent) UnmarshalJSON(bytes []byte) error {
@@ -29,18 +47,16 @@ func (o *OrderEvent) UnmarshalJSON(bytes []byte) error {
 		return err
 	}
 
-	var orderEvent map[string]any
-	if err := json.Unmarshal(bytes, &orderEvent); err != nil {
+	var event struct {
+		Event  string `json:"event"`
+		Market string `json:"market"`
+	}
+	if err := json.Unmarshal(bytes, &event); err != nil {
 		return err
 	}
 
-	var (
-		market = orderEvent["market"].(string)
-		event  = orderEvent["event"].(string)
-	)
-
-	o.Market = market
-	o.Event = event
+	o.Market = event.Market
+	o.Event = event.Event
 
 	return nil
 }
@@ -52,6 +68,18 @@ type FillEvent struct {
 	Market string `json:"market"`
 	// The fill itself
 	Fill types.Fill `json:"fill"`
+	// Whether the event has been emitted by the reconciliation after a reconnect instead of the websocket
+	// (see: WithAccountReconciliation)
+	Synthetic bool `json:"-"`
+}
+
+// String returns the market, event and fill on a single line (e.g: ETH-EUR fill buy 0.5@2000 fee=2.5 EUR ...)
+func (f FillEvent) String() string {
+	return fmt.Sprintf("%s %s %s", f.Market, f.Event, f.Fill)
+}
+
+func (f FillEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(f.Fill, f.Event, f.Market)
 }
 
 func (f *FillEvent) UnmarshalJSON(bytes []byte) error {
@@ -59,19 +87,16 @@ func (f *FillEvent) UnmarshalJSON(bytes []byte) error {
 		return err
 	}
 
-	var fillEvent map[string]any
-	if err := json.Unmarshal(bytes, &fillEvent); err != nil {
+	var event struct {
+		Event  string `json:"event"`
+		Market string `json:"market"`
+	}
+	if err := json.Unmarshal(bytes, &event); err != nil {
 		return err
-
 	}
 
-	var (
-		market = fillEvent["market"].(string)
-		event  = fillEvent["event"].(string)
-	)
-
-	f.Market = market
-	f.Event = event
+	f.Market = event.Market
+	f.Event = event.Event
 
 	return nil
 }
@@ -83,6 +108,13 @@ type AccountEventHandler interface {
 	// Default buffSize: 50
 	Subscribe(markets []string, buffSize ...uint64) (<-chan OrderEvent, <-chan FillEvent, error)
 
+	// Reader returns additional order and fill channels for markets which are already subscribed,
+	// so multiple consumers can read the same subscription without subscribing twice.
+	// The channels are closed whenever the markets are unsubscribed.
+	//
+	// Default buffSize: 50
+	Reader(markets []string, buffSize ...uint64) (<-chan OrderEvent, <-chan FillEvent, error)
+
 	// Unsubscribe from markets.
 	Unsubscribe(markets []string) error
 
@@ -94,11 +126,8 @@ type accountSubscription struct {
 	id     uuid.UUID
 	market string
 
-	orderinchn  chan<- OrderEvent
-	orderoutchn chan OrderEvent
-
-	fillinchn  chan<- FillEvent
-	filloutchn chan FillEvent
+	orders *subscription[OrderEvent]
+	fills  *subscription[FillEvent]
 }
 
 func newAccountSubscription(
@@ -110,32 +139,63 @@ func newAccountSubscription(
 	filloutchn chan FillEvent,
 ) *accountSubscription {
 	return &accountSubscription{
-		id:          id,
-		market:      market,
-		orderinchn:  orderinchn,
-		orderoutchn: orderoutchn,
-		fillinchn:   fillinchn,
-		filloutchn:  filloutchn,
+		id:     id,
+		market: market,
+		orders: newSubscription(id, market, orderinchn, orderoutchn),
+		fills:  newSubscription(id, market, fillinchn, filloutchn),
 	}
 }
 
+// ReauthEvent is emitted whenever the account handler tries to re-authenticate
+// after the session lost its authentication.
+type ReauthEvent struct {
+	// The attempt number, starting at 1.
+	Attempt uint64
+
+	// Whether the session is authenticated and the account channel is resubscribed.
+	Authenticated bool
+
+	// The reason why this attempt failed, nil if authenticated.
+	Err error
+}
+
 type accountEventHandler struct {
-	apiKey        string
-	apiSecret     string
-	authenticated bool
-	authchn       chan bool
-	writechn      chan<- WebSocketMessage
-	subs          *csmap.CsMap[string, *accountSubscription]
+	credentials      httpc.CredentialsProvider
+	authmu           sync.Mutex
+	authenticated    atomic.Bool
+	authenticating   atomic.Bool
+	reauthenticating atomic.Bool
+	authchn          chan bool
+	reauthchn        chan<- ReauthEvent
+	writechn         chan<- WebSocketMessage
+	subs             *csmap.CsMap[string, *accountSubscription]
+	resubscriber     *resubscriber
+	reconciler       *reconciler
+	reconciling      atomic.Bool
+	errchn           chan<- error
 }
 
-func newAccountEventHandler(apiKey string, apiSecret string, writechn chan<- WebSocketMessage) *accountEventHandler {
-	return &accountEventHandler{
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
-		writechn:  writechn,
-		authchn:   make(chan bool),
-		subs:      csmap.Create[string, *accountSubscription](),
+func newAccountEventHandler(
+	credentials httpc.CredentialsProvider,
+	writechn chan<- WebSocketMessage,
+	reauthchn chan<- ReauthEvent,
+	errchn chan<- error,
+	reconcileclient httpc.HttpClientAuth,
+) *accountEventHandler {
+	handler := &accountEventHandler{
+		credentials: credentials,
+		writechn:    writechn,
+		reauthchn:   reauthchn,
+		errchn:      errchn,
+		authchn:     make(chan bool),
+		subs:        csmap.Create[string, *accountSubscription](),
+	}
+	if reconcileclient != nil {
+		handler.reconciler = newReconciler(reconcileclient)
 	}
+	handler.resubscriber = newResubscriber(channelNameAccount, handler.subscribeWithAuth, handler.subs.Has, errchn)
+
+	return handler
 }
 
 func (a *accountEventHandler) Subscribe(markets []string, buffSize ...uint64) (<-chan OrderEvent, <-chan FillEvent, error) {
@@ -156,6 +216,8 @@ func (a *accountEventHandler) Subscribe(markets []string, buffSize ...uint64) (<
 		orderoutchn = make(chan OrderEvent, int(size)*len(markets))
 		filloutchn  = make(chan FillEvent, int(size)*len(markets))
 		id          = uuid.New()
+		orderwg     sync.WaitGroup
+		fillwg      sync.WaitGroup
 	)
 
 	for _, market := range markets {
@@ -164,14 +226,48 @@ func (a *accountEventHandler) Subscribe(markets []string, buffSize ...uint64) (<
 
 		a.subs.Store(market, newAccountSubscription(id, market, orderinchn, orderoutchn, fillinchn, filloutchn))
 
-		go relayMessages(orderinchn, orderoutchn)
-		go relayMessages(fillinchn, filloutchn)
+		orderwg.Add(1)
+		fillwg.Add(1)
+		go relayMessages(orderinchn, orderoutchn, &orderwg)
+		go relayMessages(fillinchn, filloutchn, &fillwg)
+	}
+	go closeWhenDone(&orderwg, orderoutchn)
+	go closeWhenDone(&fillwg, filloutchn)
+
+	if a.reconciler != nil {
+		a.reconciler.track(markets)
 	}
 
 	return orderoutchn, filloutchn, nil
 
 }
 
+func (a *accountEventHandler) Reader(markets []string, buffSize ...uint64) (<-chan OrderEvent, <-chan FillEvent, error) {
+	markets = getUniqueMarkets(markets)
+
+	if err := requireSubscription(a.subs, markets); err != nil {
+		return nil, nil, err
+	}
+
+	orderoutchn := addReaders(markets, func(market string, inchn chan<- OrderEvent) bool {
+		sub, found := a.subs.Load(market)
+		if found {
+			sub.orders.readers.add(inchn)
+		}
+		return found
+	}, buffSize...)
+
+	filloutchn := addReaders(markets, func(market string, inchn chan<- FillEvent) bool {
+		sub, found := a.subs.Load(market)
+		if found {
+			sub.fills.readers.add(inchn)
+		}
+		return found
+	}, buffSize...)
+
+	return orderoutchn, filloutchn, nil
+}
+
 func (a *accountEventHandler) Unsubscribe(markets []string) error {
 	markets = getUniqueMarkets(markets)
 
@@ -216,13 +312,20 @@ func (a *accountEventHandler) handleOrderMessage(bytes []byte) {
 	if err := json.Unmarshal(bytes, &orderEvent); err != nil {
 		log.Err(err).Str("message", string(bytes)).Msg("Couldn't unmarshal message into OrderEvent")
 	} else {
-		market := orderEvent.Market
-		sub, exist := a.subs.Load(market)
-		if exist {
-			sub.orderinchn <- *orderEvent
-		} else {
-			log.Debug().Str("market", market).Msg("There is no active subscription to handle this OrderEvent")
+		if a.reconciler != nil {
+			a.reconciler.order(orderEvent.Market, orderEvent.Order)
 		}
+		a.publishOrder(*orderEvent)
+	}
+}
+
+func (a *accountEventHandler) publishOrder(orderEvent OrderEvent) {
+	market := orderEvent.Market
+	sub, exist := a.subs.Load(market)
+	if exist {
+		sub.orders.publish(orderEvent)
+	} else {
+		log.Debug().Str("market", market).Msg("There is no active subscription to handle this OrderEvent")
 	}
 }
 
@@ -233,13 +336,20 @@ func (a *accountEventHandler) handleFillMessage(bytes []byte) {
 	if err := json.Unmarshal(bytes, &fillEvent); err != nil {
 		log.Err(err).Str("message", string(bytes)).Msg("Couldn't unmarshal message into FillEvent")
 	} else {
-		market := fillEvent.Market
-		sub, exist := a.subs.Load(market)
-		if exist {
-			sub.fillinchn <- *fillEvent
-		} else {
-			log.Debug().Str("market", market).Msg("There is no active subscription to handle this FillEvent")
+		if a.reconciler != nil {
+			a.reconciler.fill(fillEvent.Market, fillEvent.Fill)
 		}
+		a.publishFill(*fillEvent)
+	}
+}
+
+func (a *accountEventHandler) publishFill(fillEvent FillEvent) {
+	market := fillEvent.Market
+	sub, exist := a.subs.Load(market)
+	if exist {
+		sub.fills.publish(fillEvent)
+	} else {
+		log.Debug().Str("market", market).Msg("There is no active subscription to handle this FillEvent")
 	}
 }
 
@@ -266,12 +376,26 @@ func newWebSocketAuthMessage(apiKey string, apiSecret string) WebSocketMessage {
 }
 
 func (a *accountEventHandler) reconnect() {
-	a.authenticated = false
+	a.authenticated.Store(false)
+	a.reconciling.Store(a.reconciler != nil)
+	a.resubscriber.resubscribe(getSubscriptionKeys(a.subs))
+}
+
+func (a *accountEventHandler) handleSubscribed(subscriptions map[string][]string) {
+	markets, found := subscriptions[channelNameAccount.Value]
+	a.resubscriber.acknowledge(markets)
 
+	// events may have been missed until the account channel is resubscribed, so reconcile once it's acknowledged
+	if found && a.reconciling.CompareAndSwap(true, false) {
+		go a.reconciler.reconcile(a.publishOrder, a.publishFill, a.errchn)
+	}
+}
+
+func (a *accountEventHandler) subscribeWithAuth(markets []string) {
 	if err := a.runWithAuth(func() {
-		a.writechn <- newWebSocketMessage(actionSubscribe, channelNameAccount, getSubscriptionKeys(a.subs))
+		a.writechn <- newWebSocketMessage(actionSubscribe, channelNameAccount, markets)
 	}); err != nil {
-		log.Err(err).Msg("Failed to reconnect with the account handler")
+		log.Err(err).Msg("Failed to resubscribe with the account handler")
 	}
 }
 
@@ -280,16 +404,29 @@ func (a *accountEventHandler) reconnect() {
 // Authentication messages received from the websocket are handled by the handleAuthMessage func
 // that will eventually send an authentication message to the auth channel.
 func (a *accountEventHandler) runWithAuth(action func()) error {
-	if !a.authenticated {
-		a.writechn <- newWebSocketAuthMessage(a.apiKey, a.apiSecret)
+	a.authmu.Lock()
+	defer a.authmu.Unlock()
+
+	if !a.authenticated.Load() {
+		ctx, cancel := context.WithTimeout(context.Background(), authTimeout)
+		apiKey, apiSecret, err := a.credentials.Credentials(ctx)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to get credentials: %w", err)
+		}
+
+		a.authenticating.Store(true)
+		a.writechn <- newWebSocketAuthMessage(apiKey, apiSecret)
 		select {
-		case a.authenticated = <-a.authchn:
-		case <-time.After(10 * time.Second):
-			a.authenticated = false
+		case authenticated := <-a.authchn:
+			a.authenticated.Store(authenticated)
+		case <-time.After(authTimeout):
+			a.authenticated.Store(false)
 		}
+		a.authenticating.Store(false)
 	}
 
-	if a.authenticated {
+	if a.authenticated.Load() {
 		action()
 		return nil
 	}
@@ -297,34 +434,74 @@ func (a *accountEventHandler) runWithAuth(action func()) error {
 	return errAuthenticationFailed
 }
 
-func (a *accountEventHandler) deleteSubscriptions(
-	subs *csmap.CsMap[string, *accountSubscription],
-	markets []string,
-) error {
-	counts := make(map[uuid.UUID]int)
-	subs.Range(func(key string, value *accountSubscription) (stop bool) {
-		counts[value.id]++
-		return false
-	})
+// handleError handles errors received from the websocket.
+// Whenever the session lost its authentication, the account channel is re-authenticated and resubscribed.
+func (a *accountEventHandler) handleError(err *types.BitvavoErr) {
+	if !isAuthErr(err) {
+		return
+	}
 
-	idsWithKeys := make(map[uuid.UUID][]string)
-	for _, key := range markets {
-		if sub, found := subs.Load(key); found {
-			idsWithKeys[sub.id] = append(idsWithKeys[sub.id], key)
-			close(sub.orderinchn)
-			close(sub.fillinchn)
+	if a.authenticating.Load() {
+		select {
+		case a.authchn <- false:
+		default:
 		}
+		return
 	}
 
-	for id, keys := range idsWithKeys {
-		if counts[id] == len(keys) {
-			if item, found := subs.Load(keys[0]); found {
-				close(item.orderoutchn)
-				close(item.filloutchn)
+	a.authenticated.Store(false)
+	go a.reauthenticate()
+}
+
+// reauthenticate tries to authenticate and resubscribe the account channel with an exponential backoff.
+func (a *accountEventHandler) reauthenticate() {
+	if !a.reauthenticating.CompareAndSwap(false, true) {
+		return
+	}
+	defer a.reauthenticating.Store(false)
+
+	backoff := reauthMinBackoff
+	for attempt := uint64(1); attempt <= maxReauthAttempts; attempt++ {
+		markets := getSubscriptionKeys(a.subs)
+		if len(markets) == 0 {
+			log.Debug().Msg("No active account subscriptions, not re-authenticating...")
+			return
+		}
+
+		a.reconciling.Store(a.reconciler != nil)
+		err := a.runWithAuth(func() {
+			a.writechn <- newWebSocketMessage(actionSubscribe, channelNameAccount, markets)
+		})
+		if a.reauthchn != nil {
+			a.reauthchn <- ReauthEvent{
+				Attempt:       attempt,
+				Authenticated: err == nil,
+				Err:           err,
 			}
 		}
-		for _, key := range keys {
+		if err == nil {
+			log.Debug().Uint64("attempt", attempt).Msg("Re-authenticated the account handler")
+			return
+		}
+
+		log.Err(err).Uint64("attempt", attempt).Dur("backoff", backoff).Msg("Re-authentication failed, retrying")
+		time.Sleep(backoff)
+		backoff = min(backoff*2, reauthMaxBackoff)
+	}
+}
+
+func (a *accountEventHandler) deleteSubscriptions(
+	subs *csmap.CsMap[string, *accountSubscription],
+	markets []string,
+) error {
+	for _, key := range markets {
+		if sub, found := subs.Load(key); found {
 			subs.Delete(key)
+			if a.reconciler != nil {
+				a.reconciler.untrack([]string{key})
+			}
+			sub.orders.close()
+			sub.fills.close()
 		}
 	}
 
@@ -1,7 +1,15 @@
 package ws
 
 import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/orderbook"
 	"github.com/larscom/go-bitvavo/v2/types"
 	csmap "github.com/mhmtszr/concurrent-swiss-map"
 	"github.com/rs/zerolog/log"
@@ -10,6 +18,18 @@ import (
 	"github.com/larscom/go-bitvavo/v2/util"
 )
 
+const (
+	// The timeout of a snapshot request to sync a book.
+	bookSyncTimeout = 10 * time.Second
+
+	// The wait before a book is synced again after a failed sync, it doubles after every failed sync.
+	bookSyncMinBackoff = time.Second
+	bookSyncMaxBackoff = time.Minute
+
+	// The max number of updates of a market which are buffered until its book is synced, the oldest are dropped first.
+	bookSyncMaxPending = 1000
+)
+
 type BookEvent struct {
 	// Describes the returned event over the socket.
 	Event string `json:"event"`
@@ -21,6 +41,15 @@ type BookEvent struct {
 	Book types.Book `json:"book"`
 }
 
+// String returns the market, event and book on a single line (e.g: ETH-EUR book nonce=5 bids=1 asks=0 bid=0.5@2000)
+func (b BookEvent) String() string {
+	return fmt.Sprintf("%s %s %s", b.Market, b.Event, b.Book)
+}
+
+func (b BookEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(b.Book, b.Event, b.Market)
+}
+
 func (b *BookEvent) UnmarshalJSON(bytes []byte) error {
 	if err := b.Book.UnmarshalJSON(bytes); err != nil {
 		return err
@@ -42,15 +71,56 @@ func (b *BookEvent) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+type TopOfBookEvent struct {
+	// The market which was requested in the subscription.
+	Market string `json:"market"`
+
+	// The nonce of the book update which changed the top of the book.
+	Nonce int64 `json:"nonce"`
+
+	// The best (highest) bid, zero if there are no bids.
+	BestBid types.Page `json:"bestBid"`
+
+	// The best (lowest) ask, zero if there are no asks.
+	BestAsk types.Page `json:"bestAsk"`
+}
+
+// String returns the market, nonce and the best bid and ask on a single line (e.g: ETH-EUR top nonce=5 bid=0.5@2000 ask=1@2001)
+func (t TopOfBookEvent) String() string {
+	return fmt.Sprintf("%s top nonce=%d bid=%s ask=%s", t.Market, t.Nonce, t.BestBid, t.BestAsk)
+}
+
+type BookEventHandler interface {
+	EventHandler[BookEvent]
+
+	// SubscribeTop subscribes to markets and maintains the book of each market internally.
+	// It only emits an event whenever the best bid or best ask (price or size) changes.
+	//
+	// The book is synced with a snapshot from the HTTP client (see: WithHttpClient), every market is synced on its own
+	// and its updates are buffered until the snapshot has been applied.
+	//
+	// Default buffSize: 50
+	SubscribeTop(markets []string, buffSize ...uint64) (<-chan TopOfBookEvent, error)
+}
+
 type bookEventHandler struct {
-	writechn chan<- WebSocketMessage
-	subs     *csmap.CsMap[string, *subscription[BookEvent]]
+	writechn   chan<- WebSocketMessage
+	httpclient http.HttpClient
+	subs       *csmap.CsMap[string, *subscription[BookEvent]]
+
+	resubscriber *resubscriber
 }
 
-func newBookEventHandler(writechn chan<- WebSocketMessage) *bookEventHandler {
+func newBookEventHandler(writechn chan<- WebSocketMessage, httpclient http.HttpClient, errchn chan<- error) *bookEventHandler {
+	subs := csmap.Create[string, *subscription[BookEvent]]()
+
 	return &bookEventHandler{
-		writechn: writechn,
-		subs:     csmap.Create[string, *subscription[BookEvent]](),
+		writechn:   writechn,
+		httpclient: httpclient,
+		subs:       subs,
+		resubscriber: newResubscriber(channelNameBook, func(markets []string) {
+			writechn <- newWebSocketMessage(actionSubscribe, channelNameBook, markets)
+		}, subs.Has, errchn),
 	}
 }
 
@@ -65,19 +135,199 @@ func (b *bookEventHandler) Subscribe(markets []string, buffSize ...uint64) (<-ch
 		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
 		outchn = make(chan BookEvent, int(size)*len(markets))
 		id     = uuid.New()
+		wg     sync.WaitGroup
 	)
 
 	for _, market := range markets {
 		inchn := make(chan BookEvent, size)
 		b.subs.Store(market, newSubscription(id, market, inchn, outchn))
-		go relayMessages(inchn, outchn)
+		wg.Add(1)
+		go relayMessages(inchn, outchn, &wg)
 	}
+	go closeWhenDone(&wg, outchn)
 
 	b.writechn <- newWebSocketMessage(actionSubscribe, channelNameBook, markets)
 
 	return outchn, nil
 }
 
+func (b *bookEventHandler) SubscribeSeq(markets []string, buffSize ...uint64) (iter.Seq[BookEvent], error) {
+	outchn, err := b.Subscribe(markets, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSeq(outchn, func() error {
+		return unsubscribeActive(b.subs, getUniqueMarkets(markets), b.Unsubscribe)
+	}), nil
+}
+
+func (b *bookEventHandler) SubscribeGroups(groups []MarketGroup, buffSize ...uint64) (<-chan BookEvent, error) {
+	outchn, err := b.Subscribe(getGroupMarkets(groups), buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	unsubscribeOnDone(groups, func(markets []string) error {
+		return unsubscribeActive(b.subs, markets, b.Unsubscribe)
+	})
+
+	return outchn, nil
+}
+
+func (b *bookEventHandler) SubscribeTop(markets []string, buffSize ...uint64) (<-chan TopOfBookEvent, error) {
+	bookchn, err := b.Subscribe(markets, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
+		outchn = make(chan TopOfBookEvent, int(size)*len(markets))
+	)
+
+	go b.relayTopOfBook(bookchn, outchn)
+
+	return outchn, nil
+}
+
+// bookSync is the state of a book which is out of sync, the updates are buffered in pending until a snapshot has been applied.
+type bookSync struct {
+	syncing bool
+	pending []types.Book
+	retryAt time.Time
+	backoff time.Duration
+}
+
+// bookSnapshot is the result of a snapshot request of market.
+type bookSnapshot struct {
+	market   string
+	snapshot types.Book
+	err      error
+}
+
+func (b *bookEventHandler) relayTopOfBook(bookchn <-chan BookEvent, outchn chan<- TopOfBookEvent) {
+	defer close(outchn)
+
+	// cancels the pending snapshot requests once the subscription is closed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		books     = make(map[string]*orderbook.Book)
+		tops      = make(map[string]TopOfBookEvent)
+		syncs     = make(map[string]*bookSync)
+		snapshots = make(chan bookSnapshot)
+	)
+
+	// startSync requests a snapshot of market on its own goroutine, so the other markets aren't blocked meanwhile.
+	startSync := func(market string, state *bookSync) {
+		state.syncing = true
+		go func() {
+			snapshot, err := b.getSnapshot(ctx, market)
+			select {
+			case snapshots <- bookSnapshot{market: market, snapshot: snapshot, err: err}:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	// emit sends the top of the book of market whenever it changed.
+	emit := func(market string, book *orderbook.Book) {
+		bestBid, _ := book.BestBid()
+		bestAsk, _ := book.BestAsk()
+
+		top, found := tops[market]
+		if found && top.BestBid == bestBid && top.BestAsk == bestAsk {
+			return
+		}
+
+		top = TopOfBookEvent{
+			Market:  market,
+			Nonce:   book.Nonce(),
+			BestBid: bestBid,
+			BestAsk: bestAsk,
+		}
+		tops[market] = top
+		outchn <- top
+	}
+
+	for {
+		select {
+		case event, ok := <-bookchn:
+			if !ok {
+				return
+			}
+
+			book, found := books[event.Market]
+			if !found {
+				book = orderbook.New(event.Market)
+				books[event.Market] = book
+			}
+
+			if state, found := syncs[event.Market]; found {
+				state.pending = append(state.pending, event.Book)
+				if len(state.pending) > bookSyncMaxPending {
+					state.pending = state.pending[1:]
+				}
+				if !state.syncing && !time.Now().Before(state.retryAt) {
+					startSync(event.Market, state)
+				}
+				continue
+			}
+
+			if err := book.Update(event.Book); err != nil {
+				state := &bookSync{pending: []types.Book{event.Book}}
+				syncs[event.Market] = state
+				startSync(event.Market, state)
+				continue
+			}
+			emit(event.Market, book)
+
+		case result := <-snapshots:
+			var (
+				state = syncs[result.market]
+				book  = books[result.market]
+				err   = result.err
+			)
+			state.syncing = false
+
+			if err == nil {
+				book.Snapshot(result.snapshot)
+				for len(state.pending) > 0 && err == nil {
+					if err = book.Update(state.pending[0]); err == nil {
+						state.pending = state.pending[1:]
+					}
+				}
+			}
+
+			if err != nil {
+				state.backoff = min(max(state.backoff*2, bookSyncMinBackoff), bookSyncMaxBackoff)
+				state.retryAt = time.Now().Add(state.backoff)
+				log.Err(err).Str("market", result.market).Dur("backoff", state.backoff).Msg("Couldn't sync book, retrying after backoff")
+				continue
+			}
+
+			delete(syncs, result.market)
+			emit(result.market, book)
+		}
+	}
+}
+
+// getSnapshot returns a snapshot of the book of market, the request is bounded by bookSyncTimeout.
+func (b *bookEventHandler) getSnapshot(ctx context.Context, market string) (types.Book, error) {
+	log.Debug().Str("market", market).Msg("Syncing book with snapshot")
+
+	ctx, cancel := context.WithTimeout(ctx, bookSyncTimeout)
+	defer cancel()
+
+	return b.httpclient.GetOrderBookWithContext(ctx, market)
+}
+
+func (b *bookEventHandler) Reader(markets []string, buffSize ...uint64) (<-chan BookEvent, error) {
+	return newReader(b.subs, getUniqueMarkets(markets), buffSize...)
+}
+
 func (b *bookEventHandler) Unsubscribe(markets []string) error {
 	markets = getUniqueMarkets(markets)
 
@@ -112,7 +362,7 @@ func (b *bookEventHandler) handleMessage(e WsEvent, bytes []byte) {
 		market := bookEvent.Market
 		sub, exist := b.subs.Load(market)
 		if exist {
-			sub.inchn <- *bookEvent
+			sub.publish(*bookEvent)
 		} else {
 			log.Debug().Str("market", market).Msg("There is no active subscription to handle this BookEvent")
 		}
@@ -120,5 +370,9 @@ func (b *bookEventHandler) handleMessage(e WsEvent, bytes []byte) {
 }
 
 func (b *bookEventHandler) reconnect() {
-	b.writechn <- newWebSocketMessage(actionSubscribe, channelNameBook, getSubscriptionKeys(b.subs))
+	b.resubscriber.resubscribe(getSubscriptionKeys(b.subs))
+}
+
+func (b *bookEventHandler) handleSubscribed(subscriptions map[string][]string) {
+	b.resubscriber.acknowledge(subscriptions[channelNameBook.Value])
 }
@@ -2,7 +2,9 @@ package ws
 
 import (
 	"fmt"
+	"iter"
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/larscom/go-bitvavo/v2/types"
@@ -21,12 +23,31 @@ type CandlesEvent struct {
 	Market string `json:"market"`
 
 	// The interval which was requested in the subscription.
-	Interval string `json:"interval"`
+	Interval types.Interval `json:"interval"`
 
 	// The candle in the defined time period.
 	Candle types.Candle `json:"candle"`
 }
 
+// String returns the market, event, interval and candle on a single line (e.g: ETH-EUR candle 1h 2024-01-02T15:00:00Z open=1900 ...)
+func (c CandlesEvent) String() string {
+	return fmt.Sprintf("%s %s %s %s", c.Market, c.Event, c.Interval, c.Candle)
+}
+
+func (c CandlesEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Event    string         `json:"event"`
+		Market   string         `json:"market"`
+		Interval types.Interval `json:"interval"`
+		Candle   []types.Candle `json:"candle"`
+	}{
+		Event:    c.Event,
+		Market:   c.Market,
+		Interval: c.Interval,
+		Candle:   []types.Candle{c.Candle},
+	})
+}
+
 func (c *CandlesEvent) UnmarshalJSON(bytes []byte) error {
 	var candleEvent map[string]any
 	if err := json.Unmarshal(bytes, &candleEvent); err != nil {
@@ -55,7 +76,7 @@ func (c *CandlesEvent) UnmarshalJSON(bytes []byte) error {
 
 	c.Event = event
 	c.Market = market
-	c.Interval = interval
+	c.Interval = types.Interval(interval)
 
 	return nil
 }
@@ -67,10 +88,37 @@ type CandlesEventHandler interface {
 	// If you have many subscriptions at once you may need to increase the buffSize
 	//
 	// Default buffSize: 50
-	Subscribe(markets []string, interval string, buffSize ...uint64) (<-chan CandlesEvent, error)
+	Subscribe(markets []string, interval types.Interval, buffSize ...uint64) (<-chan CandlesEvent, error)
+
+	// SubscribeSeq subscribes to markets with interval and returns an iterator over the events.
+	// The markets are unsubscribed automatically whenever you stop the iteration.
+	//
+	// Default buffSize: 50
+	SubscribeSeq(markets []string, interval types.Interval, buffSize ...uint64) (iter.Seq[CandlesEvent], error)
+
+	// SubscribeGroups subscribes to the markets of every group with interval on a single channel.
+	// Whenever the context of a group is done, only the markets of that group are unsubscribed,
+	// the channel is closed after every group has been unsubscribed.
+	//
+	// Default buffSize: 50
+	SubscribeGroups(groups []MarketGroup, interval types.Interval, buffSize ...uint64) (<-chan CandlesEvent, error)
+
+	// SubscribeClosed subscribes to markets with interval and only emits finalized candles.
+	// Bitvavo sends multiple updates for the candle of the current period, a candle is finalized
+	// and emitted once the first update of the next period is received.
+	//
+	// Default buffSize: 50
+	SubscribeClosed(markets []string, interval types.Interval, buffSize ...uint64) (<-chan CandlesEvent, error)
+
+	// Reader returns an additional channel which receives the events of markets with interval which are already subscribed,
+	// so multiple consumers can read the same subscription without subscribing twice.
+	// The channel is closed whenever the markets are unsubscribed.
+	//
+	// Default buffSize: 50
+	Reader(markets []string, interval types.Interval, buffSize ...uint64) (<-chan CandlesEvent, error)
 
 	// Unsubscribe from markets with interval
-	Unsubscribe(markets []string, interval string) error
+	Unsubscribe(markets []string, interval types.Interval) error
 
 	// Unsubscribe from every market with interval
 	UnsubscribeAll() error
@@ -79,29 +127,42 @@ type CandlesEventHandler interface {
 type candlesEventHandler struct {
 	writechn chan<- WebSocketMessage
 	subs     *csmap.CsMap[string, *subscription[CandlesEvent]]
+
+	resubscriber *resubscriber
 }
 
-func newCandlesEventHandler(writechn chan<- WebSocketMessage) *candlesEventHandler {
+func newCandlesEventHandler(writechn chan<- WebSocketMessage, errchn chan<- error) *candlesEventHandler {
+	subs := csmap.Create[string, *subscription[CandlesEvent]]()
+
 	return &candlesEventHandler{
 		writechn: writechn,
-		subs:     csmap.Create[string, *subscription[CandlesEvent]](),
+		subs:     subs,
+		resubscriber: newResubscriber(channelNameCandles, func(keys []string) {
+			for interval, markets := range groupByInterval(keys) {
+				writechn <- newCandleWebSocketMessage(actionSubscribe, markets, interval)
+			}
+		}, subs.Has, errchn),
 	}
 }
 
-func newCandleWebSocketMessage(action Action, markets []string, interval string) WebSocketMessage {
+func newCandleWebSocketMessage(action Action, markets []string, interval types.Interval) WebSocketMessage {
 	return WebSocketMessage{
 		Action: action.Value,
 		Channels: []Channel{
 			{
 				Name:      channelNameCandles.Value,
 				Markets:   markets,
-				Intervals: []string{interval},
+				Intervals: []string{interval.String()},
 			},
 		},
 	}
 }
 
-func (c *candlesEventHandler) Subscribe(markets []string, interval string, buffSize ...uint64) (<-chan CandlesEvent, error) {
+func (c *candlesEventHandler) Subscribe(markets []string, interval types.Interval, buffSize ...uint64) (<-chan CandlesEvent, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+
 	markets = getUniqueMarkets(markets)
 	keys := c.createKeys(markets, interval)
 
@@ -115,20 +176,75 @@ func (c *candlesEventHandler) Subscribe(markets []string, interval string, buffS
 		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
 		outchn = make(chan CandlesEvent, int(size)*len(keys))
 		id     = uuid.New()
+		wg     sync.WaitGroup
 	)
 
 	for i, key := range keys {
 		inchn := make(chan CandlesEvent, size)
 		c.subs.Store(key, newSubscription(id, markets[i], inchn, outchn))
-		go relayMessages(inchn, outchn)
+		wg.Add(1)
+		go relayMessages(inchn, outchn, &wg)
 	}
+	go closeWhenDone(&wg, outchn)
 
 	c.writechn <- newCandleWebSocketMessage(actionSubscribe, markets, interval)
 
 	return outchn, nil
 }
 
-func (c *candlesEventHandler) Unsubscribe(markets []string, interval string) error {
+func (c *candlesEventHandler) SubscribeSeq(markets []string, interval types.Interval, buffSize ...uint64) (iter.Seq[CandlesEvent], error) {
+	outchn, err := c.Subscribe(markets, interval, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSeq(outchn, func() error {
+		return c.unsubscribeActive(getUniqueMarkets(markets), interval)
+	}), nil
+}
+
+func (c *candlesEventHandler) SubscribeGroups(groups []MarketGroup, interval types.Interval, buffSize ...uint64) (<-chan CandlesEvent, error) {
+	outchn, err := c.Subscribe(getGroupMarkets(groups), interval, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	unsubscribeOnDone(groups, func(markets []string) error {
+		return c.unsubscribeActive(markets, interval)
+	})
+
+	return outchn, nil
+}
+
+func (c *candlesEventHandler) SubscribeClosed(markets []string, interval types.Interval, buffSize ...uint64) (<-chan CandlesEvent, error) {
+	inchn, err := c.Subscribe(markets, interval, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	size := util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
+	outchn := make(chan CandlesEvent, int(size)*len(getUniqueMarkets(markets)))
+
+	go relayClosedCandles(inchn, outchn)
+
+	return outchn, nil
+}
+
+func (c *candlesEventHandler) Reader(markets []string, interval types.Interval, buffSize ...uint64) (<-chan CandlesEvent, error) {
+	markets = getUniqueMarkets(markets)
+
+	keys := c.createKeys(markets, interval)
+
+	for i, key := range keys {
+		if !c.subs.Has(key) {
+			return nil, errNoSubscriptionActive(markets[i])
+		}
+	}
+
+	return newReader(c.subs, keys, buffSize...)
+}
+
+func (c *candlesEventHandler) Unsubscribe(markets []string, interval types.Interval) error {
 	markets = getUniqueMarkets(markets)
 
 	keys := c.createKeys(markets, interval)
@@ -144,6 +260,20 @@ func (c *candlesEventHandler) Unsubscribe(markets []string, interval string) err
 	return deleteSubscriptions(c.subs, keys)
 }
 
+// unsubscribeActive unsubscribes the markets with interval which still have an active subscription.
+func (c *candlesEventHandler) unsubscribeActive(markets []string, interval types.Interval) error {
+	active := make([]string, 0, len(markets))
+	for _, market := range markets {
+		if c.subs.Has(c.createKey(market, interval)) {
+			active = append(active, market)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+	return c.Unsubscribe(active, interval)
+}
+
 func (c *candlesEventHandler) UnsubscribeAll() error {
 	for interval, markets := range c.getIntervalMarkets() {
 		if err := c.Unsubscribe(markets, interval); err != nil {
@@ -173,7 +303,7 @@ func (c *candlesEventHandler) handleMessage(e WsEvent, bytes []byte) {
 
 		sub, exist := c.subs.Load(key)
 		if exist {
-			sub.inchn <- *candleEvent
+			sub.publish(*candleEvent)
 		} else {
 			log.Debug().Str("market", market).Msg("There is no active subscription to handle this CandlesEvent")
 		}
@@ -181,38 +311,63 @@ func (c *candlesEventHandler) handleMessage(e WsEvent, bytes []byte) {
 }
 
 func (c *candlesEventHandler) reconnect() {
-	for interval, markets := range c.getIntervalMarkets() {
-		c.writechn <- newCandleWebSocketMessage(actionSubscribe, markets, interval)
-	}
+	c.resubscriber.resubscribe(getSubscriptionKeys(c.subs))
 }
 
-func (c *candlesEventHandler) getIntervalMarkets() map[string][]string {
-	m := make(map[string][]string)
+func (c *candlesEventHandler) handleSubscribed(subscriptions map[string][]string) {
+	c.resubscriber.acknowledge(subscriptions[channelNameCandles.Value])
+}
 
-	c.subs.Range(func(key string, _ *subscription[CandlesEvent]) (stop bool) {
-		market, interval := c.parseKey(key)
-		m[interval] = append(m[interval], market)
-		return false
-	})
+func (c *candlesEventHandler) getIntervalMarkets() map[types.Interval][]string {
+	return groupByInterval(getSubscriptionKeys(c.subs))
+}
 
-	return m
+func (c *candlesEventHandler) createKey(market string, interval types.Interval) string {
+	return newCandlesKey(market, interval)
 }
 
-func (c *candlesEventHandler) parseKey(key string) (string, string) {
+func (c *candlesEventHandler) createKeys(markets []string, interval types.Interval) []string {
+	keys := make([]string, len(markets))
+	for i := 0; i < len(keys); i++ {
+		keys[i] = c.createKey(markets[i], interval)
+	}
+	return keys
+}
+
+func newCandlesKey(market string, interval types.Interval) string {
+	return fmt.Sprintf("%s_%s", market, interval)
+}
+
+func parseCandlesKey(key string) (string, types.Interval) {
 	parts := strings.Split(key, "_")
 	market := parts[0]
-	interval := parts[1]
+	interval := types.Interval(parts[1])
 	return market, interval
 }
 
-func (c *candlesEventHandler) createKey(market string, interval string) string {
-	return fmt.Sprintf("%s_%s", market, interval)
+// groupByInterval groups candle keys (market_interval) by interval.
+func groupByInterval(keys []string) map[types.Interval][]string {
+	m := make(map[types.Interval][]string)
+	for _, key := range keys {
+		market, interval := parseCandlesKey(key)
+		m[interval] = append(m[interval], market)
+	}
+	return m
 }
 
-func (c *candlesEventHandler) createKeys(markets []string, interval string) []string {
-	keys := make([]string, len(markets))
-	for i := 0; i < len(keys); i++ {
-		keys[i] = c.createKey(markets[i], interval)
+// relayClosedCandles only relays a candle of a market once a candle of a newer period is received,
+// the candles of the current period are dropped whenever the in channel closes.
+func relayClosedCandles(in <-chan CandlesEvent, out chan<- CandlesEvent) {
+	defer close(out)
+
+	current := make(map[string]CandlesEvent)
+	for event := range in {
+		last, found := current[event.Market]
+		if found && event.Candle.Timestamp > last.Candle.Timestamp {
+			out <- last
+		}
+		if !found || event.Candle.Timestamp >= last.Candle.Timestamp {
+			current[event.Market] = event
+		}
 	}
-	return keys
 }
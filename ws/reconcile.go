@@ -0,0 +1,272 @@
+package ws
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	httpc "github.com/larscom/go-bitvavo/v2/http"
+	"github.com/larscom/go-bitvavo/v2/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// The margin which is subtracted from the time of the last event when fetching what has been missed,
+	// to cover the clock difference between the exchange and the client.
+	reconcileMargin  = time.Minute
+	reconcileTimeout = time.Minute
+	reconcileLimit   = 1000
+)
+
+var errReconcileFailed = func(market string, err error) error {
+	return fmt.Errorf("could not reconcile the account for market: %s: %w", market, err)
+}
+
+// reconciler keeps the last known state of the account per market and fetches what has been missed
+// after a reconnect or re-authentication, so catch-up events can be emitted for every missed transition.
+type reconciler struct {
+	client  httpc.HttpClientAuth
+	running atomic.Bool
+
+	mu      sync.Mutex
+	markets map[string]*marketState
+}
+
+// marketState is the last known state of the account for a single market.
+type marketState struct {
+	// The timestamp (unix milliseconds) of the last event, events before it can't have been missed.
+	since int64
+
+	// Every open order and the final orders since since, by orderId.
+	orders map[string]types.Order
+
+	// The timestamp of every fill since since, by fillId.
+	fills map[string]int64
+}
+
+func newReconciler(client httpc.HttpClientAuth) *reconciler {
+	return &reconciler{
+		client:  client,
+		markets: make(map[string]*marketState),
+	}
+}
+
+// track starts keeping the state of markets, events before now can't have been missed.
+func (r *reconciler) track(markets []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UnixMilli()
+	for _, market := range markets {
+		if _, found := r.markets[market]; !found {
+			r.markets[market] = &marketState{
+				since:  now,
+				orders: make(map[string]types.Order),
+				fills:  make(map[string]int64),
+			}
+		}
+	}
+}
+
+// untrack stops keeping the state of markets.
+func (r *reconciler) untrack(markets []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, market := range markets {
+		delete(r.markets, market)
+	}
+}
+
+// order applies order and returns true if it's newer than the last known state of the order.
+func (r *reconciler) order(market string, order types.Order) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, found := r.markets[market]
+	if !found {
+		return true
+	}
+
+	if known, found := state.orders[order.OrderId]; found && known.Updated >= order.Updated && known.Status == order.Status {
+		return false
+	}
+	state.orders[order.OrderId] = order
+	state.advance(order.Updated)
+	return true
+}
+
+// fill applies fill and returns true if it hasn't been seen before.
+func (r *reconciler) fill(market string, fill types.Fill) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, found := r.markets[market]
+	if !found {
+		return true
+	}
+
+	if _, found := state.fills[fill.FillId]; found {
+		return false
+	}
+	state.fills[fill.FillId] = fill.Timestamp
+	state.advance(fill.Timestamp)
+	return true
+}
+
+// reconcile fetches the orders and fills of every tracked market and passes the missed ones to publishOrder and publishFill,
+// the fills of a market are published before its orders. It's skipped whenever a reconcile is already running.
+func (r *reconciler) reconcile(publishOrder func(OrderEvent), publishFill func(FillEvent), errchn chan<- error) {
+	if !r.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer r.running.Store(false)
+
+	for _, market := range r.tracked() {
+		ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+		orders, fills, err := r.fetch(ctx, market)
+		cancel()
+
+		if err != nil {
+			err = errReconcileFailed(market, err)
+			log.Err(err).Msg("Failed to reconcile the account after a reconnect")
+			if errchn != nil {
+				errchn <- err
+			}
+			continue
+		}
+
+		for _, fill := range fills {
+			if r.fill(market, fill) {
+				publishFill(FillEvent{Event: wsEventFill.Value, Market: market, Fill: fill, Synthetic: true})
+			}
+		}
+		for _, order := range orders {
+			if r.order(market, order) {
+				publishOrder(OrderEvent{Event: wsEventOrder.Value, Market: market, Order: order, Synthetic: true})
+			}
+		}
+	}
+}
+
+// fetch returns the orders and fills of market which may have been missed, sorted by time.
+func (r *reconciler) fetch(ctx context.Context, market string) ([]types.Order, []types.Fill, error) {
+	start, known := r.snapshot(market)
+
+	open, err := r.client.GetOrdersOpenWithContext(ctx, market)
+	if err != nil {
+		return nil, nil, err
+	}
+	recent, err := r.client.GetOrdersWithContext(ctx, market, &types.OrderParams{Start: start, Limit: reconcileLimit})
+	if err != nil {
+		return nil, nil, err
+	}
+	trades, err := r.client.GetTradesWithContext(ctx, market, &types.TradeParams{Start: start, Limit: reconcileLimit})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orders := make(map[string]types.Order, len(open)+len(recent))
+	for _, order := range append(open, recent...) {
+		orders[order.OrderId] = order
+	}
+
+	// orders which were open, but are neither open nor recent anymore have been closed in the meantime
+	for _, orderId := range known {
+		if _, found := orders[orderId]; found {
+			continue
+		}
+		order, err := r.client.GetOrderWithContext(ctx, market, orderId)
+		if types.IsOrderNotFound(err) {
+			log.Debug().Str("market", market).Str("orderId", orderId).Msg("Order not found while reconciling, forgetting it")
+			r.forget(market, orderId)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		orders[order.OrderId] = order
+	}
+
+	sorted := make([]types.Order, 0, len(orders))
+	for _, order := range orders {
+		sorted = append(sorted, order)
+	}
+	slices.SortFunc(sorted, func(a, b types.Order) int {
+		return cmp.Compare(a.Updated, b.Updated)
+	})
+
+	fills := make([]types.Fill, 0, len(trades))
+	for _, trade := range trades {
+		fills = append(fills, types.Fill(trade))
+	}
+	slices.SortFunc(fills, func(a, b types.Fill) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+
+	return sorted, fills, nil
+}
+
+// snapshot returns the time from which events of market may have been missed and the ids of its open orders.
+func (r *reconciler) snapshot(market string) (time.Time, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, found := r.markets[market]
+	if !found {
+		return time.Now().Add(-reconcileMargin), nil
+	}
+
+	open := make([]string, 0, len(state.orders))
+	for orderId, order := range state.orders {
+		if !order.Status.IsFinal() {
+			open = append(open, orderId)
+		}
+	}
+	return time.UnixMilli(state.since).Add(-reconcileMargin), open
+}
+
+func (r *reconciler) forget(market string, orderId string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if state, found := r.markets[market]; found {
+		delete(state.orders, orderId)
+	}
+}
+
+func (r *reconciler) tracked() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	markets := make([]string, 0, len(r.markets))
+	for market := range r.markets {
+		markets = append(markets, market)
+	}
+	slices.Sort(markets)
+	return markets
+}
+
+// advance moves since to timestamp and forgets the final orders and fills which can't be fetched anymore.
+func (s *marketState) advance(timestamp int64) {
+	if timestamp <= s.since {
+		return
+	}
+	s.since = timestamp
+
+	cutoff := s.since - 2*reconcileMargin.Milliseconds()
+	for orderId, order := range s.orders {
+		if order.Status.IsFinal() && order.Updated < cutoff {
+			delete(s.orders, orderId)
+		}
+	}
+	for fillId, timestamp := range s.fills {
+		if timestamp < cutoff {
+			delete(s.fills, fillId)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package ws
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	resubscribeTimeout     = 5 * time.Second
+	maxResubscribeAttempts = 3
+)
+
+var errResubscribeFailed = func(channel ChannelName, keys []string) error {
+	return fmt.Errorf("could not resubscribe to channel: %s for: %s", channel.Value, strings.Join(keys, ","))
+}
+
+// resubscriber resubscribes after a reconnect and tracks the subscribe acknowledgements per key (market),
+// only the keys which are not acknowledged in time are retried.
+// Keys which could not be resubscribed after maxResubscribeAttempts are reported on the error channel.
+type resubscriber struct {
+	channel   ChannelName
+	subscribe func(keys []string)
+	isActive  func(key string) bool
+	errchn    chan<- error
+
+	mu         sync.Mutex
+	generation uint64
+	pending    map[string]struct{}
+	done       chan struct{}
+}
+
+func newResubscriber(
+	channel ChannelName,
+	subscribe func(keys []string),
+	isActive func(key string) bool,
+	errchn chan<- error,
+) *resubscriber {
+	return &resubscriber{
+		channel:   channel,
+		subscribe: subscribe,
+		isActive:  isActive,
+		errchn:    errchn,
+		pending:   make(map[string]struct{}),
+	}
+}
+
+// resubscribe subscribes to keys and retries the keys which are not acknowledged.
+func (r *resubscriber) resubscribe(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	r.generation++
+	r.pending = make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		r.pending[key] = struct{}{}
+	}
+	r.done = make(chan struct{})
+	generation, done := r.generation, r.done
+	r.mu.Unlock()
+
+	r.subscribe(keys)
+
+	go r.watch(generation, done)
+}
+
+// acknowledge marks keys as subscribed, keys contains every active subscription of the channel.
+func (r *resubscriber) acknowledge(keys []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.pending) == 0 {
+		return
+	}
+
+	for _, key := range keys {
+		delete(r.pending, key)
+	}
+	if len(r.pending) == 0 {
+		close(r.done)
+	}
+}
+
+func (r *resubscriber) watch(generation uint64, done <-chan struct{}) {
+	for attempt := 1; attempt <= maxResubscribeAttempts; attempt++ {
+		select {
+		case <-done:
+			return
+		case <-time.After(resubscribeTimeout):
+		}
+
+		missing, current := r.missing(generation)
+		if !current || len(missing) == 0 {
+			return
+		}
+
+		if attempt == maxResubscribeAttempts {
+			r.fail(generation, missing)
+			return
+		}
+
+		log.Debug().Str("channel", r.channel.Value).Strs("keys", missing).Int("attempt", attempt).Msg("Retrying subscriptions which are not acknowledged")
+		r.subscribe(missing)
+	}
+}
+
+// missing returns the pending keys which still have an active subscription,
+// current is false whenever a newer resubscribe has been started.
+func (r *resubscriber) missing(generation uint64) ([]string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if generation != r.generation {
+		return nil, false
+	}
+
+	missing := make([]string, 0, len(r.pending))
+	for key := range r.pending {
+		if r.isActive(key) {
+			missing = append(missing, key)
+		}
+	}
+	return missing, true
+}
+
+func (r *resubscriber) fail(generation uint64, missing []string) {
+	r.mu.Lock()
+	if generation == r.generation {
+		r.pending = make(map[string]struct{})
+	}
+	r.mu.Unlock()
+
+	err := errResubscribeFailed(r.channel, missing)
+	log.Err(err).Msg("Giving up on resubscribing")
+	if r.errchn != nil {
+		r.errchn <- err
+	}
+}
@@ -0,0 +1,29 @@
+package ws
+
+import (
+	"iter"
+
+	"github.com/rs/zerolog/log"
+)
+
+// newSeq returns an iterator over the events received on outchn.
+// Whenever the consumer stops the iteration, unsubscribe is called and the
+// remaining events are drained so the relays can shut down.
+func newSeq[T any](outchn <-chan T, unsubscribe func() error) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for event := range outchn {
+			if !yield(event) {
+				if err := unsubscribe(); err != nil {
+					log.Err(err).Msg("Failed to unsubscribe after the iteration stopped")
+				}
+				go drain(outchn)
+				return
+			}
+		}
+	}
+}
+
+func drain[T any](chn <-chan T) {
+	for range chn {
+	}
+}
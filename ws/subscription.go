@@ -1,13 +1,22 @@
 package ws
 
 import (
+	"context"
+	"sync"
+
 	"github.com/google/uuid"
+	"github.com/larscom/go-bitvavo/v2/util"
 	csmap "github.com/mhmtszr/concurrent-swiss-map"
 	"github.com/orsinium-labs/enum"
+	"github.com/rs/zerolog/log"
 )
 
 type WsEvent enum.Member[string]
 
+func (e WsEvent) String() string {
+	return e.Value
+}
+
 var (
 	wsEventSubscribed   = WsEvent{"subscribed"}
 	wsEventUnsubscribed = WsEvent{"unsubscribed"}
@@ -52,12 +61,54 @@ var (
 	channelNameAccount   = ChannelName{"account"}
 )
 
+// MarketGroup is a group of markets which share the same context.
+type MarketGroup struct {
+	// Cancelling this context unsubscribes the markets of this group only.
+	Context context.Context
+
+	// The markets in this group (e.g: ETH-EUR)
+	Markets []string
+}
+
+func getGroupMarkets(groups []MarketGroup) []string {
+	markets := make([]string, 0)
+	for _, group := range groups {
+		markets = append(markets, group.Markets...)
+	}
+	return markets
+}
+
+// unsubscribeOnDone calls unsubscribe for the markets of each group once the context of that group is done.
+// It runs on the goroutine of context.AfterFunc, concurrently with the handler publishing events,
+// which is safe since the subscription serializes publish with close.
+func unsubscribeOnDone(groups []MarketGroup, unsubscribe func(markets []string) error) {
+	for _, group := range groups {
+		if group.Context == nil {
+			continue
+		}
+		markets := getUniqueMarkets(group.Markets)
+		context.AfterFunc(group.Context, func() {
+			if err := unsubscribe(markets); err != nil {
+				log.Err(err).Strs("markets", markets).Msg("Failed to unsubscribe after the context was done")
+			}
+		})
+	}
+}
+
 type subscription[T any] struct {
 	id     uuid.UUID
 	market string
 
 	outchn chan T
 	inchn  chan<- T
+
+	readers readers[T]
+
+	// serializes publish with close, so an event is never sent on the closed inchn
+	mu       sync.RWMutex
+	closed   bool
+	done     chan struct{}
+	doneOnce sync.Once
 }
 
 func newSubscription[T any](id uuid.UUID, market string, inchn chan<- T, outchn chan T) *subscription[T] {
@@ -66,7 +117,123 @@ func newSubscription[T any](id uuid.UUID, market string, inchn chan<- T, outchn
 		market: market,
 		inchn:  inchn,
 		outchn: outchn,
+		done:   make(chan struct{}),
+	}
+}
+
+// publish sends the event to the subscriber and every additional reader, the event is dropped once the subscription is closed.
+func (s *subscription[T]) publish(event T) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.inchn <- event:
+	case <-s.done:
+		return
+	}
+	s.readers.publish(event, s.done)
+}
+
+// close closes the channels of the subscription, it's safe to call concurrently with publish and more than once.
+func (s *subscription[T]) close() {
+	// unblocks a publish which is waiting for the subscriber, so the lock can be acquired
+	s.doneOnce.Do(func() { close(s.done) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+
+	close(s.inchn)
+	s.readers.close()
+}
+
+// readers are the additional consumers of a single subscription.
+type readers[T any] struct {
+	mu     sync.RWMutex
+	closed bool
+	chns   []chan<- T
+}
+
+func (r *readers[T]) add(chn chan<- T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		close(chn)
+		return
+	}
+	r.chns = append(r.chns, chn)
+}
+
+// publish sends the event to every reader, it stops once done is closed so a stalled reader can't block closing the subscription.
+func (r *readers[T]) publish(event T, done <-chan struct{}) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, chn := range r.chns {
+		select {
+		case chn <- event:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (r *readers[T]) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, chn := range r.chns {
+		close(chn)
+	}
+	r.chns = nil
+	r.closed = true
+}
+
+// newReader adds a reader to the subscription of every key and returns a single channel which receives their events,
+// the channel is closed after every key has been unsubscribed.
+func newReader[T any](subs *csmap.CsMap[string, *subscription[T]], keys []string, buffSize ...uint64) (<-chan T, error) {
+	if err := requireSubscription(subs, keys); err != nil {
+		return nil, err
+	}
+
+	return addReaders(keys, func(key string, inchn chan<- T) bool {
+		sub, found := subs.Load(key)
+		if found {
+			sub.readers.add(inchn)
+		}
+		return found
+	}, buffSize...), nil
+}
+
+// addReaders creates an in channel for every key which is relayed to the returned channel,
+// add must register the in channel and returns false if the key is no longer subscribed.
+func addReaders[T any](keys []string, add func(key string, inchn chan<- T) bool, buffSize ...uint64) <-chan T {
+	var (
+		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
+		outchn = make(chan T, int(size)*len(keys))
+		wg     sync.WaitGroup
+	)
+
+	for _, key := range keys {
+		inchn := make(chan T, size)
+		if !add(key, inchn) {
+			close(inchn)
+		}
+		wg.Add(1)
+		go relayMessages(inchn, outchn, &wg)
 	}
+	go closeWhenDone(&wg, outchn)
+
+	return outchn
 }
 
 func getSubscriptionKeys[K comparable, V any](data *csmap.CsMap[K, V]) []K {
@@ -78,12 +245,20 @@ func getSubscriptionKeys[K comparable, V any](data *csmap.CsMap[K, V]) []K {
 	return keys
 }
 
-func relayMessages[T any](in <-chan T, out chan<- T) {
+func relayMessages[T any](in <-chan T, out chan<- T, wg *sync.WaitGroup) {
+	defer wg.Done()
 	for msg := range in {
 		out <- msg
 	}
 }
 
+// closeWhenDone closes the out channel once every relay feeding it has stopped,
+// so the out channel is never closed while a relay is still sending to it.
+func closeWhenDone[T any](wg *sync.WaitGroup, out chan T) {
+	wg.Wait()
+	close(out)
+}
+
 func requireSubscription[T any](subs *csmap.CsMap[string, T], markets []string) error {
 	for _, market := range markets {
 		if !subs.Has(market) {
@@ -106,30 +281,26 @@ func deleteSubscriptions[T any](
 	subs *csmap.CsMap[string, *subscription[T]],
 	keys []string,
 ) error {
-	counts := make(map[uuid.UUID]int)
-	subs.Range(func(key string, value *subscription[T]) (stop bool) {
-		counts[value.id]++
-		return false
-	})
-
-	idsWithKeys := make(map[uuid.UUID][]string)
 	for _, key := range keys {
 		if sub, found := subs.Load(key); found {
-			idsWithKeys[sub.id] = append(idsWithKeys[sub.id], key)
-			close(sub.inchn)
-		}
-	}
-
-	for id, keys := range idsWithKeys {
-		if counts[id] == len(keys) {
-			if item, found := subs.Load(keys[0]); found {
-				close(item.outchn)
-			}
-		}
-		for _, key := range keys {
 			subs.Delete(key)
+			sub.close()
 		}
 	}
 
 	return nil
 }
+
+// unsubscribeActive calls unsubscribe with the keys which still have an active subscription.
+func unsubscribeActive[T any](subs *csmap.CsMap[string, T], keys []string, unsubscribe func(keys []string) error) error {
+	active := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if subs.Has(key) {
+			active = append(active, key)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+	return unsubscribe(active)
+}
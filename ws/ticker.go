@@ -1,6 +1,10 @@
 package ws
 
 import (
+	"fmt"
+	"iter"
+	"sync"
+
 	"github.com/google/uuid"
 	"github.com/larscom/go-bitvavo/v2/types"
 	csmap "github.com/mhmtszr/concurrent-swiss-map"
@@ -21,6 +25,15 @@ type TickerEvent struct {
 	Ticker types.Ticker `json:"ticker"`
 }
 
+// String returns the market, event and ticker on a single line (e.g: ETH-EUR ticker bid=0.5@2000 ask=1@2001 last=2000.5)
+func (t TickerEvent) String() string {
+	return fmt.Sprintf("%s %s %s", t.Market, t.Event, t.Ticker)
+}
+
+func (t TickerEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(t.Ticker, t.Event, t.Market)
+}
+
 func (t *TickerEvent) UnmarshalJSON(bytes []byte) error {
 	if err := t.Ticker.UnmarshalJSON(bytes); err != nil {
 		return err
@@ -45,12 +58,19 @@ func (t *TickerEvent) UnmarshalJSON(bytes []byte) error {
 type tickerEventHandler struct {
 	writechn chan<- WebSocketMessage
 	subs     *csmap.CsMap[string, *subscription[TickerEvent]]
+
+	resubscriber *resubscriber
 }
 
-func newTickerEventHandler(writechn chan<- WebSocketMessage) *tickerEventHandler {
+func newTickerEventHandler(writechn chan<- WebSocketMessage, errchn chan<- error) *tickerEventHandler {
+	subs := csmap.Create[string, *subscription[TickerEvent]]()
+
 	return &tickerEventHandler{
 		writechn: writechn,
-		subs:     csmap.Create[string, *subscription[TickerEvent]](),
+		subs:     subs,
+		resubscriber: newResubscriber(channelNameTicker, func(markets []string) {
+			writechn <- newWebSocketMessage(actionSubscribe, channelNameTicker, markets)
+		}, subs.Has, errchn),
 	}
 }
 
@@ -65,19 +85,50 @@ func (t *tickerEventHandler) Subscribe(markets []string, buffSize ...uint64) (<-
 		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
 		outchn = make(chan TickerEvent, int(size)*len(markets))
 		id     = uuid.New()
+		wg     sync.WaitGroup
 	)
 
 	for _, market := range markets {
 		inchn := make(chan TickerEvent, size)
 		t.subs.Store(market, newSubscription(id, market, inchn, outchn))
-		go relayMessages(inchn, outchn)
+		wg.Add(1)
+		go relayMessages(inchn, outchn, &wg)
 	}
+	go closeWhenDone(&wg, outchn)
 
 	t.writechn <- newWebSocketMessage(actionSubscribe, channelNameTicker, markets)
 
 	return outchn, nil
 }
 
+func (t *tickerEventHandler) SubscribeSeq(markets []string, buffSize ...uint64) (iter.Seq[TickerEvent], error) {
+	outchn, err := t.Subscribe(markets, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSeq(outchn, func() error {
+		return unsubscribeActive(t.subs, getUniqueMarkets(markets), t.Unsubscribe)
+	}), nil
+}
+
+func (t *tickerEventHandler) SubscribeGroups(groups []MarketGroup, buffSize ...uint64) (<-chan TickerEvent, error) {
+	outchn, err := t.Subscribe(getGroupMarkets(groups), buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	unsubscribeOnDone(groups, func(markets []string) error {
+		return unsubscribeActive(t.subs, markets, t.Unsubscribe)
+	})
+
+	return outchn, nil
+}
+
+func (t *tickerEventHandler) Reader(markets []string, buffSize ...uint64) (<-chan TickerEvent, error) {
+	return newReader(t.subs, getUniqueMarkets(markets), buffSize...)
+}
+
 func (t *tickerEventHandler) Unsubscribe(markets []string) error {
 	markets = getUniqueMarkets(markets)
 
@@ -112,7 +163,7 @@ func (t *tickerEventHandler) handleMessage(e WsEvent, bytes []byte) {
 		market := tickerEvent.Market
 		sub, exist := t.subs.Load(market)
 		if exist {
-			sub.inchn <- *tickerEvent
+			sub.publish(*tickerEvent)
 		} else {
 			log.Debug().Str("market", market).Msg("There is no active subscription to handle this TickerEvent")
 		}
@@ -120,5 +171,9 @@ func (t *tickerEventHandler) handleMessage(e WsEvent, bytes []byte) {
 }
 
 func (t *tickerEventHandler) reconnect() {
-	t.writechn <- newWebSocketMessage(actionSubscribe, channelNameTicker, getSubscriptionKeys(t.subs))
+	t.resubscriber.resubscribe(getSubscriptionKeys(t.subs))
+}
+
+func (t *tickerEventHandler) handleSubscribed(subscriptions map[string][]string) {
+	t.resubscriber.acknowledge(subscriptions[channelNameTicker.Value])
 }
@@ -2,6 +2,8 @@ package ws
 
 import (
 	"fmt"
+	"iter"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/larscom/go-bitvavo/v2/types"
@@ -23,6 +25,26 @@ type Ticker24hEvent struct {
 	Ticker24h types.Ticker24h `json:"ticker24h"`
 }
 
+// String returns the market, event and ticker24h on a single line (e.g: ETH-EUR ticker24h open=1900 high=2050 ...)
+func (t Ticker24hEvent) String() string {
+	return fmt.Sprintf("%s %s %s", t.Market, t.Event, t.Ticker24h)
+}
+
+func (t Ticker24hEvent) MarshalJSON() ([]byte, error) {
+	ticker24h, err := marshalEvent(t.Ticker24h, "", t.Market)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Event string            `json:"event"`
+		Data  []json.RawMessage `json:"data"`
+	}{
+		Event: t.Event,
+		Data:  []json.RawMessage{ticker24h},
+	})
+}
+
 func (t *Ticker24hEvent) UnmarshalJSON(bytes []byte) error {
 	var ticker24hEvent map[string]any
 
@@ -59,12 +81,19 @@ func (t *Ticker24hEvent) UnmarshalJSON(bytes []byte) error {
 type ticker24hEventHandler struct {
 	writechn chan<- WebSocketMessage
 	subs     *csmap.CsMap[string, *subscription[Ticker24hEvent]]
+
+	resubscriber *resubscriber
 }
 
-func newTicker24hEventHandler(writechn chan<- WebSocketMessage) *ticker24hEventHandler {
+func newTicker24hEventHandler(writechn chan<- WebSocketMessage, errchn chan<- error) *ticker24hEventHandler {
+	subs := csmap.Create[string, *subscription[Ticker24hEvent]]()
+
 	return &ticker24hEventHandler{
 		writechn: writechn,
-		subs:     csmap.Create[string, *subscription[Ticker24hEvent]](),
+		subs:     subs,
+		resubscriber: newResubscriber(channelNameTicker24h, func(markets []string) {
+			writechn <- newWebSocketMessage(actionSubscribe, channelNameTicker24h, markets)
+		}, subs.Has, errchn),
 	}
 }
 
@@ -78,19 +107,50 @@ func (t *ticker24hEventHandler) Subscribe(markets []string, buffSize ...uint64)
 		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
 		outchn = make(chan Ticker24hEvent, int(size)*len(markets))
 		id     = uuid.New()
+		wg     sync.WaitGroup
 	)
 
 	for _, market := range markets {
 		inchn := make(chan Ticker24hEvent, size)
 		t.subs.Store(market, newSubscription(id, market, inchn, outchn))
-		go relayMessages(inchn, outchn)
+		wg.Add(1)
+		go relayMessages(inchn, outchn, &wg)
 	}
+	go closeWhenDone(&wg, outchn)
 
 	t.writechn <- newWebSocketMessage(actionSubscribe, channelNameTicker24h, markets)
 
 	return outchn, nil
 }
 
+func (t *ticker24hEventHandler) SubscribeSeq(markets []string, buffSize ...uint64) (iter.Seq[Ticker24hEvent], error) {
+	outchn, err := t.Subscribe(markets, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSeq(outchn, func() error {
+		return unsubscribeActive(t.subs, getUniqueMarkets(markets), t.Unsubscribe)
+	}), nil
+}
+
+func (t *ticker24hEventHandler) SubscribeGroups(groups []MarketGroup, buffSize ...uint64) (<-chan Ticker24hEvent, error) {
+	outchn, err := t.Subscribe(getGroupMarkets(groups), buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	unsubscribeOnDone(groups, func(markets []string) error {
+		return unsubscribeActive(t.subs, markets, t.Unsubscribe)
+	})
+
+	return outchn, nil
+}
+
+func (t *ticker24hEventHandler) Reader(markets []string, buffSize ...uint64) (<-chan Ticker24hEvent, error) {
+	return newReader(t.subs, getUniqueMarkets(markets), buffSize...)
+}
+
 func (t *ticker24hEventHandler) Unsubscribe(markets []string) error {
 	markets = getUniqueMarkets(markets)
 
@@ -125,7 +185,7 @@ func (t *ticker24hEventHandler) handleMessage(e WsEvent, bytes []byte) {
 		market := ticker24hEvent.Market
 		sub, exist := t.subs.Load(market)
 		if exist {
-			sub.inchn <- *ticker24hEvent
+			sub.publish(*ticker24hEvent)
 		} else {
 			log.Debug().Str("market", market).Msg("There is no active subscription to handle this Ticker24hEvent")
 		}
@@ -133,5 +193,9 @@ func (t *ticker24hEventHandler) handleMessage(e WsEvent, bytes []byte) {
 }
 
 func (t *ticker24hEventHandler) reconnect() {
-	t.writechn <- newWebSocketMessage(actionSubscribe, channelNameTicker24h, getSubscriptionKeys(t.subs))
+	t.resubscriber.resubscribe(getSubscriptionKeys(t.subs))
+}
+
+func (t *ticker24hEventHandler) handleSubscribed(subscriptions map[string][]string) {
+	t.resubscriber.acknowledge(subscriptions[channelNameTicker24h.Value])
 }
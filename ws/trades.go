@@ -1,6 +1,10 @@
 package ws
 
 import (
+	"fmt"
+	"iter"
+	"sync"
+
 	"github.com/google/uuid"
 	"github.com/larscom/go-bitvavo/v2/types"
 	csmap "github.com/mhmtszr/concurrent-swiss-map"
@@ -21,6 +25,15 @@ type TradesEvent struct {
 	Trade types.Trade `json:"trade"`
 }
 
+// String returns the market, event and trade on a single line (e.g: ETH-EUR trade buy 0.5@2000 at ...)
+func (t TradesEvent) String() string {
+	return fmt.Sprintf("%s %s %s", t.Market, t.Event, t.Trade)
+}
+
+func (t TradesEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(t.Trade, t.Event, t.Market)
+}
+
 func (t *TradesEvent) UnmarshalJSON(bytes []byte) error {
 	if err := t.Trade.UnmarshalJSON(bytes); err != nil {
 		return err
@@ -45,12 +58,19 @@ func (t *TradesEvent) UnmarshalJSON(bytes []byte) error {
 type tradesEventHandler struct {
 	writechn chan<- WebSocketMessage
 	subs     *csmap.CsMap[string, *subscription[TradesEvent]]
+
+	resubscriber *resubscriber
 }
 
-func newTradesEventHandler(writechn chan<- WebSocketMessage) *tradesEventHandler {
+func newTradesEventHandler(writechn chan<- WebSocketMessage, errchn chan<- error) *tradesEventHandler {
+	subs := csmap.Create[string, *subscription[TradesEvent]]()
+
 	return &tradesEventHandler{
 		writechn: writechn,
-		subs:     csmap.Create[string, *subscription[TradesEvent]](),
+		subs:     subs,
+		resubscriber: newResubscriber(channelNameTrades, func(markets []string) {
+			writechn <- newWebSocketMessage(actionSubscribe, channelNameTrades, markets)
+		}, subs.Has, errchn),
 	}
 }
 
@@ -65,19 +85,50 @@ func (t *tradesEventHandler) Subscribe(markets []string, buffSize ...uint64) (<-
 		size   = util.IfOrElse(len(buffSize) > 0, func() uint64 { return buffSize[0] }, defaultBuffSize)
 		outchn = make(chan TradesEvent, int(size)*len(markets))
 		id     = uuid.New()
+		wg     sync.WaitGroup
 	)
 
 	for _, market := range markets {
 		inchn := make(chan TradesEvent, size)
 		t.subs.Store(market, newSubscription(id, market, inchn, outchn))
-		go relayMessages(inchn, outchn)
+		wg.Add(1)
+		go relayMessages(inchn, outchn, &wg)
 	}
+	go closeWhenDone(&wg, outchn)
 
 	t.writechn <- newWebSocketMessage(actionSubscribe, channelNameTrades, markets)
 
 	return outchn, nil
 }
 
+func (t *tradesEventHandler) SubscribeSeq(markets []string, buffSize ...uint64) (iter.Seq[TradesEvent], error) {
+	outchn, err := t.Subscribe(markets, buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSeq(outchn, func() error {
+		return unsubscribeActive(t.subs, getUniqueMarkets(markets), t.Unsubscribe)
+	}), nil
+}
+
+func (t *tradesEventHandler) SubscribeGroups(groups []MarketGroup, buffSize ...uint64) (<-chan TradesEvent, error) {
+	outchn, err := t.Subscribe(getGroupMarkets(groups), buffSize...)
+	if err != nil {
+		return nil, err
+	}
+
+	unsubscribeOnDone(groups, func(markets []string) error {
+		return unsubscribeActive(t.subs, markets, t.Unsubscribe)
+	})
+
+	return outchn, nil
+}
+
+func (t *tradesEventHandler) Reader(markets []string, buffSize ...uint64) (<-chan TradesEvent, error) {
+	return newReader(t.subs, getUniqueMarkets(markets), buffSize...)
+}
+
 func (t *tradesEventHandler) Unsubscribe(markets []string) error {
 	markets = getUniqueMarkets(markets)
 
@@ -112,7 +163,7 @@ func (t *tradesEventHandler) handleMessage(e WsEvent, bytes []byte) {
 		market := tradeEvent.Market
 		sub, exist := t.subs.Load(market)
 		if exist {
-			sub.inchn <- *tradeEvent
+			sub.publish(*tradeEvent)
 		} else {
 			log.Debug().Str("market", market).Msg("There is no active subscription to handle this TradesEvent")
 		}
@@ -120,5 +171,9 @@ func (t *tradesEventHandler) handleMessage(e WsEvent, bytes []byte) {
 }
 
 func (t *tradesEventHandler) reconnect() {
-	t.writechn <- newWebSocketMessage(actionSubscribe, channelNameTrades, getSubscriptionKeys(t.subs))
+	t.resubscriber.resubscribe(getSubscriptionKeys(t.subs))
+}
+
+func (t *tradesEventHandler) handleSubscribed(subscriptions map[string][]string) {
+	t.resubscriber.acknowledge(subscriptions[channelNameTrades.Value])
 }
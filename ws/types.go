@@ -1,9 +1,11 @@
 package ws
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/goccy/go-json"
+	"github.com/larscom/go-bitvavo/v2/types"
 )
 
 type AuthEvent struct {
@@ -25,7 +27,10 @@ func (b *BaseEvent) UnmarshalJSON(bytes []byte) error {
 		return err
 	}
 
-	e := j["event"].(string)
+	e, ok := j["event"].(string)
+	if !ok {
+		return errors.New("message does not contain an event")
+	}
 
 	event := wsEvents.Parse(e)
 	if event == nil {
@@ -54,3 +59,75 @@ type Channel struct {
 	Intervals []string `json:"interval,omitempty"`
 	Markets   []string `json:"markets,omitempty"`
 }
+
+// SubscribedEvent contains every active subscription of the connection per channel name,
+// candle subscriptions are flattened to market_interval (e.g: ETH-EUR_1h)
+type SubscribedEvent struct {
+	// Describes the returned event over the socket.
+	Event string `json:"event"`
+
+	// The active subscriptions per channel name.
+	Subscriptions map[string][]string `json:"subscriptions"`
+}
+
+func (s *SubscribedEvent) UnmarshalJSON(bytes []byte) error {
+	var j struct {
+		Event         string                     `json:"event"`
+		Subscriptions map[string]json.RawMessage `json:"subscriptions"`
+	}
+	if err := json.Unmarshal(bytes, &j); err != nil {
+		return err
+	}
+
+	subscriptions := make(map[string][]string, len(j.Subscriptions))
+	for channel, raw := range j.Subscriptions {
+		if channel == channelNameCandles.Value {
+			var intervals map[string][]string
+			if err := json.Unmarshal(raw, &intervals); err != nil {
+				return err
+			}
+			for interval, markets := range intervals {
+				for _, market := range markets {
+					subscriptions[channel] = append(subscriptions[channel], newCandlesKey(market, types.Interval(interval)))
+				}
+			}
+			continue
+		}
+
+		var markets []string
+		if err := json.Unmarshal(raw, &markets); err != nil {
+			return err
+		}
+		subscriptions[channel] = markets
+	}
+
+	s.Event = j.Event
+	s.Subscriptions = subscriptions
+
+	return nil
+}
+
+// marshalEvent marshals value as an object and adds the event and market to it,
+// which is the format in which the events are received (empty fields are omitted)
+func marshalEvent(value any, event string, market string) ([]byte, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &fields); err != nil {
+		return nil, err
+	}
+
+	for key, value := range map[string]string{"event": event, "market": market} {
+		if value == "" {
+			continue
+		}
+		if fields[key], err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(fields)
+}
@@ -3,10 +3,12 @@ package ws
 import (
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"sync"
 	"time"
 
+	httpc "github.com/larscom/go-bitvavo/v2/http"
 	"github.com/larscom/go-bitvavo/v2/types"
 	"github.com/rs/zerolog/log"
 
@@ -15,12 +17,19 @@ import (
 )
 
 const (
-	wsUrl            = "wss://ws.bitvavo.com/v2"
-	readLimit        = 655350
-	handshakeTimeout = 45 * time.Second
-	defaultBuffSize  = 50
+	wsUrl             = "wss://ws.bitvavo.com/v2"
+	readLimit         = 655350
+	handshakeTimeout  = 45 * time.Second
+	authTimeout       = 10 * time.Second
+	reauthMinBackoff  = time.Second
+	reauthMaxBackoff  = time.Minute
+	maxReauthAttempts = 10
+	defaultBuffSize   = 50
 )
 
+// Bitvavo error code for messages which require an authenticated session.
+const errCodeAuthenticationRequired = 300
+
 var (
 	errNoSubscriptionActive      = func(market string) error { return fmt.Errorf("no active subscription for market: %s", market) }
 	errSubscriptionAlreadyActive = func(market string) error { return fmt.Errorf("subscription already active for market: %s", market) }
@@ -36,6 +45,27 @@ type EventHandler[T any] interface {
 	// Default buffSize: 50
 	Subscribe(markets []string, buffSize ...uint64) (<-chan T, error)
 
+	// SubscribeSeq subscribes to markets and returns an iterator over the events,
+	// so you can range over them with a for loop.
+	// The markets are unsubscribed automatically whenever you stop the iteration.
+	//
+	// Default buffSize: 50
+	SubscribeSeq(markets []string, buffSize ...uint64) (iter.Seq[T], error)
+
+	// SubscribeGroups subscribes to the markets of every group on a single channel.
+	// Whenever the context of a group is done, only the markets of that group are unsubscribed,
+	// the channel is closed after every group has been unsubscribed.
+	//
+	// Default buffSize: 50
+	SubscribeGroups(groups []MarketGroup, buffSize ...uint64) (<-chan T, error)
+
+	// Reader returns an additional channel which receives the events of markets which are already subscribed,
+	// so multiple consumers can read the same subscription without subscribing twice.
+	// The channel is closed whenever the markets are unsubscribed.
+	//
+	// Default buffSize: 50
+	Reader(markets []string, buffSize ...uint64) (<-chan T, error)
+
 	// Unsubscribe from markets.
 	Unsubscribe(markets []string) error
 
@@ -60,10 +90,14 @@ type WsClient interface {
 	Trades() EventHandler[TradesEvent]
 
 	// Book event handler to handle book events and subscriptions.
-	Book() EventHandler[BookEvent]
+	Book() BookEventHandler
 
 	// Account event handler to handle order/fill events, requires authentication.
 	Account(apiKey string, apiSecret string) AccountEventHandler
+
+	// AccountWithCredentials is the same as Account, but the apiKey and apiSecret are provided by credentials
+	// whenever the websocket (re)authenticates (e.g: to rotate keys)
+	AccountWithCredentials(credentials httpc.CredentialsProvider) AccountEventHandler
 }
 
 type handler interface {
@@ -72,28 +106,36 @@ type handler interface {
 	reconnect()
 
 	handleMessage(e WsEvent, bytes []byte)
+
+	// handleSubscribed receives every active subscription per channel name after each subscribe.
+	handleSubscribed(subscriptions map[string][]string)
+}
+
+// errorHandler is implemented by handlers which need to act on errors received from the websocket.
+type errorHandler interface {
+	handleError(err *types.BitvavoErr)
 }
 
 type wsClient struct {
-	reconnectCount uint64
-	autoReconnect  bool
-	conn           *websocket.Conn
-	writechn       chan WebSocketMessage
-	errchn         chan<- error
+	url             string
+	reconnectCount  uint64
+	autoReconnect   bool
+	conn            *websocket.Conn
+	writechn        chan WebSocketMessage
+	errchn          chan<- error
+	reauthchn       chan<- ReauthEvent
+	httpclient      httpc.HttpClient
+	reconcileclient httpc.HttpClientAuth
 
 	mu       sync.RWMutex
 	handlers []handler
 }
 
 func NewWsClient(options ...Option) (WsClient, error) {
-	conn, err := newConn()
-	if err != nil {
-		return nil, err
-	}
-
 	ws := &wsClient{
-		conn:          conn,
+		url:           wsUrl,
 		autoReconnect: true,
+		httpclient:    httpc.NewHttpClient(),
 		writechn:      make(chan WebSocketMessage),
 		handlers:      make([]handler, 0),
 	}
@@ -101,6 +143,12 @@ func NewWsClient(options ...Option) (WsClient, error) {
 		opt(ws)
 	}
 
+	conn, err := newConn(ws.url)
+	if err != nil {
+		return nil, err
+	}
+	ws.conn = conn
+
 	go ws.writeLoop()
 	go ws.readLoop()
 
@@ -116,6 +164,14 @@ func WithErrorChannel(errchn chan<- error) Option {
 	}
 }
 
+// Receive re-authentication events of the account handler, which are emitted whenever
+// the session lost its authentication and the account handler tries to re-authenticate.
+func WithReauthChannel(reauthchn chan<- ReauthEvent) Option {
+	return func(ws *wsClient) {
+		ws.reauthchn = reauthchn
+	}
+}
+
 // Auto reconnect if websocket disconnects.
 // default: true
 func WithAutoReconnect(autoReconnect bool) Option {
@@ -124,6 +180,32 @@ func WithAutoReconnect(autoReconnect bool) Option {
 	}
 }
 
+// The websocket url to connect to.
+// default: wss://ws.bitvavo.com/v2
+func WithURL(url string) Option {
+	return func(ws *wsClient) {
+		ws.url = url
+	}
+}
+
+// The HTTP client which is used to fetch book snapshots for the top of book subscriptions.
+// default: a new unauthenticated HTTP client
+func WithHttpClient(httpclient httpc.HttpClient) Option {
+	return func(ws *wsClient) {
+		ws.httpclient = httpclient
+	}
+}
+
+// Reconcile the account handler after a reconnect or re-authentication, the open orders and recent fills of every
+// subscribed market are fetched with client and compared with the last known state. Every missed order or fill is emitted
+// as a synthetic event (see: OrderEvent.Synthetic), failures are sent to the error channel.
+// default: disabled
+func WithAccountReconciliation(client httpc.HttpClientAuth) Option {
+	return func(ws *wsClient) {
+		ws.reconcileclient = client
+	}
+}
+
 // The buff size for the write channel, by default the write channel is unbuffered.
 // The write channel writes messages to the websocket.
 func WithWriteBuffSize(buffSize uint64) Option {
@@ -142,7 +224,7 @@ func (ws *wsClient) Candles() CandlesEventHandler {
 		}
 	}
 
-	handler := newCandlesEventHandler(ws.writechn)
+	handler := newCandlesEventHandler(ws.writechn, ws.errchn)
 	ws.handlers = append(ws.handlers, handler)
 
 	return handler
@@ -158,7 +240,7 @@ func (ws *wsClient) Ticker() EventHandler[TickerEvent] {
 		}
 	}
 
-	handler := newTickerEventHandler(ws.writechn)
+	handler := newTickerEventHandler(ws.writechn, ws.errchn)
 	ws.handlers = append(ws.handlers, handler)
 
 	return handler
@@ -174,7 +256,7 @@ func (ws *wsClient) Ticker24h() EventHandler[Ticker24hEvent] {
 		}
 	}
 
-	handler := newTicker24hEventHandler(ws.writechn)
+	handler := newTicker24hEventHandler(ws.writechn, ws.errchn)
 	ws.handlers = append(ws.handlers, handler)
 
 	return handler
@@ -190,13 +272,13 @@ func (ws *wsClient) Trades() EventHandler[TradesEvent] {
 		}
 	}
 
-	handler := newTradesEventHandler(ws.writechn)
+	handler := newTradesEventHandler(ws.writechn, ws.errchn)
 	ws.handlers = append(ws.handlers, handler)
 
 	return handler
 }
 
-func (ws *wsClient) Book() EventHandler[BookEvent] {
+func (ws *wsClient) Book() BookEventHandler {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
@@ -206,13 +288,17 @@ func (ws *wsClient) Book() EventHandler[BookEvent] {
 		}
 	}
 
-	handler := newBookEventHandler(ws.writechn)
+	handler := newBookEventHandler(ws.writechn, ws.httpclient, ws.errchn)
 	ws.handlers = append(ws.handlers, handler)
 
 	return handler
 }
 
 func (ws *wsClient) Account(apiKey string, apiSecret string) AccountEventHandler {
+	return ws.AccountWithCredentials(httpc.StaticCredentials(apiKey, apiSecret))
+}
+
+func (ws *wsClient) AccountWithCredentials(credentials httpc.CredentialsProvider) AccountEventHandler {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
@@ -222,7 +308,7 @@ func (ws *wsClient) Account(apiKey string, apiSecret string) AccountEventHandler
 		}
 	}
 
-	handler := newAccountEventHandler(apiKey, apiSecret, ws.writechn)
+	handler := newAccountEventHandler(credentials, ws.writechn, ws.reauthchn, ws.errchn, ws.reconcileclient)
 	ws.handlers = append(ws.handlers, handler)
 
 	return handler
@@ -244,14 +330,14 @@ func (ws *wsClient) Close() error {
 	return ws.conn.Close()
 }
 
-func newConn() (*websocket.Conn, error) {
+func newConn(url string) (*websocket.Conn, error) {
 	dialer := websocket.Dialer{
 		Proxy:             http.ProxyFromEnvironment,
 		HandshakeTimeout:  handshakeTimeout,
 		EnableCompression: false,
 	}
 
-	conn, _, err := dialer.Dial(wsUrl, nil)
+	conn, _, err := dialer.Dial(url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -298,7 +384,7 @@ func (ws *wsClient) reconnect() {
 
 	log.Debug().Msg("Reconnecting...")
 
-	conn, err := newConn()
+	conn, err := newConn(ws.url)
 	if err != nil {
 		defer ws.reconnect()
 
@@ -361,17 +447,37 @@ func (ws *wsClient) handlError(err *types.BitvavoErr) {
 		log.Err(err).Msg("Could not handle error")
 	}
 
+	for _, h := range ws.handlers {
+		if handler, ok := h.(errorHandler); ok {
+			handler.handleError(err)
+		}
+	}
+
 	if ws.hasErrorChannel() {
 		ws.errchn <- err
 	}
 }
 
+// isAuthErr returns true if the error is caused by a failed or lost authentication.
+func isAuthErr(err *types.BitvavoErr) bool {
+	return err.Action == actionAuthenticate.Value || err.Code == errCodeAuthenticationRequired
+}
+
 func (ws *wsClient) handleEvent(e *BaseEvent, bytes []byte) {
 	log.Debug().Str("event", e.Event.Value).Msg("Handling incoming event")
 
 	switch e.Event {
 	case wsEventSubscribed:
 		log.Debug().Str("message", string(bytes)).Msg("Received subscribed event")
+
+		var subscribedEvent *SubscribedEvent
+		if err := json.Unmarshal(bytes, &subscribedEvent); err != nil {
+			log.Err(err).Str("message", string(bytes)).Msg("Couldn't unmarshal message into SubscribedEvent")
+			return
+		}
+		for _, handler := range ws.handlers {
+			handler.handleSubscribed(subscribedEvent.Subscriptions)
+		}
 	case wsEventUnsubscribed:
 		log.Debug().Str("message", string(bytes)).Msg("Received unsubscribed event")
 	default:
@@ -0,0 +1,196 @@
+package wstest
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/larscom/go-bitvavo/v2/types"
+)
+
+// Message is an event in the Bitvavo wire format which can be published on the Server.
+type Message struct {
+	channel  string
+	market   string
+	interval string
+	payload  map[string]any
+}
+
+func (m Message) key() string {
+	if m.channel == ChannelCandles {
+		return fmt.Sprintf("%s_%s", m.market, m.interval)
+	}
+	return m.market
+}
+
+// Candle creates a candle event for market with interval.
+func Candle(market string, interval string, candle types.Candle) Message {
+	return Message{
+		channel:  ChannelCandles,
+		market:   market,
+		interval: interval,
+		payload: map[string]any{
+			"event":    "candle",
+			"market":   market,
+			"interval": interval,
+			"candle": []any{
+				[]any{
+					candle.Timestamp,
+					formatFloat(candle.Open),
+					formatFloat(candle.High),
+					formatFloat(candle.Low),
+					formatFloat(candle.Close),
+					formatFloat(candle.Volume),
+				},
+			},
+		},
+	}
+}
+
+// Ticker creates a ticker event for market, zero values are omitted like Bitvavo does for unchanged values.
+func Ticker(market string, ticker types.Ticker) Message {
+	payload := map[string]any{
+		"event":  "ticker",
+		"market": market,
+	}
+	putFloat(payload, "bestBid", ticker.BestBid)
+	putFloat(payload, "bestBidSize", ticker.BestBidSize)
+	putFloat(payload, "bestAsk", ticker.BestAsk)
+	putFloat(payload, "bestAskSize", ticker.BestAskSize)
+	putFloat(payload, "lastPrice", ticker.LastPrice)
+
+	return Message{
+		channel: ChannelTicker,
+		market:  market,
+		payload: payload,
+	}
+}
+
+// Ticker24h creates a ticker24h event for market.
+func Ticker24h(market string, ticker24h types.Ticker24h) Message {
+	return Message{
+		channel: ChannelTicker24h,
+		market:  market,
+		payload: map[string]any{
+			"event": "ticker24h",
+			"data": []any{
+				map[string]any{
+					"market":         market,
+					"open":           formatFloat(ticker24h.Open),
+					"high":           formatFloat(ticker24h.High),
+					"low":            formatFloat(ticker24h.Low),
+					"last":           formatFloat(ticker24h.Last),
+					"volume":         formatFloat(ticker24h.Volume),
+					"volumeQuote":    formatFloat(ticker24h.VolumeQuote),
+					"bid":            formatFloat(ticker24h.Bid),
+					"bidSize":        formatFloat(ticker24h.BidSize),
+					"ask":            formatFloat(ticker24h.Ask),
+					"askSize":        formatFloat(ticker24h.AskSize),
+					"timestamp":      ticker24h.Timestamp,
+					"startTimestamp": ticker24h.StartTimestamp,
+					"openTimestamp":  ticker24h.OpenTimestamp,
+					"closeTimestamp": ticker24h.CloseTimestamp,
+				},
+			},
+		},
+	}
+}
+
+// Trade creates a trade event for market.
+func Trade(market string, trade types.Trade) Message {
+	return Message{
+		channel: ChannelTrades,
+		market:  market,
+		payload: map[string]any{
+			"event":     "trade",
+			"market":    market,
+			"id":        trade.Id,
+			"amount":    formatFloat(trade.Amount),
+			"price":     formatFloat(trade.Price),
+			"side":      trade.Side,
+			"timestamp": trade.Timestamp,
+		},
+	}
+}
+
+// Book creates a book event for market.
+func Book(market string, book types.Book) Message {
+	return Message{
+		channel: ChannelBook,
+		market:  market,
+		payload: map[string]any{
+			"event":  "book",
+			"market": market,
+			"nonce":  book.Nonce,
+			"bids":   formatPages(book.Bids),
+			"asks":   formatPages(book.Asks),
+		},
+	}
+}
+
+// Order creates an order event for market, only delivered to authenticated connections.
+func Order(market string, order types.Order) Message {
+	return Message{
+		channel: ChannelAccount,
+		market:  market,
+		payload: map[string]any{
+			"event":               "order",
+			"market":              market,
+			"orderId":             order.OrderId,
+			"clientOrderId":       order.ClientOrderId,
+			"created":             order.Created,
+			"updated":             order.Updated,
+			"status":              order.Status,
+			"side":                order.Side,
+			"orderType":           order.OrderType,
+			"amount":              formatFloat(order.Amount),
+			"amountRemaining":     formatFloat(order.AmountRemaining),
+			"price":               formatFloat(order.Price),
+			"onHold":              formatFloat(order.OnHold),
+			"onHoldCurrency":      order.OnHoldCurrency,
+			"timeInForce":         order.TimeInForce,
+			"postOnly":            order.PostOnly,
+			"selfTradePrevention": order.SelfTradePrevention,
+			"visible":             order.Visible,
+		},
+	}
+}
+
+// Fill creates a fill event for market, only delivered to authenticated connections.
+func Fill(market string, fill types.Fill) Message {
+	return Message{
+		channel: ChannelAccount,
+		market:  market,
+		payload: map[string]any{
+			"event":       "fill",
+			"market":      market,
+			"orderId":     fill.OrderId,
+			"fillId":      fill.FillId,
+			"timestamp":   fill.Timestamp,
+			"amount":      formatFloat(fill.Amount),
+			"side":        fill.Side,
+			"price":       formatFloat(fill.Price),
+			"taker":       fill.Taker,
+			"fee":         formatFloat(fill.Fee),
+			"feeCurrency": fill.FeeCurrency,
+			"settled":     fill.Settled,
+		},
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func putFloat(payload map[string]any, key string, f float64) {
+	if f != 0 {
+		payload[key] = formatFloat(f)
+	}
+}
+
+func formatPages(pages []types.Page) [][]string {
+	formatted := make([][]string, len(pages))
+	for i, page := range pages {
+		formatted[i] = []string{formatFloat(page.Price), formatFloat(page.Size)}
+	}
+	return formatted
+}
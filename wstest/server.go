@@ -0,0 +1,375 @@
+// Package wstest provides an in-process websocket server which speaks the Bitvavo protocol,
+// so you can integration test your consumers without connecting to the live exchange.
+//
+//	server := wstest.NewServer()
+//	defer server.Close()
+//
+//	client, _ := ws.NewWsClient(ws.WithURL(server.URL()))
+//	chn, _ := client.Ticker().Subscribe([]string{"ETH-EUR"})
+//
+//	server.WaitForSubscription(wstest.ChannelTicker, "ETH-EUR", time.Second)
+//	server.Publish(wstest.Ticker("ETH-EUR", types.Ticker{LastPrice: 1800}))
+package wstest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/gorilla/websocket"
+	"github.com/larscom/go-bitvavo/v2/crypto"
+	"github.com/larscom/go-bitvavo/v2/ws"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ChannelCandles   = "candles"
+	ChannelTicker    = "ticker"
+	ChannelTicker24h = "ticker24h"
+	ChannelTrades    = "trades"
+	ChannelBook      = "book"
+	ChannelAccount   = "account"
+)
+
+var errTimeout = errors.New("timeout while waiting for subscription")
+
+type Server struct {
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	apiKey    string
+	apiSecret string
+
+	mu    sync.RWMutex
+	conns map[*conn]struct{}
+}
+
+type Option func(*Server)
+
+// Only accept authentication messages signed with apiKey and apiSecret.
+// By default every authentication message is accepted.
+func WithCredentials(apiKey string, apiSecret string) Option {
+	return func(s *Server) {
+		s.apiKey = apiKey
+		s.apiSecret = apiSecret
+	}
+}
+
+// NewServer starts a new websocket server, call Close when you are done.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		conns: make(map[*conn]struct{}),
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// URL returns the websocket url to pass to ws.WithURL
+func (s *Server) URL() string {
+	return "ws" + strings.TrimPrefix(s.server.URL, "http")
+}
+
+// Close disconnects every client and shuts down the server.
+func (s *Server) Close() {
+	s.Disconnect()
+	s.server.Close()
+}
+
+// Disconnect closes every client connection, which is useful to test reconnects.
+func (s *Server) Disconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for c := range s.conns {
+		c.close()
+		delete(s.conns, c)
+	}
+}
+
+// ExpireAuthentication drops the authentication and account subscriptions of every connection
+// and notifies the clients with an authentication required error, like Bitvavo does when a session expires.
+func (s *Server) ExpireAuthentication() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for c := range s.conns {
+		c.expireAuthentication()
+		if err := c.write(errorMessage("subscribe", 300, "Authentication is required for sending this message.")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Publish sends the message to every connection which is subscribed to the channel and market of the message.
+func (s *Server) Publish(message Message) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for c := range s.conns {
+		if c.isSubscribed(message.channel, message.key()) {
+			if err := c.write(message.payload); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Send sends a raw message to every connection, regardless of any subscription.
+func (s *Server) Send(message any) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for c := range s.conns {
+		if err := c.write(message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Subscriptions returns the subscribed markets for channel of every connection.
+// Candle subscriptions are returned as market_interval (e.g: ETH-EUR_1h)
+func (s *Server) Subscriptions(channel string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0)
+	for c := range s.conns {
+		keys = append(keys, c.subscriptions(channel)...)
+	}
+	return keys
+}
+
+// WaitForSubscription blocks until a connection is subscribed to channel for market (or market_interval for candles).
+func (s *Server) WaitForSubscription(channel string, market string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		for _, key := range s.Subscriptions(channel) {
+			if key == market {
+				return nil
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errTimeout
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	wsconn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Err(err).Msg("Upgrade failed")
+		return
+	}
+
+	c := newConn(wsconn)
+
+	s.mu.Lock()
+	s.conns[c] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		c.close()
+	}()
+
+	for {
+		_, bytes, err := wsconn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg ws.WebSocketMessage
+		if err := json.Unmarshal(bytes, &msg); err != nil {
+			c.write(errorMessage(msg.Action, 102, "The JSON object you sent is invalid."))
+			continue
+		}
+
+		s.handleMessage(c, msg)
+	}
+}
+
+func (s *Server) handleMessage(c *conn, msg ws.WebSocketMessage) {
+	switch msg.Action {
+	case "authenticate":
+		if s.verify(msg) {
+			c.setAuthenticated()
+			c.write(map[string]any{"event": "authenticate", "authenticated": true})
+		} else {
+			c.write(errorMessage(msg.Action, 309, "The signature is invalid."))
+		}
+	case "subscribe":
+		for _, channel := range msg.Channels {
+			if channel.Name == ChannelAccount && !c.isAuthenticated() {
+				c.write(errorMessage(msg.Action, 300, "Authentication is required for sending this message."))
+				return
+			}
+		}
+		c.subscribe(msg.Channels)
+		c.write(map[string]any{"event": "subscribed", "subscriptions": c.subscriptionsMessage()})
+	case "unsubscribe":
+		c.unsubscribe(msg.Channels)
+		c.write(map[string]any{"event": "unsubscribed", "subscriptions": c.subscriptionsMessage()})
+	default:
+		c.write(errorMessage(msg.Action, 110, "Invalid endpoint. Please check url and HTTP method."))
+	}
+}
+
+func (s *Server) verify(msg ws.WebSocketMessage) bool {
+	if s.apiKey == "" && s.apiSecret == "" {
+		return true
+	}
+	return msg.Key == s.apiKey && msg.Signature == crypto.CreateSignature("GET", "/websocket", nil, msg.Timestamp, s.apiSecret)
+}
+
+func errorMessage(action string, code int, message string) map[string]any {
+	return map[string]any{"action": action, "errorCode": code, "error": message}
+}
+
+type conn struct {
+	wsconn *websocket.Conn
+
+	writemu sync.Mutex
+
+	mu            sync.RWMutex
+	authenticated bool
+	subs          map[string]map[string]struct{}
+}
+
+func newConn(wsconn *websocket.Conn) *conn {
+	return &conn{
+		wsconn: wsconn,
+		subs:   make(map[string]map[string]struct{}),
+	}
+}
+
+func (c *conn) write(message any) error {
+	c.writemu.Lock()
+	defer c.writemu.Unlock()
+	return c.wsconn.WriteJSON(message)
+}
+
+func (c *conn) close() {
+	c.wsconn.Close()
+}
+
+func (c *conn) setAuthenticated() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authenticated = true
+}
+
+func (c *conn) expireAuthentication() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authenticated = false
+	delete(c.subs, ChannelAccount)
+}
+
+func (c *conn) isAuthenticated() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.authenticated
+}
+
+func (c *conn) subscribe(channels []ws.Channel) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, channel := range channels {
+		if c.subs[channel.Name] == nil {
+			c.subs[channel.Name] = make(map[string]struct{})
+		}
+		for _, key := range channelKeys(channel) {
+			c.subs[channel.Name][key] = struct{}{}
+		}
+	}
+}
+
+func (c *conn) unsubscribe(channels []ws.Channel) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, channel := range channels {
+		for _, key := range channelKeys(channel) {
+			delete(c.subs[channel.Name], key)
+		}
+	}
+}
+
+func (c *conn) isSubscribed(channel string, key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.subs[channel][key]
+	return ok
+}
+
+func (c *conn) subscriptions(channel string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]string, 0, len(c.subs[channel]))
+	for key := range c.subs[channel] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// subscriptionsMessage returns the subscriptions in the format of the subscribed event.
+func (c *conn) subscriptionsMessage() map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	subscriptions := make(map[string]any)
+	for channel, keys := range c.subs {
+		if len(keys) == 0 {
+			continue
+		}
+		if channel == ChannelCandles {
+			intervals := make(map[string][]string)
+			for key := range keys {
+				market, interval, _ := strings.Cut(key, "_")
+				intervals[interval] = append(intervals[interval], market)
+			}
+			subscriptions[channel] = intervals
+			continue
+		}
+		markets := make([]string, 0, len(keys))
+		for key := range keys {
+			markets = append(markets, key)
+		}
+		subscriptions[channel] = markets
+	}
+	return subscriptions
+}
+
+func channelKeys(channel ws.Channel) []string {
+	if channel.Name != ChannelCandles {
+		return channel.Markets
+	}
+
+	keys := make([]string, 0, len(channel.Markets)*len(channel.Intervals))
+	for _, market := range channel.Markets {
+		for _, interval := range channel.Intervals {
+			keys = append(keys, fmt.Sprintf("%s_%s", market, interval))
+		}
+	}
+	return keys
+}